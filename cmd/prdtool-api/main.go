@@ -0,0 +1,110 @@
+// Package main provides a REST/JSON API server for the PRDs in a workspace.
+//
+// It serves the same API as 'prdtool serve', without the browser UI, for
+// tools that want plain HTTP rather than MCP. The OpenAPI description is
+// served at /api/openapi.json.
+//
+// Usage:
+//
+//	prdtool-api [-addr host:port] [-root dir] [-token token] [-read-only]
+//
+// PRDs are discovered under -root (default: the working directory). Set
+// -token or PRDTOOL_API_TOKEN to require "Authorization: Bearer <token>"
+// on every request. Responses to GET /api/prds/{id} and to every change
+// carry an ETag; send it back in If-Match to make a change only if nobody
+// else has changed the PRD in the meantime.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/agentplexus/agent-team-prd/internal/middleware"
+	"github.com/agentplexus/agent-team-prd/pkg/server"
+)
+
+// tokenEnv is the environment variable read when -token is not set.
+const tokenEnv = "PRDTOOL_API_TOKEN"
+
+// shutdownTimeout bounds how long in-flight requests get to finish after
+// a shutdown signal.
+const shutdownTimeout = 10 * time.Second
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:8090", "Listen address")
+	root := flag.String("root", ".", "Workspace directory containing the PRDs")
+	token := flag.String("token", "", "Bearer token required on every request (default: $"+tokenEnv+")")
+	readOnly := flag.Bool("read-only", false, "Reject all requests that create or modify PRDs")
+	flag.Parse()
+
+	if *token == "" {
+		*token = os.Getenv(tokenEnv)
+	}
+	if info, err := os.Stat(*root); err != nil || !info.IsDir() {
+		log.Fatalf("Workspace root is not a directory: %s", *root)
+	}
+
+	if err := run(*addr, *root, *token, *readOnly); err != nil {
+		log.Fatalf("Server error: %v", err)
+	}
+}
+
+func run(addr, root, token string, readOnly bool) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	api := server.New(root)
+	api.Addr = addr
+	api.ReadOnly = readOnly
+
+	// Only the API is served; the browser UI is part of 'prdtool serve'.
+	mux := http.NewServeMux()
+	mux.Handle("/api/", api)
+
+	var handler http.Handler = mux
+	if token != "" {
+		handler = middleware.RequireBearerToken("prdtool-api", token, handler)
+	}
+	handler = middleware.LogRequests(handler)
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.Serve(ln)
+	}()
+
+	log.Printf("prdtool-api serving %s at http://%s/api (OpenAPI: /api/openapi.json)", root, ln.Addr())
+	if token == "" {
+		log.Printf("Warning: no bearer token configured; any client that can reach %s can use the server", ln.Addr())
+	}
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Printf("Shutting down...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/agent-team-prd/pkg/service"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// The entity tools edit any section through prd.EntityKinds, the same
+// operations behind the REST API's /entities endpoints and the TUI.
+
+type ListEntitiesInput struct {
+	Path string `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
+	Kind string `json:"kind" jsonschema:"Entity kind, e.g. requirements, risks or personas (see prd_entity_kinds)"`
+}
+
+type EntityKindsOutput struct {
+	Kinds []*prd.EntityKind `json:"kinds" jsonschema:"Entity kinds and their fields"`
+}
+
+type EntitiesOutput struct {
+	Kind     string       `json:"kind" jsonschema:"Entity kind"`
+	Entities []prd.Entity `json:"entities" jsonschema:"Entities with their field values"`
+}
+
+func handleEntityKinds(_ context.Context, _ *mcp.CallToolRequest, _ struct{}) (*mcp.CallToolResult, EntityKindsOutput, error) {
+	out := EntityKindsOutput{Kinds: prd.EntityKinds()}
+	data, _ := json.MarshalIndent(out.Kinds, "", "  ")
+	return textResult(string(data)), out, nil
+}
+
+func handleListEntities(ctx context.Context, req *mcp.CallToolRequest, in ListEntitiesInput) (*mcp.CallToolResult, EntitiesOutput, error) {
+	kind, err := service.LookupEntityKind(in.Kind)
+	if err != nil {
+		return nil, EntitiesOutput{}, err
+	}
+	path, err := resolvePath(ctx, req.Session, in.Path)
+	if err != nil {
+		return nil, EntitiesOutput{}, err
+	}
+
+	p, err := prd.Load(path)
+	if err != nil {
+		return nil, EntitiesOutput{}, fmt.Errorf("failed to load PRD: %w", err)
+	}
+
+	out := EntitiesOutput{Kind: kind.Name, Entities: kind.List(p)}
+	data, _ := json.MarshalIndent(out.Entities, "", "  ")
+	return textResult(string(data)), out, nil
+}
+
+func handleUpdateEntity(ctx context.Context, req *mcp.CallToolRequest, in service.UpdateEntityRequest) (*mcp.CallToolResult, MutationOutput, error) {
+	return runMutation(ctx, req, &in.Path, func() (*service.Result, error) {
+		return prdService.UpdateEntity(in)
+	})
+}
+
+func handleRemoveEntity(ctx context.Context, req *mcp.CallToolRequest, in service.RemoveEntityRequest) (*mcp.CallToolResult, MutationOutput, error) {
+	return runMutation(ctx, req, &in.Path, func() (*service.Result, error) {
+		return prdService.RemoveEntity(in)
+	})
+}
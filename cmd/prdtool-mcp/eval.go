@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/agent-team-prd/pkg/scoring"
+	"github.com/agentplexus/agent-team-prd/pkg/service"
+	"github.com/agentplexus/structured-evaluation/evaluation"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type EvalTemplateInput struct {
+	Path    string             `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
+	Weights map[string]float64 `json:"weights,omitempty" jsonschema:"Category weight overrides keyed by category ID, e.g. {\"problem_definition\": 0.3}"`
+}
+
+type EvalMergeInput struct {
+	Path               string             `json:"path,omitempty" jsonschema:"Path to PRD file, scored when deterministic is omitted (default: PRD.json)"`
+	Deterministic      map[string]any     `json:"deterministic,omitempty" jsonschema:"Deterministic EvaluationReport (default: from prd_eval_deterministic)"`
+	LLM                map[string]any     `json:"llm" jsonschema:"EvaluationReport filled in by the LLM judge"`
+	LLMWeight          *float64           `json:"llm_weight,omitempty" jsonschema:"Share of each category score taken from the LLM judge, 0-1 (default: 0.5)"`
+	CategoryLLMWeights map[string]float64 `json:"category_llm_weights,omitempty" jsonschema:"Per-category LLM weight overrides keyed by category ID"`
+	Threshold          *float64           `json:"threshold,omitempty" jsonschema:"Score difference that flags a category for review (default: 2.0)"`
+}
+
+type RecordReviewInput struct {
+	Path   string         `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
+	Report map[string]any `json:"report" jsonschema:"Finalized EvaluationReport, e.g. from prd_eval_deterministic or the report of prd_eval_merge"`
+	DryRun bool           `json:"dry_run,omitempty" jsonschema:"Preview the change and score delta without saving (default: false)"`
+}
+
+type CategoriesOutput struct {
+	Categories []scoring.Category `json:"categories" jsonschema:"Rubric categories in weight order"`
+}
+
+func handleEvalTemplate(ctx context.Context, req *mcp.CallToolRequest, in EvalTemplateInput) (*mcp.CallToolResult, *evaluation.EvaluationReport, error) {
+	if err := scoring.ValidateWeights(in.Weights); err != nil {
+		return nil, nil, err
+	}
+
+	path, err := resolvePath(ctx, req.Session, in.Path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p, err := prd.Load(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load PRD: %w", err)
+	}
+
+	var report *evaluation.EvaluationReport
+	if len(in.Weights) == 0 {
+		report = prd.GenerateEvaluationTemplate(p, path)
+	} else {
+		report = prd.GenerateEvaluationTemplateWithWeights(p, path, in.Weights)
+	}
+	return reportResult(report)
+}
+
+func handleEvalDeterministic(ctx context.Context, req *mcp.CallToolRequest, in PathInput) (*mcp.CallToolResult, *evaluation.EvaluationReport, error) {
+	path, err := resolvePath(ctx, req.Session, in.Path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p, err := prd.Load(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load PRD: %w", err)
+	}
+
+	return reportResult(prd.ScoreToEvaluationReport(p, path))
+}
+
+func handleEvalMerge(ctx context.Context, req *mcp.CallToolRequest, in EvalMergeInput) (*mcp.CallToolResult, *scoring.MergeResult, error) {
+	llm, err := decodeReport(in.LLM)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid llm report: %w", err)
+	}
+
+	var det *evaluation.EvaluationReport
+	if in.Deterministic != nil {
+		if det, err = decodeReport(in.Deterministic); err != nil {
+			return nil, nil, fmt.Errorf("invalid deterministic report: %w", err)
+		}
+	} else {
+		path, err := resolvePath(ctx, req.Session, in.Path)
+		if err != nil {
+			return nil, nil, err
+		}
+		p, err := prd.Load(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load PRD: %w", err)
+		}
+		det = prd.ScoreToEvaluationReport(p, path)
+	}
+
+	opts := scoring.DefaultMergeOptions()
+	opts.CategoryLLMWeights = in.CategoryLLMWeights
+	opts.RerunCommand = "prd_eval_merge"
+	if in.LLMWeight != nil {
+		opts.LLMWeight = *in.LLMWeight
+	}
+	if in.Threshold != nil {
+		opts.DisagreementThreshold = *in.Threshold
+	}
+
+	result, err := scoring.MergeEvaluations(det, llm, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, _ := json.MarshalIndent(result, "", "  ")
+	return textResult(string(data)), result, nil
+}
+
+func handleRecordReview(ctx context.Context, req *mcp.CallToolRequest, in RecordReviewInput) (*mcp.CallToolResult, MutationOutput, error) {
+	report, err := decodeReport(in.Report)
+	if err != nil {
+		return nil, MutationOutput{}, fmt.Errorf("invalid report: %w", err)
+	}
+
+	return runMutation(ctx, req, &in.Path, func() (*service.Result, error) {
+		return prdService.RecordReview(service.RecordReviewRequest{Path: in.Path, Report: report, DryRun: in.DryRun})
+	})
+}
+
+func handleEvalCategories(_ context.Context, _ *mcp.CallToolRequest, _ struct{}) (*mcp.CallToolResult, CategoriesOutput, error) {
+	out := CategoriesOutput{Categories: scoring.Categories()}
+	data, _ := json.MarshalIndent(out.Categories, "", "  ")
+	return textResult(string(data)), out, nil
+}
+
+// decodeReport converts a client-supplied JSON object to an EvaluationReport.
+func decodeReport(m map[string]any) (*evaluation.EvaluationReport, error) {
+	if m == nil {
+		return nil, fmt.Errorf("report is required")
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	var report evaluation.EvaluationReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+func reportResult(report *evaluation.EvaluationReport) (*mcp.CallToolResult, *evaluation.EvaluationReport, error) {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal report: %w", err)
+	}
+	return textResult(string(data)), report, nil
+}
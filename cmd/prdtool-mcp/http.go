@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/agentplexus/agent-team-prd/internal/middleware"
+	"github.com/agentplexus/mcpkit/runtime"
+)
+
+// shutdownTimeout bounds how long in-flight requests and open streams get
+// to finish after a shutdown signal.
+const shutdownTimeout = 10 * time.Second
+
+// serveHTTP serves MCP over streamable HTTP at /mcp, and the legacy SSE
+// transport at /sse, until ctx is cancelled. All clients share one server,
+// so resource subscriptions see changes made by any of them.
+func serveHTTP(ctx context.Context, rt *runtime.Runtime, addr, token string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/mcp", rt.StreamableHTTPHandler(nil))
+	mux.Handle("/sse", rt.SSEHandler(nil))
+
+	var handler http.Handler = mux
+	if token != "" {
+		handler = middleware.RequireBearerToken("prdtool-mcp", token, handler)
+	}
+	handler = middleware.LogRequests(handler)
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.ListenAndServe()
+	}()
+
+	log.Printf("prdtool-mcp listening on http://%s/mcp", addr)
+	if token == "" {
+		log.Printf("Warning: no bearer token configured; any client that can reach %s can use the server", addr)
+	}
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Printf("Shutting down...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		// Long-lived streams don't end on their own; drop them.
+		return srv.Close()
+	}
+	return nil
+}
@@ -3,23 +3,47 @@
 // This allows AI assistants (Kiro CLI, Claude Code, etc.) to interact with PRD
 // documents through the Model Context Protocol.
 //
+// PRD documents are also published as resources, so clients can read only
+// what they need and subscribe to changes:
+//
+//	prd://{path}                  full PRD document (JSON)
+//	prd://{path}/section/{name}   a single section (JSON)
+//	prd://{path}/score            scoring results (JSON)
+//	prd://{path}/view/{type}      pm, exec or diagrams view (markdown)
+//
+// The creation, review and executive summary workflows are available as the
+// prd_creation, prd_review and exec_summary prompts.
+//
 // Usage:
 //
-//	prdtool-mcp
+//	prdtool-mcp [-transport stdio|http] [-addr host:port] [-token token]
+//	            [-root dir] [-read-only]
+//
+// By default the server communicates over stdio. With -transport http it
+// serves streamable HTTP at /mcp (and legacy SSE at /sse) so several
+// assistants can share one server. Set -token or PRDTOOL_MCP_TOKEN to
+// require "Authorization: Bearer <token>" on every request.
 //
-// The server communicates over stdio using the MCP protocol.
+// Every PRD path is confined to the workspace root: -root or
+// PRDTOOL_MCP_ROOT, else the client's roots, else the working directory.
+// The http transport requires -root.
+// Paths that escape it, directly or through a symlink, are rejected.
+// -read-only leaves out every tool that creates or modifies a PRD.
 package main
 
 import (
 	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
 	"os"
-	"strings"
+	"os/signal"
+	"syscall"
 
 	"github.com/agentplexus/agent-team-prd/pkg/prd"
 	"github.com/agentplexus/agent-team-prd/pkg/scoring"
+	"github.com/agentplexus/agent-team-prd/pkg/service"
 	"github.com/agentplexus/agent-team-prd/pkg/views"
 	"github.com/agentplexus/mcpkit/runtime"
 	"github.com/grokify/structured-plan/schema"
@@ -28,26 +52,87 @@ import (
 
 const version = "0.2.0"
 
+// tokenEnv is the environment variable read when -token is not set.
+const tokenEnv = "PRDTOOL_MCP_TOKEN"
+
+// prdService runs the tools that change PRDs, serializing changes to the
+// same file across sessions.
+var prdService = service.New()
+
 func main() {
+	transport := flag.String("transport", "stdio", "Transport: stdio or http")
+	addr := flag.String("addr", "127.0.0.1:8080", "Listen address for the http transport")
+	token := flag.String("token", "", "Bearer token required by the http transport (default: $"+tokenEnv+")")
+	root := flag.String("root", "", "Workspace root that all PRD paths are confined to; required for http (default: $"+rootEnv+", client roots, or the working directory)")
+	readOnly := flag.Bool("read-only", false, "Disable all tools that create or modify PRDs")
+	flag.Parse()
+
+	if *token == "" {
+		*token = os.Getenv(tokenEnv)
+	}
+	if *root == "" {
+		*root = os.Getenv(rootEnv)
+	}
+	if *root == "" && *transport == "http" {
+		// Client roots are only a hint from whoever connects, and every
+		// http client shares this server, so the root must be fixed.
+		log.Fatalf("-root or $%s is required with -transport http", rootEnv)
+	}
+	if *root != "" {
+		info, err := os.Stat(*root)
+		if err != nil || !info.IsDir() {
+			log.Fatalf("Workspace root is not a directory: %s", *root)
+		}
+	}
+	workspaceSandbox = newSandbox(*root)
+
+	if err := run(*transport, *addr, *token, *readOnly); err != nil {
+		log.Fatalf("Server error: %v", err)
+	}
+}
+
+func run(transport, addr, token string, readOnly bool) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	watcher := newResourceWatcher()
+
 	rt := runtime.New(&mcp.Implementation{
 		Name:    "prdtool-mcp",
 		Version: version,
-	}, nil)
+	}, &runtime.Options{
+		ServerOptions: &mcp.ServerOptions{
+			SubscribeHandler:        watcher.subscribe,
+			UnsubscribeHandler:      watcher.unsubscribe,
+			RootsListChangedHandler: workspaceSandbox.rootsChanged,
+		},
+	})
 
 	registerTools(rt)
+	if !readOnly {
+		registerWriteTools(rt)
+	}
+	registerResources(rt)
+	registerPrompts(rt)
 
-	if err := rt.ServeStdio(context.Background()); err != nil {
-		log.Fatalf("Server error: %v", err)
+	watcher.server = rt.MCPServer()
+	go watcher.run(ctx)
+
+	switch transport {
+	case "stdio":
+		if err := rt.ServeStdio(ctx); err != nil && ctx.Err() == nil {
+			return err
+		}
+		return nil
+	case "http":
+		return serveHTTP(ctx, rt, addr, token)
+	default:
+		return fmt.Errorf("unknown transport: %s (use stdio or http)", transport)
 	}
 }
 
+// registerTools registers the tools that only read PRDs.
 func registerTools(rt *runtime.Runtime) {
-	// prd_init
-	runtime.AddTool(rt, &mcp.Tool{
-		Name:        "prd_init",
-		Description: "Initialize a new PRD document with required metadata",
-	}, handleInit)
-
 	// prd_load
 	runtime.AddTool(rt, &mcp.Tool{
 		Name:        "prd_load",
@@ -63,15 +148,73 @@ func registerTools(rt *runtime.Runtime) {
 	// prd_score
 	runtime.AddTool(rt, &mcp.Tool{
 		Name:        "prd_score",
-		Description: "Score a PRD's quality against the rubric and return detailed results",
+		Description: "Score a PRD's quality against the rubric and return detailed results. Set explain to list the checks behind each category score",
 	}, handleScore)
 
+	// prd_next_actions
+	runtime.AddTool(rt, &mcp.Tool{
+		Name:        "prd_next_actions",
+		Description: "List the changes that would most improve a PRD's score, ranked by projected gain, each with the tool call that makes it",
+	}, handleNextActions)
+
 	// prd_view
 	runtime.AddTool(rt, &mcp.Tool{
 		Name:        "prd_view",
 		Description: "Generate a human-readable view of the PRD",
 	}, handleView)
 
+	// prd_schema
+	runtime.AddTool(rt, &mcp.Tool{
+		Name:        "prd_schema",
+		Description: "Get the canonical PRD JSON Schema from structured-plan",
+	}, handleSchema)
+
+	// prd_eval_template
+	runtime.AddTool(rt, &mcp.Tool{
+		Name:        "prd_eval_template",
+		Description: "Generate a structured-evaluation EvaluationReport template for an LLM judge to fill in",
+	}, handleEvalTemplate)
+
+	// prd_eval_deterministic
+	runtime.AddTool(rt, &mcp.Tool{
+		Name:        "prd_eval_deterministic",
+		Description: "Score a PRD with the rule-based scorer and return the result as a structured-evaluation EvaluationReport",
+	}, handleEvalDeterministic)
+
+	// prd_eval_merge
+	runtime.AddTool(rt, &mcp.Tool{
+		Name:        "prd_eval_merge",
+		Description: "Blend a deterministic EvaluationReport with an LLM judge's EvaluationReport per category and list the categories where they disagree",
+	}, handleEvalMerge)
+
+	// prd_eval_categories
+	runtime.AddTool(rt, &mcp.Tool{
+		Name:        "prd_eval_categories",
+		Description: "List the rubric categories with their default weights, descriptions and suggested owners",
+	}, handleEvalCategories)
+
+	// prd_entity_kinds
+	runtime.AddTool(rt, &mcp.Tool{
+		Name:        "prd_entity_kinds",
+		Description: "List the entity kinds (requirements, risks, personas, ...) and their fields, for prd_list_entities, prd_update_entity and prd_remove_entity",
+	}, handleEntityKinds)
+
+	// prd_list_entities
+	runtime.AddTool(rt, &mcp.Tool{
+		Name:        "prd_list_entities",
+		Description: "List the entities of one kind with their IDs and field values",
+	}, handleListEntities)
+}
+
+// registerWriteTools registers the tools that create or modify PRDs.
+// They are left out in read-only mode.
+func registerWriteTools(rt *runtime.Runtime) {
+	// prd_init
+	runtime.AddTool(rt, &mcp.Tool{
+		Name:        "prd_init",
+		Description: "Initialize a new PRD document with required metadata",
+	}, handleInit)
+
 	// prd_add_problem
 	runtime.AddTool(rt, &mcp.Tool{
 		Name:        "prd_add_problem",
@@ -106,6 +249,9 @@ func registerTools(rt *runtime.Runtime) {
 	runtime.AddTool(rt, &mcp.Tool{
 		Name:        "prd_add_requirement",
 		Description: "Add a functional requirement to the PRD",
+		InputSchema: enumSchema[service.AddRequirementRequest](map[string][]string{
+			"priority": prd.MoSCoWValues(),
+		}),
 	}, handleAddRequirement)
 
 	// prd_add_metric
@@ -118,12 +264,20 @@ func registerTools(rt *runtime.Runtime) {
 	runtime.AddTool(rt, &mcp.Tool{
 		Name:        "prd_add_risk",
 		Description: "Add a risk to the PRD",
+		InputSchema: enumSchema[service.AddRiskRequest](map[string][]string{
+			"probability": prd.RiskProbabilityValues(),
+			"impact":      prd.RiskImpactValues(),
+		}),
 	}, handleAddRisk)
 
 	// prd_add_nfr
 	runtime.AddTool(rt, &mcp.Tool{
 		Name:        "prd_add_nfr",
 		Description: "Add a non-functional requirement to the PRD",
+		InputSchema: enumSchema[service.AddNFRRequest](map[string][]string{
+			"category": prd.NFRCategoryValues(),
+			"priority": prd.MoSCoWValues(),
+		}),
 	}, handleAddNFR)
 
 	// prd_add_decision
@@ -138,471 +292,368 @@ func registerTools(rt *runtime.Runtime) {
 		Description: "Select a solution option and provide rationale",
 	}, handleSelectSolution)
 
+	// prd_record_review
+	runtime.AddTool(rt, &mcp.Tool{
+		Name:        "prd_record_review",
+		Description: "Record a finalized EvaluationReport's scores, decision, blockers and revision triggers in the PRD's reviews section",
+	}, handleRecordReview)
+
 	// prd_update_status
 	runtime.AddTool(rt, &mcp.Tool{
 		Name:        "prd_update_status",
-		Description: "Update the PRD status",
+		Description: "Move the PRD through the status workflow (draft → in_review → approved → deprecated). Entering review requires a valid PRD; approval requires a score of at least 8.0, no open blockers and a current sign-off from every approver (see prd_approve). Fails without saving, naming the failed gates, if the transition is not allowed",
+		InputSchema: enumSchema[service.UpdateStatusRequest](map[string][]string{
+			"status": prd.StatusValues(),
+		}),
 	}, handleUpdateStatus)
 
-	// prd_schema
+	// prd_approve
 	runtime.AddTool(rt, &mcp.Tool{
-		Name:        "prd_schema",
-		Description: "Get the canonical PRD JSON Schema from structured-plan",
-	}, handleSchema)
-}
+		Name:        "prd_approve",
+		Description: "Record an approver's approval of the PRD's current content. The sign-off is tied to a content hash and goes stale when the PRD changes materially; approval needs a current sign-off from every approver. The approver must be listed in metadata.approvers",
+	}, handleApprove)
 
-// Input types with jsonschema tags for automatic schema generation
+	// prd_reject
+	runtime.AddTool(rt, &mcp.Tool{
+		Name:        "prd_reject",
+		Description: "Record an approver's rejection of the PRD's current content, with a comment explaining it",
+	}, handleReject)
 
-type InitInput struct {
-	Title string `json:"title" jsonschema:"PRD title"`
-	Owner string `json:"owner" jsonschema:"PRD owner name"`
-	ID    string `json:"id,omitempty" jsonschema:"PRD ID (optional, auto-generated if not provided)"`
-	Path  string `json:"path,omitempty" jsonschema:"File path (default: PRD.json)"`
-}
+	// prd_patch
+	runtime.AddTool(rt, &mcp.Tool{
+		Name:        "prd_patch",
+		Description: "Edit any PRD field with RFC 6902 JSON Patch operations or an RFC 7396 merge patch. The patch is rejected without saving if it introduces validation errors",
+	}, handlePatch)
 
-type PathInput struct {
-	Path string `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
-}
+	// prd_update_entity
+	runtime.AddTool(rt, &mcp.Tool{
+		Name:        "prd_update_entity",
+		Description: "Update fields of an existing entity by kind and ID, keeping the fields that are not given",
+	}, handleUpdateEntity)
 
-type ViewInput struct {
-	Path   string `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
-	Type   string `json:"type,omitempty" jsonschema:"View type: pm or exec (default: pm)"`
-	Format string `json:"format,omitempty" jsonschema:"Output format: markdown or json (default: markdown)"`
+	// prd_remove_entity
+	runtime.AddTool(rt, &mcp.Tool{
+		Name:        "prd_remove_entity",
+		Description: "Remove an entity by kind and ID",
+	}, handleRemoveEntity)
 }
 
-type AddProblemInput struct {
-	Path       string  `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
-	Statement  string  `json:"statement" jsonschema:"Problem statement"`
-	Impact     string  `json:"impact,omitempty" jsonschema:"User impact"`
-	Confidence float64 `json:"confidence,omitempty" jsonschema:"Confidence level 0-1 (default: 0.5)"`
-}
+// Input types with jsonschema tags for automatic schema generation
 
-type AddPersonaInput struct {
-	Path       string `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
-	Name       string `json:"name" jsonschema:"Persona name"`
-	Role       string `json:"role,omitempty" jsonschema:"Persona role"`
-	PainPoints string `json:"pain_points,omitempty" jsonschema:"Pain points (comma-separated)"`
+type PathInput struct {
+	Path string `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
 }
 
-type AddGoalInput struct {
-	Path      string `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
-	Statement string `json:"statement" jsonschema:"Goal statement"`
+type ScoreInput struct {
+	Path    string `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
+	Explain bool   `json:"explain,omitempty" jsonschema:"Include each category's pass state and failed checks (default: false)"`
 }
 
-type AddNonGoalInput struct {
-	Path      string `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
-	Statement string `json:"statement" jsonschema:"Non-goal statement"`
+type NextActionsInput struct {
+	Path  string `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
+	Limit int    `json:"limit,omitempty" jsonschema:"Maximum number of actions (default: 10)"`
 }
 
-type AddSolutionInput struct {
-	Path        string `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
-	Name        string `json:"name" jsonschema:"Solution name"`
-	Description string `json:"description,omitempty" jsonschema:"Solution description"`
-	Tradeoffs   string `json:"tradeoffs,omitempty" jsonschema:"Tradeoffs (comma-separated)"`
+type ViewInput struct {
+	Path    string `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
+	Type    string `json:"type,omitempty" jsonschema:"View type: pm, exec or diagrams (default: pm)"`
+	Format  string `json:"format,omitempty" jsonschema:"Output format: markdown or json (default: markdown)"`
+	Rescore bool   `json:"rescore,omitempty" jsonschema:"Score the PRD for the exec view even if a review is recorded (default: false)"`
 }
 
-type AddRequirementInput struct {
-	Path        string `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
-	Title       string `json:"title,omitempty" jsonschema:"Requirement title"`
-	Description string `json:"description" jsonschema:"Requirement description"`
-	Priority    string `json:"priority,omitempty" jsonschema:"Priority: must, should, or could (default: should)"`
+// Output types for structured tool results
+
+// MutationOutput is returned by tools that change a PRD.
+type MutationOutput struct {
+	Path    string           `json:"path" jsonschema:"Path to the PRD file"`
+	ID      string           `json:"id,omitempty" jsonschema:"ID of the created or affected entity"`
+	Message string           `json:"message" jsonschema:"Human-readable summary"`
+	Preview *service.Preview `json:"preview,omitempty" jsonschema:"Changes that would be made, when dry_run is set"`
 }
 
-type AddMetricInput struct {
-	Path        string `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
-	Name        string `json:"name" jsonschema:"Metric name"`
-	Description string `json:"description,omitempty" jsonschema:"How the metric is calculated"`
-	Target      string `json:"target,omitempty" jsonschema:"Target value"`
+type LoadOutput struct {
+	Path string         `json:"path" jsonschema:"Path to the PRD file"`
+	PRD  map[string]any `json:"prd" jsonschema:"The PRD document"`
 }
 
-type AddRiskInput struct {
-	Path        string `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
-	Description string `json:"description" jsonschema:"Risk description"`
-	Probability string `json:"probability,omitempty" jsonschema:"Probability: low, medium, or high (default: medium)"`
-	Impact      string `json:"impact,omitempty" jsonschema:"Impact level: low, medium, high, or critical (default: medium)"`
-	Mitigation  string `json:"mitigation,omitempty" jsonschema:"Mitigation strategy"`
+type ViewOutput struct {
+	Type    string         `json:"type" jsonschema:"View type"`
+	Format  string         `json:"format" jsonschema:"Output format"`
+	Content string         `json:"content" jsonschema:"Rendered view (markdown or JSON text)"`
+	View    map[string]any `json:"view,omitempty" jsonschema:"Structured view, when format is json"`
 }
 
-type AddNFRInput struct {
-	Path        string `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
-	Category    string `json:"category,omitempty" jsonschema:"NFR category: performance, security, reliability, scalability, usability, compliance (default: performance)"`
-	Title       string `json:"title,omitempty" jsonschema:"NFR title"`
-	Requirement string `json:"requirement" jsonschema:"NFR description"`
-	Target      string `json:"target,omitempty" jsonschema:"Target value"`
-	Priority    string `json:"priority,omitempty" jsonschema:"Priority: must, should, or could (default: should)"`
+type StatusOutput struct {
+	Path    string           `json:"path" jsonschema:"Path to the PRD file"`
+	Status  string           `json:"status" jsonschema:"New PRD status"`
+	Message string           `json:"message" jsonschema:"Human-readable summary"`
+	Preview *service.Preview `json:"preview,omitempty" jsonschema:"Changes that would be made, when dry_run is set"`
+	Gates   []prd.GateResult `json:"gates,omitempty" jsonschema:"Workflow gates checked for the new status"`
 }
 
-type AddDecisionInput struct {
-	Path      string `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
-	Decision  string `json:"decision" jsonschema:"Decision made"`
-	Rationale string `json:"rationale,omitempty" jsonschema:"Rationale for decision"`
-	MadeBy    string `json:"made_by,omitempty" jsonschema:"Who made the decision"`
+// ScoreOutput is the scoring result, with the checks behind each category
+// score when explain is set.
+type ScoreOutput struct {
+	*scoring.ScoringResult
+	Explanation []scoring.CategoryExplanation `json:"explanation,omitempty" jsonschema:"Score, pass state and failed checks per category, when explain is set"`
 }
 
-type SelectSolutionInput struct {
-	Path      string `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
-	ID        string `json:"id" jsonschema:"Solution ID to select"`
-	Rationale string `json:"rationale,omitempty" jsonschema:"Selection rationale"`
+// NextActionsOutput lists the suggested changes, highest gain first.
+type NextActionsOutput struct {
+	Score   float64              `json:"score" jsonschema:"Current weighted score"`
+	Actions []scoring.NextAction `json:"actions" jsonschema:"Suggested changes; replace values in angle brackets before calling the tool"`
 }
 
-type UpdateStatusInput struct {
-	Path   string `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
-	Status string `json:"status" jsonschema:"New status: draft, in_review, approved, or deprecated"`
+type SchemaOutput struct {
+	ID     string         `json:"id" jsonschema:"Schema ID/URL"`
+	Schema map[string]any `json:"schema,omitempty" jsonschema:"The PRD JSON Schema"`
 }
 
 // Handler functions
 
-func handleInit(_ context.Context, _ *mcp.CallToolRequest, in InitInput) (*mcp.CallToolResult, any, error) {
-	path := defaultPath(in.Path)
-
-	if in.Title == "" || in.Owner == "" {
-		return nil, nil, fmt.Errorf("title and owner are required")
-	}
-
-	if _, err := os.Stat(path); err == nil {
-		return nil, nil, fmt.Errorf("PRD file already exists: %s", path)
-	}
-
-	id := in.ID
-	if id == "" {
-		id = prd.GenerateID()
-	}
-
-	// Create Person from owner name
-	owner := prd.Person{Name: in.Owner}
-	newPRD := prd.New(id, in.Title, owner)
-	if err := prd.Save(newPRD, path); err != nil {
-		return nil, nil, fmt.Errorf("failed to save PRD: %w", err)
-	}
-
-	return textResult(fmt.Sprintf("Created new PRD: %s\nID: %s\nTitle: %s\nOwner: %s", path, id, in.Title, in.Owner)), nil, nil
+func handleInit(ctx context.Context, req *mcp.CallToolRequest, in service.InitRequest) (*mcp.CallToolResult, MutationOutput, error) {
+	return runMutation(ctx, req, &in.Path, func() (*service.Result, error) {
+		return prdService.Init(in)
+	})
 }
 
-func handleLoad(_ context.Context, _ *mcp.CallToolRequest, in PathInput) (*mcp.CallToolResult, any, error) {
-	path := defaultPath(in.Path)
+func handleLoad(ctx context.Context, req *mcp.CallToolRequest, in PathInput) (*mcp.CallToolResult, LoadOutput, error) {
+	path, err := resolvePath(ctx, req.Session, in.Path)
+	if err != nil {
+		return nil, LoadOutput{}, err
+	}
 
 	p, err := prd.Load(path)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to load PRD: %w", err)
+		return nil, LoadOutput{}, fmt.Errorf("failed to load PRD: %w", err)
 	}
 
 	data, err := json.MarshalIndent(p, "", "  ")
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal PRD: %w", err)
+		return nil, LoadOutput{}, fmt.Errorf("failed to marshal PRD: %w", err)
 	}
 
-	return textResult(string(data)), nil, nil
-}
-
-func handleValidate(_ context.Context, _ *mcp.CallToolRequest, in PathInput) (*mcp.CallToolResult, any, error) {
-	path := defaultPath(in.Path)
-
-	p, err := prd.Load(path)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to load PRD: %w", err)
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, LoadOutput{}, fmt.Errorf("failed to decode PRD: %w", err)
 	}
 
-	result := prd.Validate(p)
-	data, _ := json.MarshalIndent(result, "", "  ")
-	return textResult(string(data)), nil, nil
+	return textResult(string(data)), LoadOutput{Path: path, PRD: doc}, nil
 }
 
-func handleScore(_ context.Context, _ *mcp.CallToolRequest, in PathInput) (*mcp.CallToolResult, any, error) {
-	path := defaultPath(in.Path)
+func handleValidate(ctx context.Context, req *mcp.CallToolRequest, in PathInput) (*mcp.CallToolResult, *prd.ValidationResult, error) {
+	path, err := resolvePath(ctx, req.Session, in.Path)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	p, err := prd.Load(path)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to load PRD: %w", err)
 	}
 
-	result := scoring.Score(p)
+	result := prd.Validate(p)
 	data, _ := json.MarshalIndent(result, "", "  ")
-	return textResult(string(data)), nil, nil
+	return textResult(string(data)), result, nil
 }
 
-func handleView(_ context.Context, _ *mcp.CallToolRequest, in ViewInput) (*mcp.CallToolResult, any, error) {
-	path := defaultPath(in.Path)
-	viewType := defaultString(in.Type, "pm")
-	format := defaultString(in.Format, "markdown")
-
-	p, err := prd.Load(path)
+func handleScore(ctx context.Context, req *mcp.CallToolRequest, in ScoreInput) (*mcp.CallToolResult, ScoreOutput, error) {
+	path, err := resolvePath(ctx, req.Session, in.Path)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to load PRD: %w", err)
-	}
-
-	var output string
-	switch viewType {
-	case "pm":
-		view := views.GeneratePMView(p)
-		if format == "json" {
-			output, _ = views.ToJSON(view)
-		} else {
-			output = views.RenderPMMarkdown(view)
-		}
-	case "exec":
-		scores := scoring.Score(p)
-		view := views.GenerateExecView(p, scores)
-		if format == "json" {
-			output, _ = views.ToJSON(view)
-		} else {
-			output = views.RenderExecMarkdown(view)
-		}
-	default:
-		return nil, nil, fmt.Errorf("unknown view type: %s", viewType)
+		return nil, ScoreOutput{}, err
 	}
 
-	return textResult(output), nil, nil
-}
-
-func handleAddProblem(_ context.Context, _ *mcp.CallToolRequest, in AddProblemInput) (*mcp.CallToolResult, any, error) {
-	path := defaultPath(in.Path)
-
 	p, err := prd.Load(path)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to load PRD: %w", err)
+		return nil, ScoreOutput{}, fmt.Errorf("failed to load PRD: %w", err)
 	}
 
-	confidence := in.Confidence
-	if confidence == 0 {
-		confidence = 0.5
+	out := ScoreOutput{ScoringResult: scoring.Score(p)}
+	if in.Explain {
+		out.Explanation = scoring.Explain(p)
 	}
-
-	prd.SetProblemStatement(p, in.Statement, in.Impact, confidence)
-	id := "PROB-1"
-	if p.Problem != nil {
-		id = p.Problem.ID
-	}
-
-	if err := prd.Save(p, path); err != nil {
-		return nil, nil, fmt.Errorf("failed to save PRD: %w", err)
-	}
-
-	return textResult(fmt.Sprintf("Set problem statement: %s", id)), nil, nil
+	data, _ := json.MarshalIndent(out, "", "  ")
+	return textResult(string(data)), out, nil
 }
 
-func handleAddPersona(_ context.Context, _ *mcp.CallToolRequest, in AddPersonaInput) (*mcp.CallToolResult, any, error) {
-	path := defaultPath(in.Path)
-
-	p, err := prd.Load(path)
+func handleNextActions(ctx context.Context, req *mcp.CallToolRequest, in NextActionsInput) (*mcp.CallToolResult, NextActionsOutput, error) {
+	path, err := resolvePath(ctx, req.Session, in.Path)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to load PRD: %w", err)
+		return nil, NextActionsOutput{}, err
 	}
 
-	var painPoints []string
-	if in.PainPoints != "" {
-		painPoints = splitAndTrim(in.PainPoints)
-	}
-
-	id := prd.AddPersona(p, in.Name, in.Role, painPoints)
-
-	if err := prd.Save(p, path); err != nil {
-		return nil, nil, fmt.Errorf("failed to save PRD: %w", err)
-	}
-
-	return textResult(fmt.Sprintf("Added persona: %s (%s)", in.Name, id)), nil, nil
-}
-
-func handleAddGoal(_ context.Context, _ *mcp.CallToolRequest, in AddGoalInput) (*mcp.CallToolResult, any, error) {
-	path := defaultPath(in.Path)
-
 	p, err := prd.Load(path)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to load PRD: %w", err)
+		return nil, NextActionsOutput{}, fmt.Errorf("failed to load PRD: %w", err)
 	}
 
-	id := prd.AddProductGoal(p, in.Statement, "")
-
-	if err := prd.Save(p, path); err != nil {
-		return nil, nil, fmt.Errorf("failed to save PRD: %w", err)
+	limit := in.Limit
+	if limit <= 0 {
+		limit = 10
 	}
-
-	return textResult(fmt.Sprintf("Added goal: %s", id)), nil, nil
-}
-
-func handleAddNonGoal(_ context.Context, _ *mcp.CallToolRequest, in AddNonGoalInput) (*mcp.CallToolResult, any, error) {
-	path := defaultPath(in.Path)
-
-	p, err := prd.Load(path)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to load PRD: %w", err)
+	out := NextActionsOutput{
+		Score:   scoring.Score(p).WeightedScore,
+		Actions: scoring.NextActions(p, in.Path),
 	}
-
-	prd.AddOutOfScope(p, in.Statement)
-
-	if err := prd.Save(p, path); err != nil {
-		return nil, nil, fmt.Errorf("failed to save PRD: %w", err)
+	if len(out.Actions) > limit {
+		out.Actions = out.Actions[:limit]
 	}
-
-	return textResult("Added non-goal"), nil, nil
+	data, _ := json.MarshalIndent(out, "", "  ")
+	return textResult(string(data)), out, nil
 }
 
-func handleAddSolution(_ context.Context, _ *mcp.CallToolRequest, in AddSolutionInput) (*mcp.CallToolResult, any, error) {
-	path := defaultPath(in.Path)
-
-	p, err := prd.Load(path)
+func handleView(ctx context.Context, req *mcp.CallToolRequest, in ViewInput) (*mcp.CallToolResult, ViewOutput, error) {
+	path, err := resolvePath(ctx, req.Session, in.Path)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to load PRD: %w", err)
-	}
-
-	var tradeoffs []string
-	if in.Tradeoffs != "" {
-		tradeoffs = splitAndTrim(in.Tradeoffs)
+		return nil, ViewOutput{}, err
 	}
-
-	id := prd.AddSolution(p, in.Name, in.Description, tradeoffs)
-
-	if err := prd.Save(p, path); err != nil {
-		return nil, nil, fmt.Errorf("failed to save PRD: %w", err)
-	}
-
-	return textResult(fmt.Sprintf("Added solution option: %s (%s)", in.Name, id)), nil, nil
-}
-
-func handleAddRequirement(_ context.Context, _ *mcp.CallToolRequest, in AddRequirementInput) (*mcp.CallToolResult, any, error) {
-	path := defaultPath(in.Path)
+	viewType := defaultString(in.Type, "pm")
+	format := defaultString(in.Format, "markdown")
 
 	p, err := prd.Load(path)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to load PRD: %w", err)
+		return nil, ViewOutput{}, fmt.Errorf("failed to load PRD: %w", err)
 	}
 
-	priority := prd.ParseMoSCoW(defaultString(in.Priority, "should"))
-	title := in.Title
-	if title == "" {
-		title = in.Description
-		if len(title) > 50 {
-			title = title[:50] + "..."
+	var view interface{}
+	var markdown string
+	switch viewType {
+	case "pm":
+		view = views.GeneratePMView(p)
+		if markdown, err = views.RenderPMViewMarkdown(p); err != nil {
+			return nil, ViewOutput{}, fmt.Errorf("failed to render PM view: %w", err)
 		}
+	case "diagrams":
+		view = views.GenerateDiagrams(p)
+		markdown = views.RenderDiagramsViewMarkdown(p)
+	case "exec":
+		scores := scoring.ReviewOrScore(p)
+		if in.Rescore {
+			scores = scoring.Score(p)
+		}
+		execView, err := views.GenerateExecView(p, scores)
+		if err != nil {
+			return nil, ViewOutput{}, fmt.Errorf("failed to generate exec view: %w", err)
+		}
+		view = execView
+		markdown = views.RenderExecMarkdown(execView)
+	default:
+		return nil, ViewOutput{}, fmt.Errorf("unknown view type: %s (valid: pm, exec, diagrams)", viewType)
 	}
 
-	id := prd.AddFunctionalRequirement(p, title, in.Description, priority)
-
-	if err := prd.Save(p, path); err != nil {
-		return nil, nil, fmt.Errorf("failed to save PRD: %w", err)
+	out := ViewOutput{Type: viewType, Format: format, Content: markdown}
+	if format == "json" {
+		output, err := views.ToJSON(view)
+		if err != nil {
+			return nil, ViewOutput{}, fmt.Errorf("failed to generate JSON: %w", err)
+		}
+		if err := json.Unmarshal([]byte(output), &out.View); err != nil {
+			return nil, ViewOutput{}, fmt.Errorf("failed to decode view: %w", err)
+		}
+		out.Content = output
 	}
 
-	return textResult(fmt.Sprintf("Added requirement: %s (%s)", id, priority)), nil, nil
+	return textResult(out.Content), out, nil
 }
 
-func handleAddMetric(_ context.Context, _ *mcp.CallToolRequest, in AddMetricInput) (*mcp.CallToolResult, any, error) {
-	path := defaultPath(in.Path)
-
-	p, err := prd.Load(path)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to load PRD: %w", err)
-	}
-
-	id := prd.AddSuccessMetric(p, in.Name, in.Description, in.Target)
-
-	if err := prd.Save(p, path); err != nil {
-		return nil, nil, fmt.Errorf("failed to save PRD: %w", err)
-	}
-
-	return textResult(fmt.Sprintf("Added metric: %s (%s)", in.Name, id)), nil, nil
+func handleAddProblem(ctx context.Context, req *mcp.CallToolRequest, in service.AddProblemRequest) (*mcp.CallToolResult, MutationOutput, error) {
+	return runMutation(ctx, req, &in.Path, func() (*service.Result, error) {
+		return prdService.AddProblem(in)
+	})
 }
 
-func handleAddRisk(_ context.Context, _ *mcp.CallToolRequest, in AddRiskInput) (*mcp.CallToolResult, any, error) {
-	path := defaultPath(in.Path)
-
-	p, err := prd.Load(path)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to load PRD: %w", err)
-	}
-
-	probability := prd.ParseRiskProbability(defaultString(in.Probability, "medium"))
-	impact := prd.ParseRiskImpact(defaultString(in.Impact, "medium"))
-	id := prd.AddRisk(p, in.Description, probability, impact, in.Mitigation)
-
-	if err := prd.Save(p, path); err != nil {
-		return nil, nil, fmt.Errorf("failed to save PRD: %w", err)
-	}
-
-	return textResult(fmt.Sprintf("Added risk: %s (%s impact)", id, impact)), nil, nil
+func handleAddPersona(ctx context.Context, req *mcp.CallToolRequest, in service.AddPersonaRequest) (*mcp.CallToolResult, MutationOutput, error) {
+	return runMutation(ctx, req, &in.Path, func() (*service.Result, error) {
+		return prdService.AddPersona(in)
+	})
 }
 
-func handleAddNFR(_ context.Context, _ *mcp.CallToolRequest, in AddNFRInput) (*mcp.CallToolResult, any, error) {
-	path := defaultPath(in.Path)
-
-	p, err := prd.Load(path)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to load PRD: %w", err)
-	}
-
-	category := prd.ParseNFRCategory(defaultString(in.Category, "performance"))
-	priority := prd.ParseMoSCoW(defaultString(in.Priority, "should"))
-	title := in.Title
-	if title == "" {
-		title = in.Requirement
-		if len(title) > 50 {
-			title = title[:50] + "..."
-		}
-	}
-
-	id := prd.AddNonFunctionalRequirement(p, category, title, in.Requirement, in.Target, priority)
-
-	if err := prd.Save(p, path); err != nil {
-		return nil, nil, fmt.Errorf("failed to save PRD: %w", err)
-	}
-
-	return textResult(fmt.Sprintf("Added NFR: %s (%s)", id, category)), nil, nil
+func handleAddGoal(ctx context.Context, req *mcp.CallToolRequest, in service.AddGoalRequest) (*mcp.CallToolResult, MutationOutput, error) {
+	return runMutation(ctx, req, &in.Path, func() (*service.Result, error) {
+		return prdService.AddGoal(in)
+	})
 }
 
-func handleAddDecision(_ context.Context, _ *mcp.CallToolRequest, in AddDecisionInput) (*mcp.CallToolResult, any, error) {
-	path := defaultPath(in.Path)
-
-	p, err := prd.Load(path)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to load PRD: %w", err)
-	}
+func handleAddNonGoal(ctx context.Context, req *mcp.CallToolRequest, in service.AddNonGoalRequest) (*mcp.CallToolResult, MutationOutput, error) {
+	return runMutation(ctx, req, &in.Path, func() (*service.Result, error) {
+		return prdService.AddNonGoal(in)
+	})
+}
 
-	id := prd.AddDecision(p, in.Decision, in.Rationale, in.MadeBy)
+func handleAddSolution(ctx context.Context, req *mcp.CallToolRequest, in service.AddSolutionRequest) (*mcp.CallToolResult, MutationOutput, error) {
+	return runMutation(ctx, req, &in.Path, func() (*service.Result, error) {
+		return prdService.AddSolution(in)
+	})
+}
 
-	if err := prd.Save(p, path); err != nil {
-		return nil, nil, fmt.Errorf("failed to save PRD: %w", err)
-	}
+func handleAddRequirement(ctx context.Context, req *mcp.CallToolRequest, in service.AddRequirementRequest) (*mcp.CallToolResult, MutationOutput, error) {
+	return runMutation(ctx, req, &in.Path, func() (*service.Result, error) {
+		return prdService.AddRequirement(in)
+	})
+}
 
-	return textResult(fmt.Sprintf("Added decision: %s", id)), nil, nil
+func handleAddMetric(ctx context.Context, req *mcp.CallToolRequest, in service.AddMetricRequest) (*mcp.CallToolResult, MutationOutput, error) {
+	return runMutation(ctx, req, &in.Path, func() (*service.Result, error) {
+		return prdService.AddMetric(in)
+	})
 }
 
-func handleSelectSolution(_ context.Context, _ *mcp.CallToolRequest, in SelectSolutionInput) (*mcp.CallToolResult, any, error) {
-	path := defaultPath(in.Path)
+func handleAddRisk(ctx context.Context, req *mcp.CallToolRequest, in service.AddRiskRequest) (*mcp.CallToolResult, MutationOutput, error) {
+	return runMutation(ctx, req, &in.Path, func() (*service.Result, error) {
+		return prdService.AddRisk(in)
+	})
+}
 
-	p, err := prd.Load(path)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to load PRD: %w", err)
-	}
+func handleAddNFR(ctx context.Context, req *mcp.CallToolRequest, in service.AddNFRRequest) (*mcp.CallToolResult, MutationOutput, error) {
+	return runMutation(ctx, req, &in.Path, func() (*service.Result, error) {
+		return prdService.AddNFR(in)
+	})
+}
 
-	if !prd.SelectSolution(p, in.ID, in.Rationale) {
-		return nil, nil, fmt.Errorf("solution not found: %s", in.ID)
-	}
+func handleAddDecision(ctx context.Context, req *mcp.CallToolRequest, in service.AddDecisionRequest) (*mcp.CallToolResult, MutationOutput, error) {
+	return runMutation(ctx, req, &in.Path, func() (*service.Result, error) {
+		return prdService.AddDecision(in)
+	})
+}
 
-	if err := prd.Save(p, path); err != nil {
-		return nil, nil, fmt.Errorf("failed to save PRD: %w", err)
-	}
+func handleSelectSolution(ctx context.Context, req *mcp.CallToolRequest, in service.SelectSolutionRequest) (*mcp.CallToolResult, MutationOutput, error) {
+	return runMutation(ctx, req, &in.Path, func() (*service.Result, error) {
+		return prdService.SelectSolution(in)
+	})
+}
 
-	return textResult(fmt.Sprintf("Selected solution: %s", in.ID)), nil, nil
+func handleApprove(ctx context.Context, req *mcp.CallToolRequest, in service.SignOffRequest) (*mcp.CallToolResult, MutationOutput, error) {
+	return runMutation(ctx, req, &in.Path, func() (*service.Result, error) {
+		return prdService.Approve(in)
+	})
 }
 
-func handleUpdateStatus(_ context.Context, _ *mcp.CallToolRequest, in UpdateStatusInput) (*mcp.CallToolResult, any, error) {
-	path := defaultPath(in.Path)
+func handleReject(ctx context.Context, req *mcp.CallToolRequest, in service.SignOffRequest) (*mcp.CallToolResult, MutationOutput, error) {
+	return runMutation(ctx, req, &in.Path, func() (*service.Result, error) {
+		return prdService.Reject(in)
+	})
+}
 
-	p, err := prd.Load(path)
+func handleUpdateStatus(ctx context.Context, req *mcp.CallToolRequest, in service.UpdateStatusRequest) (*mcp.CallToolResult, StatusOutput, error) {
+	path, err := resolvePath(ctx, req.Session, in.Path)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to load PRD: %w", err)
+		return nil, StatusOutput{}, err
 	}
+	in.Path = path
 
-	status, ok := prd.ParseStatus(in.Status)
-	if !ok {
-		return nil, nil, fmt.Errorf("unknown status: %s", in.Status)
+	res, err := prdService.UpdateStatus(in)
+	if err != nil {
+		return nil, StatusOutput{}, err
 	}
 
-	prd.UpdateStatus(p, status)
-
-	if err := prd.Save(p, path); err != nil {
-		return nil, nil, fmt.Errorf("failed to save PRD: %w", err)
-	}
+	message := previewMessage(res.Message, res.Preview)
+	return textResult(message), StatusOutput{Path: res.Path, Status: string(res.PRD.Metadata.Status), Message: message, Preview: res.Preview, Gates: res.Gates}, nil
+}
 
-	return textResult(fmt.Sprintf("Updated status to: %s", status)), nil, nil
+func handlePatch(ctx context.Context, req *mcp.CallToolRequest, in service.PatchRequest) (*mcp.CallToolResult, MutationOutput, error) {
+	return runMutation(ctx, req, &in.Path, func() (*service.Result, error) {
+		return prdService.Patch(in)
+	})
 }
 
 // Helper functions
@@ -621,16 +672,27 @@ func defaultString(s, def string) string {
 	return s
 }
 
-func splitAndTrim(s string) []string {
-	parts := strings.Split(s, ",")
-	result := make([]string, 0, len(parts))
-	for _, part := range parts {
-		trimmed := strings.TrimSpace(part)
-		if trimmed != "" {
-			result = append(result, trimmed)
-		}
+// runMutation confines *path to the workspace and runs op, which sees the
+// resolved path through the request it captured.
+func runMutation(ctx context.Context, req *mcp.CallToolRequest, path *string, op func() (*service.Result, error)) (*mcp.CallToolResult, MutationOutput, error) {
+	resolved, err := resolvePath(ctx, req.Session, *path)
+	if err != nil {
+		return nil, MutationOutput{}, err
 	}
-	return result
+	*path = resolved
+
+	res, err := op()
+	if err != nil {
+		return nil, MutationOutput{}, err
+	}
+	return mutationResult(res)
+}
+
+// mutationResult returns the text and structured results for a tool that
+// changed a PRD, or previewed a change when res has a preview.
+func mutationResult(res *service.Result) (*mcp.CallToolResult, MutationOutput, error) {
+	message := previewMessage(res.Message, res.Preview)
+	return textResult(message), MutationOutput{Path: res.Path, ID: res.ID, Message: message, Preview: res.Preview}, nil
 }
 
 func textResult(text string) *mcp.CallToolResult {
@@ -644,9 +706,15 @@ type SchemaInput struct {
 	IDOnly bool `json:"id_only,omitempty" jsonschema:"Return only the schema ID/URL (default: false)"`
 }
 
-func handleSchema(_ context.Context, _ *mcp.CallToolRequest, in SchemaInput) (*mcp.CallToolResult, any, error) {
+func handleSchema(_ context.Context, _ *mcp.CallToolRequest, in SchemaInput) (*mcp.CallToolResult, SchemaOutput, error) {
 	if in.IDOnly {
-		return textResult(schema.PRDSchemaID), nil, nil
+		return textResult(schema.PRDSchemaID), SchemaOutput{ID: schema.PRDSchemaID}, nil
+	}
+
+	text := schema.PRDSchema()
+	out := SchemaOutput{ID: schema.PRDSchemaID}
+	if err := json.Unmarshal([]byte(text), &out.Schema); err != nil {
+		return nil, SchemaOutput{}, fmt.Errorf("failed to decode schema: %w", err)
 	}
-	return textResult(schema.PRDSchema()), nil, nil
+	return textResult(text), out, nil
 }
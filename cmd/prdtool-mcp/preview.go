@@ -0,0 +1,16 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/agentplexus/agent-team-prd/pkg/service"
+)
+
+// previewMessage marks the message of a dry run as not saved.
+func previewMessage(message string, preview *service.Preview) string {
+	if preview == nil {
+		return message
+	}
+	return fmt.Sprintf("Dry run, not saved: %s\nChanges: %d, score %.1f → %.1f (%+.1f)",
+		message, len(preview.Changes), preview.Score.Before, preview.Score.After, preview.Score.Change)
+}
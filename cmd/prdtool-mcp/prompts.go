@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/agent-team-prd/pkg/workflows"
+	"github.com/agentplexus/mcpkit/runtime"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+var (
+	pathArgument = &mcp.PromptArgument{
+		Name:        "path",
+		Description: "Path to PRD file (default: PRD.json)",
+	}
+	focusArgument = &mcp.PromptArgument{
+		Name:        "focus",
+		Description: "Scoring category to concentrate on, e.g. problem_definition, metrics_quality",
+	}
+	audienceArgument = &mcp.PromptArgument{
+		Name:        "audience",
+		Description: "Intended readers, e.g. executive team, board, engineering leads",
+	}
+)
+
+func registerPrompts(rt *runtime.Runtime) {
+	arguments := map[string][]*mcp.PromptArgument{
+		workflows.NameCreation:    {pathArgument, focusArgument},
+		workflows.NameReview:      {pathArgument, focusArgument},
+		workflows.NameExecSummary: {pathArgument, audienceArgument},
+	}
+
+	for _, w := range workflows.All() {
+		rt.AddPrompt(&mcp.Prompt{
+			Name:        promptName(w),
+			Title:       w.Title,
+			Description: w.Description,
+			Arguments:   arguments[w.Name],
+		}, handlePrompt(w))
+	}
+}
+
+// promptName converts a workflow name such as "prd-review" to the
+// underscore style used by the server's tools ("prd_review").
+func promptName(w workflows.Workflow) string {
+	return strings.ReplaceAll(w.Name, "-", "_")
+}
+
+func handlePrompt(w workflows.Workflow) mcp.PromptHandler {
+	return func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		args := req.Params.Arguments
+		path, err := resolvePath(ctx, req.Session, args["path"])
+		if err != nil {
+			return nil, err
+		}
+
+		// The creation workflow starts before the PRD exists.
+		p, err := prd.Load(path)
+		if errors.Is(err, fs.ErrNotExist) && w.Name == workflows.NameCreation {
+			p = nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to load PRD: %w", err)
+		}
+
+		text, err := workflows.Render(w, p, workflows.Options{
+			Path:     path,
+			Focus:    args["focus"],
+			Audience: args["audience"],
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &mcp.GetPromptResult{
+			Description: w.Description,
+			Messages: []*mcp.PromptMessage{
+				{Role: "user", Content: &mcp.TextContent{Text: text}},
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,344 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/agent-team-prd/pkg/scoring"
+	"github.com/agentplexus/agent-team-prd/pkg/views"
+	"github.com/agentplexus/mcpkit/runtime"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Resource URIs have the form prd://{path}[/score|/section/{name}|/view/{type}],
+// where path is the PRD file path ending in .json.
+var resourceURIPattern = regexp.MustCompile(`^prd://(.+?\.json)(?:/(score)|/(section)/([a-z_]+)|/(view)/([a-z]+))?$`)
+
+// pollInterval is how often subscribed PRD files are checked for changes.
+const pollInterval = 2 * time.Second
+
+// resourceSections lists the section names accepted by prd://{path}/section/{name}.
+var resourceSections = []string{
+	"metadata", "problem", "personas", "market", "objectives", "solution",
+	"requirements", "ux", "technical", "risks", "decisions", "dependencies",
+	"approvals",
+}
+
+// resourceRef is a parsed PRD resource URI.
+type resourceRef struct {
+	URI     string
+	Path    string
+	Kind    string // document, score, section or view
+	Section string
+	View    string
+}
+
+func parseResourceURI(uri string) (*resourceRef, error) {
+	m := resourceURIPattern.FindStringSubmatch(uri)
+	if m == nil {
+		return nil, fmt.Errorf("invalid PRD resource URI: %s", uri)
+	}
+	ref := &resourceRef{URI: uri, Path: m[1], Kind: "document"}
+	switch {
+	case m[2] == "score":
+		ref.Kind = "score"
+	case m[3] == "section":
+		ref.Kind = "section"
+		ref.Section = m[4]
+	case m[5] == "view":
+		ref.Kind = "view"
+		ref.View = m[6]
+	}
+	return ref, nil
+}
+
+func registerResources(rt *runtime.Runtime) {
+	rt.AddResource(&mcp.Resource{
+		URI:         "prd://PRD.json",
+		Name:        "prd",
+		Title:       "PRD.json",
+		Description: "The default PRD document in the server's working directory",
+		MIMEType:    "application/json",
+	}, handleReadResource)
+
+	rt.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: "prd://{+path}",
+		Name:        "prd_document",
+		Description: "A PRD document as JSON",
+		MIMEType:    "application/json",
+	}, handleReadResource)
+
+	rt.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: "prd://{+path}/section/{name}",
+		Name:        "prd_section",
+		Description: "A single PRD section as JSON. Sections: metadata, problem, personas, market, objectives, solution, requirements, ux, technical, risks, decisions, dependencies, approvals",
+		MIMEType:    "application/json",
+	}, handleReadResource)
+
+	rt.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: "prd://{+path}/score",
+		Name:        "prd_score",
+		Description: "Quality scoring results for a PRD as JSON",
+		MIMEType:    "application/json",
+	}, handleReadResource)
+
+	rt.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: "prd://{+path}/view/{type}",
+		Name:        "prd_view",
+		Description: "A rendered markdown view of a PRD. Types: pm, exec, diagrams",
+		MIMEType:    "text/markdown",
+	}, handleReadResource)
+}
+
+// handleReadResource serves every prd:// resource. All templates share this
+// handler because prd://{+path} also matches the more specific URIs.
+func handleReadResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	uri := req.Params.URI
+	ref, err := parseResourceURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := resolvePath(ctx, req.Session, ref.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := prd.Load(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, mcp.ResourceNotFoundError(uri)
+		}
+		return nil, fmt.Errorf("failed to load PRD: %w", err)
+	}
+
+	var data interface{}
+	switch ref.Kind {
+	case "document":
+		data = p
+	case "score":
+		data = scoring.Score(p)
+	case "section":
+		data, err = sectionData(p, ref.Section)
+		if err != nil {
+			return nil, err
+		}
+	case "view":
+		text, err := renderView(p, ref.View)
+		if err != nil {
+			return nil, err
+		}
+		return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{
+			{URI: uri, MIMEType: "text/markdown", Text: text},
+		}}, nil
+	}
+
+	out, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal resource: %w", err)
+	}
+	return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{
+		{URI: uri, MIMEType: "application/json", Text: string(out)},
+	}}, nil
+}
+
+func sectionData(p *prd.PRD, section string) (interface{}, error) {
+	switch section {
+	case "metadata":
+		return p.Metadata, nil
+	case "problem":
+		return p.Problem, nil
+	case "personas", "users":
+		return p.Personas, nil
+	case "market":
+		return p.Market, nil
+	case "objectives", "goals":
+		return map[string]interface{}{
+			"okrs":         p.Objectives.OKRs,
+			"out_of_scope": p.OutOfScope,
+		}, nil
+	case "solution":
+		return p.Solution, nil
+	case "requirements":
+		return p.Requirements, nil
+	case "ux":
+		return p.UXRequirements, nil
+	case "technical":
+		return p.TechArchitecture, nil
+	case "risks":
+		return map[string]interface{}{
+			"risks":       p.Risks,
+			"assumptions": p.Assumptions,
+		}, nil
+	case "decisions":
+		return p.Decisions, nil
+	case "dependencies":
+		return prd.GetDependencies(p)
+	case "approvals":
+		return prd.ApprovalStatus(p)
+	default:
+		return nil, fmt.Errorf("unknown section: %s (valid: %v)", section, resourceSections)
+	}
+}
+
+func renderView(p *prd.PRD, viewType string) (string, error) {
+	switch viewType {
+	case "pm":
+		return views.RenderPMViewMarkdown(p)
+	case "exec":
+		view, err := views.GenerateExecView(p, scoring.ReviewOrScore(p))
+		if err != nil {
+			return "", err
+		}
+		return views.RenderExecMarkdown(view), nil
+	case "diagrams":
+		return views.RenderDiagramsViewMarkdown(p), nil
+	default:
+		return "", fmt.Errorf("unknown view type: %s (valid: pm, exec, diagrams)", viewType)
+	}
+}
+
+// resourceWatcher tracks resource subscriptions and sends
+// notifications/resources/updated when a subscribed PRD file changes on disk,
+// whether it was changed by a tool call or by another process.
+//
+// Subscriptions are kept per session, since sessions with different roots
+// can subscribe to the same URI for different files, and one session
+// unsubscribing must not end another's subscription.
+type resourceWatcher struct {
+	mu       sync.Mutex
+	server   *mcp.Server
+	subs     map[subscriptionKey]string  // subscription -> resolved PRD path
+	sessions map[*mcp.ServerSession]bool // sessions with subscriptions
+	modTimes map[string]time.Time        // PRD path -> last seen modification time
+}
+
+type subscriptionKey struct {
+	session *mcp.ServerSession
+	uri     string
+}
+
+func newResourceWatcher() *resourceWatcher {
+	return &resourceWatcher{
+		subs:     make(map[subscriptionKey]string),
+		sessions: make(map[*mcp.ServerSession]bool),
+		modTimes: make(map[string]time.Time),
+	}
+}
+
+func (w *resourceWatcher) subscribe(ctx context.Context, req *mcp.SubscribeRequest) error {
+	ref, err := parseResourceURI(req.Params.URI)
+	if err != nil {
+		return err
+	}
+	path, err := resolvePath(ctx, req.Session, ref.Path)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subs[subscriptionKey{req.Session, ref.URI}] = path
+	if !w.sessions[req.Session] {
+		w.sessions[req.Session] = true
+		go w.dropOnClose(req.Session)
+	}
+	if _, ok := w.modTimes[path]; !ok {
+		w.modTimes[path] = modTime(path)
+	}
+	return nil
+}
+
+func (w *resourceWatcher) unsubscribe(_ context.Context, req *mcp.UnsubscribeRequest) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.subs, subscriptionKey{req.Session, req.Params.URI})
+	return nil
+}
+
+// dropOnClose removes a session's subscriptions once it closes.
+func (w *resourceWatcher) dropOnClose(session *mcp.ServerSession) {
+	_ = session.Wait()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for key := range w.subs {
+		if key.session == session {
+			delete(w.subs, key)
+		}
+	}
+	delete(w.sessions, session)
+}
+
+// run polls subscribed files until ctx is cancelled.
+func (w *resourceWatcher) run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, uri := range w.changed() {
+				if err := w.server.ResourceUpdated(ctx, &mcp.ResourceUpdatedNotificationParams{URI: uri}); err != nil {
+					log.Printf("Failed to notify resource update for %s: %v", uri, err)
+				}
+			}
+		}
+	}
+}
+
+// changed returns the subscribed URIs whose PRD file was modified since the
+// last poll.
+func (w *resourceWatcher) changed() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	paths := make(map[string]bool)
+	for _, path := range w.subs {
+		paths[path] = true
+	}
+
+	updated := make(map[string]bool)
+	for path := range paths {
+		mt := modTime(path)
+		if !mt.Equal(w.modTimes[path]) {
+			w.modTimes[path] = mt
+			updated[path] = true
+		}
+	}
+	for path := range w.modTimes {
+		if !paths[path] {
+			delete(w.modTimes, path)
+		}
+	}
+
+	seen := make(map[string]bool)
+	var uris []string
+	for key, path := range w.subs {
+		if updated[path] && !seen[key.uri] {
+			seen[key.uri] = true
+			uris = append(uris, key.uri)
+		}
+	}
+	return uris
+}
+
+// modTime returns the file's modification time, or the zero time if it
+// does not exist.
+func modTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
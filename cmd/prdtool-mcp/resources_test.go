@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/mcpkit/runtime"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// newResourceTest starts a server with the PRD resources for a workspace
+// holding PRD.json, and returns its runtime, watcher and the PRD's path.
+func newResourceTest(t *testing.T) (*runtime.Runtime, *resourceWatcher, string) {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "PRD.json")
+	p := prd.New("PRD-2026-001", "Resource Test", prd.Person{Name: "Owner"})
+	p.Metadata.Approvers = []prd.Approver{{Name: "Alice"}}
+	if err := prd.Save(p, path); err != nil {
+		t.Fatal(err)
+	}
+
+	saved := workspaceSandbox
+	workspaceSandbox = newSandbox(dir)
+	t.Cleanup(func() { workspaceSandbox = saved })
+
+	watcher := newResourceWatcher()
+	rt := runtime.New(&mcp.Implementation{Name: "prdtool-mcp", Version: "test"}, &runtime.Options{
+		ServerOptions: &mcp.ServerOptions{
+			SubscribeHandler:   watcher.subscribe,
+			UnsubscribeHandler: watcher.unsubscribe,
+		},
+	})
+	registerResources(rt)
+	watcher.server = rt.MCPServer()
+	return rt, watcher, path
+}
+
+// connect opens a client session to the server. Update notifications are
+// sent to updates.
+func connect(t *testing.T, rt *runtime.Runtime, updates chan<- string) *mcp.ClientSession {
+	t.Helper()
+	ctx := context.Background()
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	if _, err := rt.MCPServer().Connect(ctx, serverTransport, nil); err != nil {
+		t.Fatal(err)
+	}
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "test"}, &mcp.ClientOptions{
+		ResourceUpdatedHandler: func(_ context.Context, req *mcp.ResourceUpdatedNotificationRequest) {
+			if updates != nil {
+				updates <- req.Params.URI
+			}
+		},
+	})
+	cs, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = cs.Close() })
+	return cs
+}
+
+func TestParseResourceURI(t *testing.T) {
+	tests := []struct {
+		uri, path, kind, name string
+	}{
+		{"prd://PRD.json", "PRD.json", "document", ""},
+		{"prd://docs/auth.json/score", "docs/auth.json", "score", ""},
+		{"prd://PRD.json/section/approvals", "PRD.json", "section", "approvals"},
+		{"prd://PRD.json/view/diagrams", "PRD.json", "view", "diagrams"},
+	}
+	for _, tt := range tests {
+		ref, err := parseResourceURI(tt.uri)
+		if err != nil {
+			t.Errorf("%s: %v", tt.uri, err)
+			continue
+		}
+		if ref.Path != tt.path || ref.Kind != tt.kind || ref.Section+ref.View != tt.name {
+			t.Errorf("%s: unexpected ref %+v", tt.uri, ref)
+		}
+	}
+
+	for _, uri := range []string{"prd://notes.txt", "file://PRD.json", "prd://PRD.json/section/"} {
+		if _, err := parseResourceURI(uri); err == nil {
+			t.Errorf("%s: expected an error", uri)
+		}
+	}
+}
+
+func TestReadResource(t *testing.T) {
+	rt, _, _ := newResourceTest(t)
+	cs := connect(t, rt, nil)
+	ctx := context.Background()
+
+	read := func(uri string) *mcp.ResourceContents {
+		t.Helper()
+		res, err := cs.ReadResource(ctx, &mcp.ReadResourceParams{URI: uri})
+		if err != nil {
+			t.Fatalf("%s: %v", uri, err)
+		}
+		if len(res.Contents) != 1 || res.Contents[0].URI != uri {
+			t.Fatalf("%s: unexpected contents %+v", uri, res.Contents)
+		}
+		return res.Contents[0]
+	}
+
+	var doc prd.PRD
+	if err := json.Unmarshal([]byte(read("prd://PRD.json").Text), &doc); err != nil || doc.Metadata.ID != "PRD-2026-001" {
+		t.Errorf("expected the PRD document, got %v", err)
+	}
+
+	var approvals []prd.ApproverStatus
+	if err := json.Unmarshal([]byte(read("prd://PRD.json/section/approvals").Text), &approvals); err != nil ||
+		len(approvals) != 1 || approvals[0].State != prd.ApprovalPending {
+		t.Errorf("expected Alice's pending approval, got %+v (%v)", approvals, err)
+	}
+
+	view := read("prd://PRD.json/view/diagrams")
+	if view.MIMEType != "text/markdown" || !strings.HasPrefix(view.Text, "No diagrams:") {
+		t.Errorf("expected the diagrams view as markdown, got %s %q", view.MIMEType, view.Text)
+	}
+
+	for _, uri := range []string{"prd://missing.json", "prd://PRD.json/section/widgets", "prd://../outside.json"} {
+		if _, err := cs.ReadResource(ctx, &mcp.ReadResourceParams{URI: uri}); err == nil {
+			t.Errorf("%s: expected an error", uri)
+		}
+	}
+}
+
+func TestSubscriptionsPerSession(t *testing.T) {
+	rt, watcher, path := newResourceTest(t)
+	ctx := context.Background()
+	const uri = "prd://PRD.json"
+
+	updatesA := make(chan string, 10)
+	updatesB := make(chan string, 10)
+	a := connect(t, rt, updatesA)
+	b := connect(t, rt, updatesB)
+	for _, cs := range []*mcp.ClientSession{a, b} {
+		if err := cs.Subscribe(ctx, &mcp.SubscribeParams{URI: uri}); err != nil {
+			t.Fatalf("Subscribe failed: %v", err)
+		}
+	}
+
+	// One session unsubscribing leaves the other's subscription in place.
+	if err := a.Unsubscribe(ctx, &mcp.UnsubscribeParams{URI: uri}); err != nil {
+		t.Fatalf("Unsubscribe failed: %v", err)
+	}
+	touch(t, path)
+	if changed := watcher.changed(); len(changed) != 1 || changed[0] != uri {
+		t.Fatalf("expected %s to change for the remaining subscriber, got %v", uri, changed)
+	}
+	if err := watcher.server.ResourceUpdated(ctx, &mcp.ResourceUpdatedNotificationParams{URI: uri}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case got := <-updatesB:
+		if got != uri {
+			t.Errorf("expected an update for %s, got %s", uri, got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the subscribed session to be notified")
+	}
+	select {
+	case got := <-updatesA:
+		t.Errorf("expected no update after unsubscribing, got %s", got)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Closing the last subscriber drops its subscriptions.
+	if err := b.Close(); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		watcher.mu.Lock()
+		n := len(watcher.subs)
+		watcher.mu.Unlock()
+		if n == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the closed session's subscription to be dropped, %d left", n)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	touch(t, path)
+	if changed := watcher.changed(); len(changed) != 0 {
+		t.Errorf("expected no subscribed changes, got %v", changed)
+	}
+}
+
+// touch moves the file's modification time forward.
+func touch(t *testing.T, path string) {
+	t.Helper()
+	p, err := prd.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if err := prd.Save(p, path); err != nil {
+		t.Fatal(err)
+	}
+}
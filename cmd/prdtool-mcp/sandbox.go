@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/agentplexus/agent-team-prd/pkg/workspace"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// rootEnv is the environment variable read when -root is not set.
+const rootEnv = "PRDTOOL_MCP_ROOT"
+
+// listRootsTimeout bounds how long we wait for a client to report its roots.
+const listRootsTimeout = 5 * time.Second
+
+// sandbox confines every PRD path a client passes in to the workspace root.
+//
+// The root is, in order of preference: the -root flag or PRDTOOL_MCP_ROOT,
+// the file:// roots the client reports, or the server's working directory.
+// The http transport requires -root, since its clients share one server.
+type sandbox struct {
+	root string
+
+	mu    sync.Mutex
+	roots map[*mcp.ServerSession]*sessionRoots // client roots, cached per session
+}
+
+// sessionRoots caches the roots a session's client reported. Stale roots
+// are listed again on the next request.
+type sessionRoots struct {
+	roots []string
+	stale bool
+}
+
+var workspaceSandbox = newSandbox("")
+
+func newSandbox(root string) *sandbox {
+	return &sandbox{
+		root:  root,
+		roots: make(map[*mcp.ServerSession]*sessionRoots),
+	}
+}
+
+// resolvePath applies the default path and confines it to the workspace root
+// of the requesting session.
+func resolvePath(ctx context.Context, session *mcp.ServerSession, path string) (string, error) {
+	return workspaceSandbox.resolve(ctx, session, defaultPath(path))
+}
+
+func (s *sandbox) resolve(ctx context.Context, session *mcp.ServerSession, path string) (string, error) {
+	var firstErr error
+	for _, root := range s.rootsFor(ctx, session) {
+		resolved, err := workspace.Confine(root, path)
+		if err == nil {
+			return resolved, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+		// Relative paths are resolved against the first root only.
+		if !filepath.IsAbs(path) {
+			break
+		}
+	}
+	return "", firstErr
+}
+
+func (s *sandbox) rootsFor(ctx context.Context, session *mcp.ServerSession) []string {
+	if s.root != "" {
+		return []string{s.root}
+	}
+
+	if session != nil {
+		if roots := s.sessionRoots(ctx, session); len(roots) > 0 {
+			return roots
+		}
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return []string{"."}
+	}
+	return []string{cwd}
+}
+
+// sessionRoots returns the session's client roots, listing them if they
+// aren't cached. A failed listing isn't cached, so it is retried on the
+// next request; the cache entry is dropped when the session closes.
+func (s *sandbox) sessionRoots(ctx context.Context, session *mcp.ServerSession) []string {
+	s.mu.Lock()
+	cached, ok := s.roots[session]
+	s.mu.Unlock()
+	if ok && !cached.stale {
+		return cached.roots
+	}
+
+	roots, err := listClientRoots(ctx, session)
+	if err != nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.roots[session]; !ok {
+		go s.evictOnClose(session)
+	}
+	s.roots[session] = &sessionRoots{roots: roots}
+	return roots
+}
+
+// evictOnClose drops the cached roots of a session once it closes.
+func (s *sandbox) evictOnClose(session *mcp.ServerSession) {
+	_ = session.Wait()
+	s.mu.Lock()
+	delete(s.roots, session)
+	s.mu.Unlock()
+}
+
+// rootsChanged marks the cached roots of a session whose client reported a
+// new roots list as stale.
+func (s *sandbox) rootsChanged(_ context.Context, req *mcp.RootsListChangedRequest) {
+	s.mu.Lock()
+	if cached, ok := s.roots[req.Session]; ok {
+		cached.stale = true
+	}
+	s.mu.Unlock()
+}
+
+// listClientRoots returns the local directories of the client's file://
+// roots. A client that doesn't support roots has none.
+func listClientRoots(ctx context.Context, session *mcp.ServerSession) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, listRootsTimeout)
+	defer cancel()
+
+	if params := session.InitializeParams(); params == nil || params.Capabilities == nil || params.Capabilities.RootsV2 == nil {
+		return nil, nil
+	}
+	res, err := session.ListRoots(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var roots []string
+	for _, r := range res.Roots {
+		u, err := url.Parse(r.URI)
+		if err != nil || u.Scheme != "file" || u.Path == "" {
+			continue
+		}
+		roots = append(roots, filepath.FromSlash(u.Path))
+	}
+	return roots, nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+// enumSchema infers the input schema of a tool from In, like mcp.AddTool
+// does, and restricts the named string properties to the given values,
+// so clients can offer them as choices.
+//
+// The values should be the canonical ones, such as prd.MoSCoWValues. The
+// SDK validates arguments against the schema before calling the handler,
+// so MCP input must be canonical: the aliases and spellings the prd.Parse
+// functions also accept (e.g. "won't", "review" or " Must ") are rejected.
+func enumSchema[In any](enums map[string][]string) *jsonschema.Schema {
+	schema, err := jsonschema.For[In](nil)
+	if err != nil {
+		panic(fmt.Sprintf("input schema: %v", err))
+	}
+	for name, values := range enums {
+		prop, ok := schema.Properties[name]
+		if !ok {
+			panic(fmt.Sprintf("input schema: no property %q", name))
+		}
+		prop.Enum = make([]any, len(values))
+		for i, v := range values {
+			prop.Enum[i] = v
+		}
+	}
+	return schema
+}
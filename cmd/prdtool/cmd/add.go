@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/agent-team-prd/pkg/service"
 	"github.com/spf13/cobra"
 )
 
@@ -59,22 +60,13 @@ var addProblemCmd = &cobra.Command{
 	Use:   "problem",
 	Short: "Add a problem statement",
 	Run: func(cmd *cobra.Command, args []string) {
-		path := getPRDPath(args)
-		p, err := prd.Load(path)
-		if err != nil {
-			exitWithError("Failed to load PRD: %v", err)
-		}
-
-		prd.SetProblemStatement(p, problemStatement, problemImpact, problemConfidence)
-		id := "PROB-1"
-		if p.Problem != nil {
-			id = p.Problem.ID
-		}
-
-		if err := prd.Save(p, path); err != nil {
-			exitWithError("Failed to save PRD: %v", err)
-		}
-		fmt.Printf("Set problem statement: %s\n", id)
+		printResult(prdService.AddProblem(service.AddProblemRequest{
+			Path:       getPRDPath(args),
+			Statement:  problemStatement,
+			Impact:     problemImpact,
+			Confidence: problemConfidence,
+			DryRun:     dryRun,
+		}))
 	},
 }
 
@@ -96,25 +88,20 @@ var addPersonaCmd = &cobra.Command{
 	Use:   "persona",
 	Short: "Add a user persona",
 	Run: func(cmd *cobra.Command, args []string) {
-		path := getPRDPath(args)
-		p, err := prd.Load(path)
-		if err != nil {
-			exitWithError("Failed to load PRD: %v", err)
-		}
-
-		id := prd.AddPersona(p, personaName, personaRole, personaPainPoints)
-
-		if err := prd.Save(p, path); err != nil {
-			exitWithError("Failed to save PRD: %v", err)
-		}
-		fmt.Printf("Added persona: %s (%s)\n", personaName, id)
+		printResult(prdService.AddPersona(service.AddPersonaRequest{
+			Path:       getPRDPath(args),
+			Name:       personaName,
+			Role:       personaRole,
+			PainPoints: personaPainPoints,
+			DryRun:     dryRun,
+		}))
 	},
 }
 
 func init() {
 	addPersonaCmd.Flags().StringVar(&personaName, "name", "", "Persona name (required)")
 	addPersonaCmd.Flags().StringVar(&personaRole, "role", "", "Persona role")
-	addPersonaCmd.Flags().StringSliceVar(&personaPainPoints, "pain-point", nil, "Pain points (can be repeated)")
+	addPersonaCmd.Flags().StringArrayVar(&personaPainPoints, "pain-point", nil, "Pain point (can be repeated)")
 	mustMarkRequired(addPersonaCmd, "name")
 }
 
@@ -125,18 +112,11 @@ var addGoalCmd = &cobra.Command{
 	Use:   "goal",
 	Short: "Add a goal",
 	Run: func(cmd *cobra.Command, args []string) {
-		path := getPRDPath(args)
-		p, err := prd.Load(path)
-		if err != nil {
-			exitWithError("Failed to load PRD: %v", err)
-		}
-
-		id := prd.AddProductGoal(p, goalStatement, "")
-
-		if err := prd.Save(p, path); err != nil {
-			exitWithError("Failed to save PRD: %v", err)
-		}
-		fmt.Printf("Added goal: %s\n", id)
+		printResult(prdService.AddGoal(service.AddGoalRequest{
+			Path:      getPRDPath(args),
+			Statement: goalStatement,
+			DryRun:    dryRun,
+		}))
 	},
 }
 
@@ -152,18 +132,11 @@ var addNonGoalCmd = &cobra.Command{
 	Use:   "nongoal",
 	Short: "Add a non-goal",
 	Run: func(cmd *cobra.Command, args []string) {
-		path := getPRDPath(args)
-		p, err := prd.Load(path)
-		if err != nil {
-			exitWithError("Failed to load PRD: %v", err)
-		}
-
-		prd.AddOutOfScope(p, nonGoalStatement)
-
-		if err := prd.Save(p, path); err != nil {
-			exitWithError("Failed to save PRD: %v", err)
-		}
-		fmt.Println("Added non-goal")
+		printResult(prdService.AddNonGoal(service.AddNonGoalRequest{
+			Path:      getPRDPath(args),
+			Statement: nonGoalStatement,
+			DryRun:    dryRun,
+		}))
 	},
 }
 
@@ -183,25 +156,20 @@ var addSolutionCmd = &cobra.Command{
 	Use:   "solution",
 	Short: "Add a solution option",
 	Run: func(cmd *cobra.Command, args []string) {
-		path := getPRDPath(args)
-		p, err := prd.Load(path)
-		if err != nil {
-			exitWithError("Failed to load PRD: %v", err)
-		}
-
-		id := prd.AddSolution(p, solutionName, solutionDescription, solutionTradeoffs)
-
-		if err := prd.Save(p, path); err != nil {
-			exitWithError("Failed to save PRD: %v", err)
-		}
-		fmt.Printf("Added solution option: %s (%s)\n", solutionName, id)
+		printResult(prdService.AddSolution(service.AddSolutionRequest{
+			Path:        getPRDPath(args),
+			Name:        solutionName,
+			Description: solutionDescription,
+			Tradeoffs:   solutionTradeoffs,
+			DryRun:      dryRun,
+		}))
 	},
 }
 
 func init() {
 	addSolutionCmd.Flags().StringVar(&solutionName, "name", "", "Solution name (required)")
 	addSolutionCmd.Flags().StringVar(&solutionDescription, "description", "", "Solution description")
-	addSolutionCmd.Flags().StringSliceVar(&solutionTradeoffs, "tradeoff", nil, "Tradeoffs (can be repeated)")
+	addSolutionCmd.Flags().StringArrayVar(&solutionTradeoffs, "tradeoff", nil, "Tradeoff (can be repeated)")
 	mustMarkRequired(addSolutionCmd, "name")
 }
 
@@ -216,34 +184,22 @@ var addReqCmd = &cobra.Command{
 	Use:   "req",
 	Short: "Add a functional requirement",
 	Run: func(cmd *cobra.Command, args []string) {
-		path := getPRDPath(args)
-		p, err := prd.Load(path)
-		if err != nil {
-			exitWithError("Failed to load PRD: %v", err)
-		}
-
-		priority := prd.ParseMoSCoW(reqPriority)
-		title := reqTitle
-		if title == "" {
-			title = reqDescription
-			if len(title) > 50 {
-				title = title[:50] + "..."
-			}
-		}
-		id := prd.AddFunctionalRequirement(p, title, reqDescription, priority)
-
-		if err := prd.Save(p, path); err != nil {
-			exitWithError("Failed to save PRD: %v", err)
-		}
-		fmt.Printf("Added requirement: %s (%s)\n", id, priority)
+		printResult(prdService.AddRequirement(service.AddRequirementRequest{
+			Path:        getPRDPath(args),
+			Title:       reqTitle,
+			Description: reqDescription,
+			Priority:    reqPriority,
+			DryRun:      dryRun,
+		}))
 	},
 }
 
 func init() {
 	addReqCmd.Flags().StringVar(&reqTitle, "title", "", "Requirement title")
 	addReqCmd.Flags().StringVar(&reqDescription, "description", "", "Requirement description (required)")
-	addReqCmd.Flags().StringVar(&reqPriority, "priority", "should", "Priority: must, should, could")
+	addReqCmd.Flags().StringVar(&reqPriority, "priority", "should", valuesHelp("Priority", prd.MoSCoWValues()))
 	mustMarkRequired(addReqCmd, "description")
+	mustCompleteValues(addReqCmd, "priority", prd.MoSCoWValues())
 }
 
 // NFR
@@ -259,37 +215,27 @@ var addNFRCmd = &cobra.Command{
 	Use:   "nfr",
 	Short: "Add a non-functional requirement",
 	Run: func(cmd *cobra.Command, args []string) {
-		path := getPRDPath(args)
-		p, err := prd.Load(path)
-		if err != nil {
-			exitWithError("Failed to load PRD: %v", err)
-		}
-
-		category := prd.ParseNFRCategory(nfrCategory)
-		priority := prd.ParseMoSCoW(nfrPriority)
-		title := nfrTitle
-		if title == "" {
-			title = nfrRequirement
-			if len(title) > 50 {
-				title = title[:50] + "..."
-			}
-		}
-		id := prd.AddNonFunctionalRequirement(p, category, title, nfrRequirement, nfrTarget, priority)
-
-		if err := prd.Save(p, path); err != nil {
-			exitWithError("Failed to save PRD: %v", err)
-		}
-		fmt.Printf("Added NFR: %s (%s)\n", id, category)
+		printResult(prdService.AddNFR(service.AddNFRRequest{
+			Path:        getPRDPath(args),
+			Category:    nfrCategory,
+			Title:       nfrTitle,
+			Requirement: nfrRequirement,
+			Target:      nfrTarget,
+			Priority:    nfrPriority,
+			DryRun:      dryRun,
+		}))
 	},
 }
 
 func init() {
-	addNFRCmd.Flags().StringVar(&nfrCategory, "category", "performance", "Category: performance, security, reliability, scalability, usability, compliance")
+	addNFRCmd.Flags().StringVar(&nfrCategory, "category", "performance", valuesHelp("Category", prd.NFRCategoryValues()))
 	addNFRCmd.Flags().StringVar(&nfrTitle, "title", "", "NFR title")
 	addNFRCmd.Flags().StringVar(&nfrRequirement, "requirement", "", "NFR description (required)")
 	addNFRCmd.Flags().StringVar(&nfrTarget, "target", "", "Target value")
-	addNFRCmd.Flags().StringVar(&nfrPriority, "priority", "should", "Priority: must, should, could")
+	addNFRCmd.Flags().StringVar(&nfrPriority, "priority", "should", valuesHelp("Priority", prd.MoSCoWValues()))
 	mustMarkRequired(addNFRCmd, "requirement")
+	mustCompleteValues(addNFRCmd, "category", prd.NFRCategoryValues())
+	mustCompleteValues(addNFRCmd, "priority", prd.MoSCoWValues())
 }
 
 // Metric
@@ -303,18 +249,13 @@ var addMetricCmd = &cobra.Command{
 	Use:   "metric",
 	Short: "Add a success metric",
 	Run: func(cmd *cobra.Command, args []string) {
-		path := getPRDPath(args)
-		p, err := prd.Load(path)
-		if err != nil {
-			exitWithError("Failed to load PRD: %v", err)
-		}
-
-		id := prd.AddSuccessMetric(p, metricName, metricDescription, metricTarget)
-
-		if err := prd.Save(p, path); err != nil {
-			exitWithError("Failed to save PRD: %v", err)
-		}
-		fmt.Printf("Added metric: %s (%s)\n", metricName, id)
+		printResult(prdService.AddMetric(service.AddMetricRequest{
+			Path:        getPRDPath(args),
+			Name:        metricName,
+			Description: metricDescription,
+			Target:      metricTarget,
+			DryRun:      dryRun,
+		}))
 	},
 }
 
@@ -337,29 +278,25 @@ var addRiskCmd = &cobra.Command{
 	Use:   "risk",
 	Short: "Add a risk",
 	Run: func(cmd *cobra.Command, args []string) {
-		path := getPRDPath(args)
-		p, err := prd.Load(path)
-		if err != nil {
-			exitWithError("Failed to load PRD: %v", err)
-		}
-
-		probability := prd.ParseRiskProbability(riskProbability)
-		impact := prd.ParseRiskImpact(riskImpact)
-		id := prd.AddRisk(p, riskDescription, probability, impact, riskMitigation)
-
-		if err := prd.Save(p, path); err != nil {
-			exitWithError("Failed to save PRD: %v", err)
-		}
-		fmt.Printf("Added risk: %s (%s impact)\n", id, impact)
+		printResult(prdService.AddRisk(service.AddRiskRequest{
+			Path:        getPRDPath(args),
+			Description: riskDescription,
+			Probability: riskProbability,
+			Impact:      riskImpact,
+			Mitigation:  riskMitigation,
+			DryRun:      dryRun,
+		}))
 	},
 }
 
 func init() {
 	addRiskCmd.Flags().StringVar(&riskDescription, "description", "", "Risk description (required)")
-	addRiskCmd.Flags().StringVar(&riskProbability, "probability", "medium", "Probability: low, medium, high")
-	addRiskCmd.Flags().StringVar(&riskImpact, "impact", "medium", "Impact: low, medium, high, critical")
+	addRiskCmd.Flags().StringVar(&riskProbability, "probability", "medium", valuesHelp("Probability", prd.RiskProbabilityValues()))
+	addRiskCmd.Flags().StringVar(&riskImpact, "impact", "medium", valuesHelp("Impact", prd.RiskImpactValues()))
 	addRiskCmd.Flags().StringVar(&riskMitigation, "mitigation", "", "Mitigation strategy")
 	mustMarkRequired(addRiskCmd, "description")
+	mustCompleteValues(addRiskCmd, "probability", prd.RiskProbabilityValues())
+	mustCompleteValues(addRiskCmd, "impact", prd.RiskImpactValues())
 }
 
 // Decision
@@ -373,18 +310,13 @@ var addDecisionCmd = &cobra.Command{
 	Use:   "decision",
 	Short: "Add a decision record",
 	Run: func(cmd *cobra.Command, args []string) {
-		path := getPRDPath(args)
-		p, err := prd.Load(path)
-		if err != nil {
-			exitWithError("Failed to load PRD: %v", err)
-		}
-
-		id := prd.AddDecision(p, decisionText, decisionRationale, decisionMadeBy)
-
-		if err := prd.Save(p, path); err != nil {
-			exitWithError("Failed to save PRD: %v", err)
-		}
-		fmt.Printf("Added decision: %s\n", id)
+		printResult(prdService.AddDecision(service.AddDecisionRequest{
+			Path:      getPRDPath(args),
+			Decision:  decisionText,
+			Rationale: decisionRationale,
+			MadeBy:    decisionMadeBy,
+			DryRun:    dryRun,
+		}))
 	},
 }
 
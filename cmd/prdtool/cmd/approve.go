@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"github.com/agentplexus/agent-team-prd/pkg/service"
+	"github.com/spf13/cobra"
+)
+
+var (
+	signOffApprover string
+	signOffComment  string
+)
+
+var approveCmd = &cobra.Command{
+	Use:   "approve [file]",
+	Short: "Sign off on the PRD as an approver",
+	Long: `Record an approver's sign-off on the PRD's current content.
+
+The sign-off is tied to a hash of the content. When the PRD changes
+materially it becomes stale and the approver must approve again; changes
+to the status, version, authors, reviewers, review, comments and test
+coverage don't count. Only approvers listed in metadata.approvers can sign
+off. The approvers' names are part of the content, so adding or removing
+an approver makes every sign-off stale.
+
+Approval needs a valid sign-off from every approver (see 'prdtool status').
+Pending approvals are shown by 'prdtool show' and the exec view.
+
+Examples:
+  prdtool approve --as "Bob Chen"
+  prdtool approve --as "Bob Chen" --comment "Good to go once RISK-2 is mitigated"`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		printResult(prdService.Approve(signOffRequest(args)))
+	},
+}
+
+var rejectCmd = &cobra.Command{
+	Use:   "reject [file]",
+	Short: "Reject the PRD as an approver",
+	Long: `Record an approver's rejection of the PRD's current content, with a
+comment explaining it. Like an approval, the rejection is tied to a hash
+of the content and goes stale when the PRD changes materially.
+
+Examples:
+  prdtool reject --as "Bob Chen" --comment "Success metrics have no baselines"`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		printResult(prdService.Reject(signOffRequest(args)))
+	},
+}
+
+func signOffRequest(args []string) service.SignOffRequest {
+	return service.SignOffRequest{
+		Path:     getPRDPath(args),
+		Approver: signOffApprover,
+		Comment:  signOffComment,
+		DryRun:   dryRun,
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(approveCmd)
+	rootCmd.AddCommand(rejectCmd)
+
+	for _, cmd := range []*cobra.Command{approveCmd, rejectCmd} {
+		cmd.Flags().StringVar(&signOffApprover, "as", "", "Name of the approver signing off")
+		cmd.Flags().StringVar(&signOffComment, "comment", "", "Comment on the decision")
+		mustMarkRequired(cmd, "as")
+		mustCompleteFlag(cmd, "as", completeApprovers)
+	}
+	mustMarkRequired(rejectCmd, "comment")
+}
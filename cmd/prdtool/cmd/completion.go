@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/agent-team-prd/pkg/scoring"
+	"github.com/spf13/cobra"
+)
+
+// Completion functions for flags and arguments. Cobra generates the bash,
+// zsh, fish and PowerShell scripts from them ('prdtool completion'); the
+// dynamic ones read the PRD given by --file or the [file] argument each
+// time Tab is pressed.
+
+// showSections are the sections accepted by 'show --section'.
+var showSections = []string{
+	"metadata", "problem", "personas", "market", "objectives", "solution",
+	"requirements", "ux", "technical", "risks", "decisions", "dependencies",
+	"approvals",
+}
+
+// viewTypes are the view types accepted by 'view --type'.
+var viewTypes = []string{"pm", "exec", "diagrams"}
+
+func init() {
+	// Commands that take a single optional [file] argument.
+	for _, cmd := range []*cobra.Command{
+		approveCmd, coverageCmd, depsAddCmd, depsRemoveCmd, evalTemplateCmd,
+		evalDeterministicCmd, interviewCmd, patchCmd, scoreCmd, scoreNextCmd,
+		rejectCmd, showCmd, tuiCmd, validateCmd, viewCmd,
+	} {
+		cmd.ValidArgsFunction = completePRDFile
+	}
+}
+
+// mustCompleteValues offers values as shell completions for a flag,
+// panicking if the flag doesn't exist.
+func mustCompleteValues(cmd *cobra.Command, name string, values []string) {
+	mustCompleteFlag(cmd, name, cobra.FixedCompletions(values, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// mustCompleteFlag registers a completion function for a flag, panicking
+// if the flag doesn't exist.
+func mustCompleteFlag(cmd *cobra.Command, name string, fn cobra.CompletionFunc) {
+	if err := cmd.RegisterFlagCompletionFunc(name, fn); err != nil {
+		panic(fmt.Sprintf("flag %q not found: %v", name, err))
+	}
+}
+
+// valuesHelp lists the valid values of a flag for its usage text.
+func valuesHelp(label string, values []string) string {
+	return label + ": " + strings.Join(values, ", ")
+}
+
+// completeCategoryWeights completes the category=weight pairs of the
+// eval weight flags with rubric category IDs.
+func completeCategoryWeights(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	var completions []cobra.Completion
+	for _, c := range scoring.Categories() {
+		if strings.HasPrefix(c.ID, toComplete) {
+			completions = append(completions, cobra.CompletionWithDesc(c.ID+"=", c.Name))
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+// completionPRD loads the PRD for a completion. Completions must not
+// print errors, so a missing or invalid PRD yields nil.
+func completionPRD(fileArgs []string) *prd.PRD {
+	p, err := prd.Load(getPRDPath(fileArgs))
+	if err != nil {
+		return nil
+	}
+	return p
+}
+
+// entityCompletions returns the IDs of the kinds' entities that start
+// with toComplete, described by their labels.
+func entityCompletions(p *prd.PRD, kinds []*prd.EntityKind, toComplete string) []cobra.Completion {
+	if p == nil {
+		return nil
+	}
+	var completions []cobra.Completion
+	for _, kind := range kinds {
+		for _, e := range kind.List(p) {
+			if e.ID != "" && strings.HasPrefix(e.ID, toComplete) {
+				completions = append(completions, cobra.CompletionWithDesc(e.ID, e.Label))
+			}
+		}
+	}
+	return completions
+}
+
+// completeEntityIDs completes the IDs of the named kinds' entities, or of
+// every kind without names, in the PRD given by the [file] argument or
+// --file.
+func completeEntityIDs(names ...string) cobra.CompletionFunc {
+	kinds := prd.EntityKinds()
+	if len(names) > 0 {
+		kinds = nil
+		for _, name := range names {
+			kind, ok := prd.LookupEntityKind(name)
+			if !ok {
+				panic(fmt.Sprintf("unknown entity kind %q", name))
+			}
+			kinds = append(kinds, kind)
+		}
+	}
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return entityCompletions(completionPRD(args), kinds, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeStatus completes '[new-status] [file]' arguments.
+func completeStatus(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return completePRDFile(cmd, args[1:], toComplete)
+	}
+	return prd.StatusValues(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeApprovers completes the approvers listed in the PRD's
+// metadata, described by their roles.
+func completeApprovers(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	p := completionPRD(args)
+	if p == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var completions []cobra.Completion
+	for _, a := range p.Metadata.Approvers {
+		if strings.HasPrefix(strings.ToLower(a.Name), strings.ToLower(toComplete)) {
+			completions = append(completions, cobra.CompletionWithDesc(a.Name, a.Role))
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completePRDFile completes a single [file] argument with JSON files.
+func completePRDFile(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return []cobra.Completion{"json"}, cobra.ShellCompDirectiveFilterFileExt
+}
+
+// completeDependencyIDs completes the IDs of the PRDs that the PRD given
+// by the [file] argument or --file depends on.
+func completeDependencyIDs(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	p := completionPRD(args)
+	if p == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	deps, err := prd.GetDependencies(p)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	seen := make(map[string]bool)
+	var completions []cobra.Completion
+	add := func(id, reason string) {
+		if id != "" && !seen[id] && strings.HasPrefix(id, toComplete) {
+			seen[id] = true
+			completions = append(completions, cobra.CompletionWithDesc(id, reason))
+		}
+	}
+	for _, d := range deps.Documents {
+		add(d.PRDID, d.Reason)
+	}
+	for _, d := range deps.Requirements {
+		add(d.PRDID, d.Reason)
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/agentplexus/agent-team-prd/pkg/coverage"
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	coverageSrc   []string
+	coverageJSON  bool
+	coverageWrite bool
+)
+
+var coverageCmd = &cobra.Command{
+	Use:   "coverage [file]",
+	Short: "Trace tests back to PRD requirements",
+	Long: `Scan Go and Gherkin sources for PRD IDs and report which functional
+requirements and acceptance criteria have no tests.
+
+Tests reference PRD IDs with comments and tags:
+  Go:      // Covers: FR-12, AC-3
+  Gherkin: @FR-12 tags or # Covers: FR-12 comments
+
+Source patterns follow the go tool convention: "./..." scans recursively,
+a directory scans only that directory.
+
+With --write, the coverage status is stored in the PRD's "test-coverage"
+custom section so it shows up in views and scoring.
+
+Examples:
+  prdtool coverage --src ./... PRD.json
+  prdtool coverage --src ./internal/... --src ./features/... PRD.json
+  prdtool coverage --src ./... --write PRD.json
+  prdtool coverage --json PRD.json`,
+	Run: runCoverage,
+}
+
+func init() {
+	rootCmd.AddCommand(coverageCmd)
+
+	coverageCmd.Flags().StringSliceVar(&coverageSrc, "src", []string{"./..."}, "Source patterns to scan (repeatable)")
+	coverageCmd.Flags().BoolVar(&coverageJSON, "json", false, "Output as JSON")
+	coverageCmd.Flags().BoolVarP(&coverageWrite, "write", "w", false, "Write coverage status back into the PRD")
+}
+
+func runCoverage(cmd *cobra.Command, args []string) {
+	path := getPRDPath(args)
+
+	// Load PRD
+	p, err := prd.Load(path)
+	if err != nil {
+		exitWithError("Failed to load PRD: %v", err)
+	}
+
+	// Scan sources
+	refs, err := coverage.Scan(coverageSrc)
+	if err != nil {
+		exitWithError("Failed to scan sources: %v", err)
+	}
+
+	report := coverage.Analyze(p, refs)
+
+	written := false
+	if coverageWrite {
+		coverage.Apply(p, report)
+		// Keep stdout clean JSON when previewing a dry run.
+		out := os.Stdout
+		if coverageJSON {
+			out = os.Stderr
+		}
+		written = savePRD(out, p, path)
+	}
+
+	if coverageJSON {
+		output, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			exitWithError("Failed to marshal JSON: %v", err)
+		}
+		fmt.Println(string(output))
+		return
+	}
+
+	bold := color.New(color.Bold).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	fmt.Printf("%s\n", bold("Test Coverage"))
+	fmt.Printf("════════════════════════════════════════\n\n")
+
+	fmt.Printf("Requirements:        %d/%d covered (%.0f%%)\n",
+		report.Summary.CoveredRequirements, report.Summary.TotalRequirements,
+		report.Summary.RequirementPercent())
+	fmt.Printf("Acceptance criteria: %d/%d covered (%.0f%%)\n\n",
+		report.Summary.CoveredCriteria, report.Summary.TotalCriteria,
+		report.Summary.CriteriaPercent())
+
+	if len(report.Requirements) > 0 {
+		fmt.Printf("%s\n", bold("Functional Requirements"))
+		for _, req := range report.Requirements {
+			if req.Covered {
+				fmt.Printf("  %s [%s] %s (%d tests)\n", green("✓"), req.ID, req.Title, len(req.Tests))
+			} else {
+				fmt.Printf("  %s [%s] %s (%s)\n", red("✗"), req.ID, req.Title, req.Priority)
+			}
+		}
+		fmt.Println()
+	}
+
+	if uncovered := report.UncoveredCriteria(); len(uncovered) > 0 {
+		fmt.Printf("%s\n", bold("Acceptance Criteria Without Tests"))
+		for _, c := range uncovered {
+			fmt.Printf("  %s [%s] %s (from %s)\n", red("✗"), c.ID, c.Description, c.ParentID)
+		}
+		fmt.Println()
+	}
+
+	if len(report.UnknownIDs) > 0 {
+		fmt.Printf("%s\n", bold("Unknown IDs Referenced by Tests"))
+		for _, id := range report.UnknownIDs {
+			fmt.Printf("  %s %s\n", yellow("!"), id)
+		}
+		fmt.Println()
+	}
+
+	if len(report.AmbiguousIDs) > 0 {
+		fmt.Printf("%s\n", bold("Ambiguous Criteria IDs (qualify as US-1-AC-2)"))
+		for _, id := range report.AmbiguousIDs {
+			fmt.Printf("  %s %s\n", yellow("!"), id)
+		}
+		fmt.Println()
+	}
+
+	if written {
+		fmt.Printf("Coverage written to %s\n", path)
+	}
+}
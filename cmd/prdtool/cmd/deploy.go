@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/agentplexus/agent-team-prd/pkg/workflows"
 	"github.com/spf13/cobra"
 )
 
@@ -41,6 +42,7 @@ func init() {
 
 	deployCmd.Flags().StringVarP(&deployTarget, "target", "t", "kiro-cli", "Deployment target: kiro-cli, kiro-power, claude, all")
 	deployCmd.Flags().StringVarP(&deployOutput, "output", "o", "", "Output directory (default: platform-specific)")
+	mustCompleteValues(deployCmd, "target", []string{"kiro-cli", "kiro-power", "claude", "all"})
 }
 
 func runDeploy(cmd *cobra.Command, args []string) {
@@ -320,123 +322,11 @@ When users mention PRD-related keywords, help them with the appropriate workflow
 }
 
 func getSteeringFiles() map[string]string {
-	return map[string]string{
-		"prd-creation.md": `# PRD Creation Workflow
-
-Guide users through creating comprehensive PRDs:
-
-## Phase 1: Problem Discovery
-- Ask probing questions about the problem
-- Request evidence and data
-- Document user impact
-- Identify root causes
-
-## Phase 2: User Understanding
-- Create detailed personas
-- Document pain points and behaviors
-- Identify primary persona
-
-## Phase 3: Scope Definition
-- Define measurable goals
-- Document explicit non-goals
-- Set success criteria
-
-## Phase 4: Solution Design
-- Generate multiple options (2-3 minimum)
-- Document tradeoffs for each
-- Select with documented rationale
-
-## Phase 5: Requirements
-- Write testable requirements with acceptance criteria
-- Use MoSCoW prioritization (Must/Should/Could)
-- Link requirements to goals
-
-## Phase 6: Metrics
-- Define North Star metric
-- Add supporting and guardrail metrics
-- Set specific targets
-
-## Phase 7: Risk Assessment
-- Document risks with impact levels
-- Provide mitigation strategies
-- List open questions
-
-## Validation
-Always run prd_validate and prd_score after significant changes.
-`,
-		"prd-review.md": `# PRD Review Workflow
-
-Guide users through reviewing and improving PRDs:
-
-## Step 1: Load and Examine
-` + "```\nprd_load\n```" + `
-
-## Step 2: Validate Structure
-` + "```\nprd_validate\n```" + `
-
-## Step 3: Score Quality
-` + "```\nprd_score\n```" + `
-
-## Step 4: Analyze Low Scores
-
-For each category below 7.0, check:
-
-### Problem Definition (20%)
-- Is evidence provided?
-- Is impact quantified?
-- Is confidence justified?
-
-### Solution Fit (15%)
-- Were multiple options considered?
-- Is rationale documented?
-- Are tradeoffs acknowledged?
-
-### User Understanding (10%)
-- Are personas specific?
-- Are pain points validated?
-
-### Scope Discipline (10%)
-- Are non-goals documented?
-- Are goals measurable?
-
-### Requirements Quality (10%)
-- Do requirements have acceptance criteria?
-- Is priority assigned?
-
-### Metrics Quality (10%)
-- Is there a North Star metric?
-- Are targets defined?
-
-## Step 5: Generate Recommendations
-Prioritize by: Blockers > High-weight categories > Quick wins
-`,
-		"exec-summary.md": `# Executive Summary Workflow
-
-Generate executive-level PRD summaries:
-
-## Generate Views
-` + "```\nprd_score\nprd_view --type exec\n```" + `
-
-## Summary Structure
-
-1. **Opening**: State initiative and recommendation
-2. **Problem Validation**: Summarize evidence
-3. **Solution Summary**: Approach and rationale
-4. **Decision Factors**: Status table
-5. **Key Risks**: Top 3 with mitigations
-6. **Recommendation**: Clear call to action
-
-## Decision Matrix Format
-
-| Criteria | Status |
-|----------|--------|
-| Problem validated | ✅/⚠️/❌ |
-| Solution viable | ✅/⚠️/❌ |
-| Scope bounded | ✅/⚠️/❌ |
-| Success measurable | ✅/⚠️/❌ |
-| Risks managed | ✅/⚠️/❌ |
-`,
+	files := make(map[string]string)
+	for _, w := range workflows.All() {
+		files[w.SteeringFile()] = w.Instructions
 	}
+	return files
 }
 
 func deployClaude() {
@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/agentplexus/agent-team-prd/pkg/deps"
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/agent-team-prd/pkg/workspace"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var depsFormat string
+
+var depsCmd = &cobra.Command{
+	Use:   "deps [dir]",
+	Short: "Show cross-PRD dependencies in a workspace",
+	Long: `Build the dependency graph across all PRDs in a workspace directory.
+
+Reports dependency cycles, dependencies on deprecated PRDs, and references
+to PRDs or requirements that are not in the workspace.
+
+Output formats:
+  text    - Dependency list and issues (default)
+  json    - Graph and issues as JSON
+  dot     - Graphviz DOT
+  mermaid - Mermaid flowchart
+
+Subcommands:
+  add    - Add a dependency to a PRD
+  remove - Remove dependencies on a PRD
+
+Examples:
+  prdtool deps
+  prdtool deps ./docs --format mermaid
+  prdtool deps --format dot | dot -Tsvg > deps.svg
+  prdtool deps add --on PRD-2026-001 --reason "Needs platform auth"
+  prdtool deps add --on PRD-2026-001 --req FR-2 --external-req FR-7`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runDeps,
+}
+
+func init() {
+	rootCmd.AddCommand(depsCmd)
+
+	depsCmd.Flags().StringVarP(&depsFormat, "format", "o", "text", "Output format: text, json, dot, mermaid")
+	mustCompleteValues(depsCmd, "format", []string{"text", "json", "dot", "mermaid"})
+
+	depsCmd.AddCommand(depsAddCmd)
+	depsCmd.AddCommand(depsRemoveCmd)
+
+	depsAddCmd.Flags().StringVar(&depsOn, "on", "", "ID of the PRD depended on (required)")
+	depsAddCmd.Flags().StringVar(&depsReq, "req", "", "Local requirement ID (for requirement-level links)")
+	depsAddCmd.Flags().StringVar(&depsExternalReq, "external-req", "", "Requirement ID in the other PRD")
+	depsAddCmd.Flags().StringVar(&depsReason, "reason", "", "Why the dependency exists")
+	mustMarkRequired(depsAddCmd, "on")
+	mustCompleteFlag(depsAddCmd, "req", completeEntityIDs("requirements"))
+
+	depsRemoveCmd.Flags().StringVar(&depsOn, "on", "", "ID of the PRD depended on (required)")
+	mustMarkRequired(depsRemoveCmd, "on")
+	mustCompleteFlag(depsRemoveCmd, "on", completeDependencyIDs)
+}
+
+func runDeps(cmd *cobra.Command, args []string) {
+	root := getWorkspaceDir(args)
+
+	ws, err := workspace.Discover(root)
+	if err != nil {
+		exitWithError("Failed to discover PRDs: %v", err)
+	}
+	for _, f := range ws.Failures {
+		fmt.Fprintf(os.Stderr, "Warning: skipped %s: %s\n", f.Path, f.Error)
+	}
+
+	g, err := deps.Build(ws)
+	if err != nil {
+		exitWithError("Failed to build dependency graph: %v", err)
+	}
+	issues := g.Check()
+
+	switch depsFormat {
+	case "json":
+		output, err := json.MarshalIndent(map[string]interface{}{
+			"nodes":  g.Nodes,
+			"edges":  g.Edges,
+			"issues": issues,
+		}, "", "  ")
+		if err != nil {
+			exitWithError("Failed to marshal JSON: %v", err)
+		}
+		fmt.Println(string(output))
+	case "dot":
+		fmt.Print(g.DOT())
+	case "mermaid":
+		fmt.Print(g.Mermaid())
+	case "text":
+		printDeps(g, issues)
+	default:
+		exitWithError("Unknown format: %s. Use 'text', 'json', 'dot' or 'mermaid'", depsFormat)
+	}
+}
+
+func printDeps(g *deps.Graph, issues []deps.Issue) {
+	bold := color.New(color.Bold).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	fmt.Printf("%s\n", bold("PRD Dependencies"))
+	fmt.Printf("════════════════════════════════════════\n\n")
+
+	if len(g.Edges) == 0 {
+		fmt.Println("No dependencies recorded")
+	}
+
+	for _, n := range g.Nodes {
+		if n.Missing {
+			continue
+		}
+		depIDs := g.Dependencies(n.ID)
+		if len(depIDs) == 0 {
+			continue
+		}
+		fmt.Printf("  %s: %s\n", n.ID, n.Title)
+		for _, e := range g.Edges {
+			if e.From != n.ID {
+				continue
+			}
+			line := "    → " + e.To
+			if e.FromRequirement != "" {
+				line += fmt.Sprintf(" (%s → %s)", e.FromRequirement, e.ToRequirement)
+			}
+			if e.Reason != "" {
+				line += ": " + e.Reason
+			}
+			fmt.Println(line)
+		}
+		fmt.Println()
+	}
+
+	if len(issues) > 0 {
+		fmt.Printf("%s\n", bold("Issues"))
+		for _, issue := range issues {
+			icon := yellow("!")
+			if issue.Type == deps.IssueCycle {
+				icon = red("✗")
+			}
+			fmt.Printf("  %s [%s] %s\n", icon, issue.Type, issue.Message)
+		}
+	}
+}
+
+// Deps add/remove
+var (
+	depsOn          string
+	depsReq         string
+	depsExternalReq string
+	depsReason      string
+)
+
+var depsAddCmd = &cobra.Command{
+	Use:   "add [file]",
+	Short: "Add a dependency on another PRD",
+	Run: func(cmd *cobra.Command, args []string) {
+		path := getPRDPath(args)
+		p, err := prd.Load(path)
+		if err != nil {
+			exitWithError("Failed to load PRD: %v", err)
+		}
+
+		if depsReq != "" || depsExternalReq != "" {
+			if depsReq == "" || depsExternalReq == "" {
+				exitWithError("--req and --external-req must be used together")
+			}
+			err = prd.AddRequirementDependency(p, depsReq, depsOn, depsExternalReq, depsReason)
+		} else {
+			err = prd.AddDocumentDependency(p, depsOn, depsReason)
+		}
+		if err != nil {
+			exitWithError("Failed to add dependency: %v", err)
+		}
+
+		if !savePRD(os.Stdout, p, path) {
+			return
+		}
+		if depsReq != "" {
+			fmt.Printf("Added dependency: %s → %s %s\n", depsReq, depsOn, depsExternalReq)
+		} else {
+			fmt.Printf("Added dependency: %s → %s\n", p.Metadata.ID, depsOn)
+		}
+	},
+}
+
+var depsRemoveCmd = &cobra.Command{
+	Use:   "remove [file]",
+	Short: "Remove all dependencies on another PRD",
+	Run: func(cmd *cobra.Command, args []string) {
+		path := getPRDPath(args)
+		p, err := prd.Load(path)
+		if err != nil {
+			exitWithError("Failed to load PRD: %v", err)
+		}
+
+		removed, err := prd.RemoveDependencies(p, depsOn)
+		if err != nil {
+			exitWithError("Failed to remove dependencies: %v", err)
+		}
+		if removed == 0 {
+			exitWithError("No dependencies on %s", depsOn)
+		}
+
+		if savePRD(os.Stdout, p, path) {
+			fmt.Printf("Removed %d dependencies on %s\n", removed, depsOn)
+		}
+	},
+}
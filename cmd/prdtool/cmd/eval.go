@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/agent-team-prd/pkg/scoring"
+	"github.com/agentplexus/structured-evaluation/evaluation"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	evalOutput  string
+	evalWeights map[string]string
+	evalJSON    bool
+
+	evalLLMWeight       float64
+	evalCategoryWeights map[string]string
+	evalThreshold       float64
+)
+
+var evalCmd = &cobra.Command{
+	Use:   "eval",
+	Short: "Produce structured-evaluation reports for a PRD",
+	Long: `Produce EvaluationReport JSON (github.com/agentplexus/structured-evaluation)
+for a PRD.
+
+Subcommands:
+  template      - Empty report for an LLM judge to fill in
+  deterministic - Report from the rule-based scorer
+  merge         - Blend a deterministic report with an LLM judge's report
+  categories    - List the rubric categories, weights and owners
+
+Examples:
+  prdtool eval template PRD.json -o eval-template.json
+  prdtool eval template --weight problem_definition=0.3 --weight risk_management=0.1
+  prdtool eval deterministic PRD.json -o eval-deterministic.json
+  prdtool eval merge eval-deterministic.json eval-llm.json`,
+}
+
+var evalTemplateCmd = &cobra.Command{
+	Use:   "template [file]",
+	Short: "Generate an EvaluationReport template for an LLM judge",
+	Long: `Generate an EvaluationReport with one pending entry per rubric category.
+
+The judge fills in each category's score, justification and findings.
+Use --weight to override the default weight of a category.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		path := getPRDPath(args)
+		p, err := prd.Load(path)
+		if err != nil {
+			exitWithError("Failed to load PRD: %v", err)
+		}
+
+		weights, err := parseWeights(evalWeights)
+		if err != nil {
+			exitWithError("%v", err)
+		}
+
+		if len(weights) == 0 {
+			writeJSON(prd.GenerateEvaluationTemplate(p, path), evalOutput)
+		} else {
+			writeJSON(prd.GenerateEvaluationTemplateWithWeights(p, path, weights), evalOutput)
+		}
+	},
+}
+
+var evalDeterministicCmd = &cobra.Command{
+	Use:   "deterministic [file]",
+	Short: "Generate an EvaluationReport from the rule-based scorer",
+	Long: `Score the PRD with the rule-based scorer and emit the result in the
+same EvaluationReport format an LLM judge produces, so CI and judge
+results can be compared and merged.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		path := getPRDPath(args)
+		p, err := prd.Load(path)
+		if err != nil {
+			exitWithError("Failed to load PRD: %v", err)
+		}
+
+		writeJSON(prd.ScoreToEvaluationReport(p, path), evalOutput)
+	},
+}
+
+var evalMergeCmd = &cobra.Command{
+	Use:   "merge <deterministic.json> <llm.json>",
+	Short: "Blend deterministic and LLM judge evaluations",
+	Long: `Combine a deterministic EvaluationReport with one filled in by an LLM
+judge.
+
+Each category score is blended as (1-w)*deterministic + w*llm, where w is
+--llm-weight or a --category-weight override. Categories the judge left
+pending keep their deterministic score. Categories whose scores differ by
+more than --threshold are listed as the review agenda, and a passing
+decision becomes human_review while any remain.
+
+Examples:
+  prdtool eval merge det.json llm.json
+  prdtool eval merge det.json llm.json --llm-weight 0.7 --threshold 1.5
+  prdtool eval merge det.json llm.json --category-weight market_awareness=0.9 -o merged.json`,
+	Args: cobra.ExactArgs(2),
+	Run:  runEvalMerge,
+}
+
+var evalCategoriesCmd = &cobra.Command{
+	Use:   "categories",
+	Short: "List the rubric categories",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		categories := scoring.Categories()
+
+		if evalJSON {
+			writeJSON(categories, "")
+			return
+		}
+
+		bold := color.New(color.Bold).SprintFunc()
+		for _, c := range categories {
+			fmt.Printf("%s (%s) %.0f%%\n", bold(c.Name), c.ID, c.Weight*100)
+			if c.Owner != "" {
+				fmt.Printf("  Owner: %s\n", c.Owner)
+			}
+			if c.Description != "" {
+				fmt.Printf("  %s\n", c.Description)
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(evalCmd)
+
+	evalCmd.AddCommand(evalTemplateCmd)
+	evalCmd.AddCommand(evalDeterministicCmd)
+	evalCmd.AddCommand(evalMergeCmd)
+	evalCmd.AddCommand(evalCategoriesCmd)
+
+	evalCmd.PersistentFlags().StringVarP(&evalOutput, "output", "o", "", "Write the report to a file instead of stdout")
+	evalTemplateCmd.Flags().StringToStringVar(&evalWeights, "weight", nil, "Category weight override as category=weight (repeatable)")
+	evalCategoriesCmd.Flags().BoolVar(&evalJSON, "json", false, "Output as JSON")
+
+	evalMergeCmd.Flags().Float64Var(&evalLLMWeight, "llm-weight", scoring.DefaultLLMWeight, "Share of each category score taken from the LLM judge (0-1)")
+	evalMergeCmd.Flags().StringToStringVar(&evalCategoryWeights, "category-weight", nil, "Per-category LLM weight as category=weight (repeatable)")
+	evalMergeCmd.Flags().Float64Var(&evalThreshold, "threshold", scoring.DefaultDisagreementThreshold, "Score difference that flags a category for review")
+	evalMergeCmd.Flags().BoolVar(&evalJSON, "json", false, "Output as JSON")
+
+	mustCompleteFlag(evalTemplateCmd, "weight", completeCategoryWeights)
+	mustCompleteFlag(evalMergeCmd, "category-weight", completeCategoryWeights)
+}
+
+func runEvalMerge(cmd *cobra.Command, args []string) {
+	det, err := readEvaluationReport(args[0])
+	if err != nil {
+		exitWithError("%v", err)
+	}
+	llm, err := readEvaluationReport(args[1])
+	if err != nil {
+		exitWithError("%v", err)
+	}
+
+	categoryWeights, err := parseWeights(evalCategoryWeights)
+	if err != nil {
+		exitWithError("%v", err)
+	}
+
+	result, err := scoring.MergeEvaluations(det, llm, scoring.MergeOptions{
+		LLMWeight:             evalLLMWeight,
+		CategoryLLMWeights:    categoryWeights,
+		DisagreementThreshold: evalThreshold,
+		RerunCommand:          "prdtool eval merge " + args[0] + " " + args[1],
+	})
+	if err != nil {
+		exitWithError("Failed to merge evaluations: %v", err)
+	}
+
+	if evalJSON || evalOutput != "" {
+		writeJSON(result, evalOutput)
+		return
+	}
+	printMergeResult(det, llm, result)
+}
+
+func printMergeResult(det, llm *evaluation.EvaluationReport, result *scoring.MergeResult) {
+	bold := color.New(color.Bold).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	llmScores := make(map[string]float64)
+	for _, c := range llm.Categories {
+		if c.Status != evaluation.CategoryPending {
+			llmScores[c.Category] = c.Score
+		}
+	}
+	detScores := make(map[string]float64)
+	for _, c := range det.Categories {
+		detScores[c.Category] = c.Score
+	}
+
+	report := result.Report
+	fmt.Printf("%s\n\n", bold("Merged Evaluation"))
+	fmt.Printf("  %-25s %6s %6s %6s\n", "Category", "Det", "LLM", "Merged")
+	for _, c := range report.Categories {
+		fmt.Printf("  %-25s %6s %6s %6.1f\n", scoring.CategoryName(c.Category),
+			scoreOrDash(detScores, c.Category), scoreOrDash(llmScores, c.Category), c.Score)
+	}
+
+	fmt.Printf("\nScore:    %.1f/10 (LLM weight %.2f)\n", report.WeightedScore, result.LLMWeight)
+	fmt.Printf("Decision: %s\n", report.Decision.Status)
+	fmt.Printf("          %s\n", report.Decision.Rationale)
+
+	if len(result.Disagreements) == 0 {
+		fmt.Printf("\nNo categories disagree by more than %.1f.\n", result.Threshold)
+		return
+	}
+
+	fmt.Printf("\n%s Review agenda (%d categories disagree by more than %.1f):\n", yellow("●"), len(result.Disagreements), result.Threshold)
+	for i, d := range result.Disagreements {
+		fmt.Printf("  %d. %s: deterministic %.1f, LLM %.1f (%+.1f)\n", i+1, scoring.CategoryName(d.Category), d.Deterministic, d.LLM, d.Difference)
+		if d.LLMJustification != "" {
+			fmt.Printf("     LLM: %s\n", d.LLMJustification)
+		}
+	}
+}
+
+func scoreOrDash(scores map[string]float64, category string) string {
+	if s, ok := scores[category]; ok {
+		return fmt.Sprintf("%.1f", s)
+	}
+	return "-"
+}
+
+// readEvaluationReport reads an EvaluationReport JSON file.
+func readEvaluationReport(path string) (*evaluation.EvaluationReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var report evaluation.EvaluationReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &report, nil
+}
+
+// parseWeights converts --weight category=value flags to weights.
+func parseWeights(flags map[string]string) (map[string]float64, error) {
+	weights := make(map[string]float64, len(flags))
+	for category, value := range flags {
+		w, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight for %s: %s", category, value)
+		}
+		weights[category] = w
+	}
+	if err := scoring.ValidateWeights(weights); err != nil {
+		return nil, err
+	}
+	return weights, nil
+}
+
+// writeJSON prints v as indented JSON, or writes it to output if set.
+func writeJSON(v interface{}, output string) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		exitWithError("Failed to marshal JSON: %v", err)
+	}
+
+	if output == "" {
+		fmt.Println(string(data))
+		return
+	}
+
+	if err := os.WriteFile(output, append(data, '\n'), 0600); err != nil {
+		exitWithError("Failed to write %s: %v", output, err)
+	}
+	fmt.Printf("Report written to: %s\n", output)
+}
@@ -1,10 +1,11 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
-	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/agent-team-prd/pkg/service"
 	"github.com/spf13/cobra"
 )
 
@@ -40,31 +41,26 @@ func init() {
 }
 
 func runInit(cmd *cobra.Command, args []string) {
-	path := getPRDPath(args)
-
-	// Check if file exists
-	if _, err := os.Stat(path); err == nil {
-		exitWithError("PRD file already exists: %s. Use --file to specify a different path.", path)
-	}
-
-	// Generate ID if not provided
-	id := initID
-	if id == "" {
-		id = prd.GenerateID()
+	res, err := prdService.Init(service.InitRequest{
+		Path:   getPRDPath(args),
+		ID:     initID,
+		Title:  initTitle,
+		Owner:  initOwner,
+		DryRun: dryRun,
+	})
+	if errors.Is(err, service.ErrPRDExists) {
+		exitWithError("%v. Use --file to specify a different path.", err)
+	} else if err != nil {
+		exitWithError("%v", err)
 	}
-
-	// Create new PRD
-	owner := prd.Person{Name: initOwner}
-	newPRD := prd.New(id, initTitle, owner)
-
-	// Save to file
-	if err := prd.Save(newPRD, path); err != nil {
-		exitWithError("Failed to save PRD: %v", err)
+	if res.Preview != nil {
+		printPreview(os.Stdout, res.Path, res.Preview)
+		return
 	}
 
-	fmt.Printf("Created new PRD: %s\n", path)
-	fmt.Printf("  ID:    %s\n", id)
-	fmt.Printf("  Title: %s\n", initTitle)
+	fmt.Printf("Created new PRD: %s\n", res.Path)
+	fmt.Printf("  ID:    %s\n", res.ID)
+	fmt.Printf("  Title: %s\n", res.PRD.Metadata.Title)
 	fmt.Printf("  Owner: %s\n", initOwner)
 	fmt.Println("\nNext steps:")
 	fmt.Println("  1. Add a problem statement: prdtool add problem --statement \"...\"")
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/agentplexus/agent-team-prd/pkg/interview"
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	interviewState   string
+	interviewRestart bool
+)
+
+var interviewCmd = &cobra.Command{
+	Use:   "interview [file]",
+	Short: "Fill PRD gaps by answering targeted questions",
+	Long: `Score the PRD and ask questions about its weakest categories.
+
+Each answer is applied with the same operations as 'prdtool add', the PRD
+is saved and rescored, and the next question comes from whichever category
+is now weakest. The interview ends once the score reaches the approval
+threshold (8.0), when no questions remain, or when you type /quit.
+
+Press Enter on a required field or type /skip to skip a question.
+
+Progress is saved to a state file (PRD.interview.json for PRD.json) after
+every answer. Running the command again resumes the interview; use
+--restart to start over.
+
+Examples:
+  prdtool interview
+  prdtool interview docs/PRD.json
+  prdtool interview --restart
+  prdtool interview --state review.interview.json`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runInterview,
+}
+
+func init() {
+	rootCmd.AddCommand(interviewCmd)
+
+	interviewCmd.Flags().StringVar(&interviewState, "state", "", "Interview state file (default: <file>.interview.json)")
+	interviewCmd.Flags().BoolVar(&interviewRestart, "restart", false, "Discard saved progress and start a new interview")
+}
+
+func runInterview(cmd *cobra.Command, args []string) {
+	if dryRun {
+		exitWithError("interview does not support --dry-run")
+	}
+
+	path := getPRDPath(args)
+	p, err := prd.Load(path)
+	if err != nil {
+		exitWithError("Failed to load PRD: %v", err)
+	}
+
+	statePath := interviewState
+	if statePath == "" {
+		statePath = interview.StatePath(path)
+	}
+
+	var state *interview.State
+	if !interviewRestart {
+		state, err = interview.LoadState(statePath)
+		if errors.Is(err, fs.ErrNotExist) {
+			state = nil
+		} else if err != nil {
+			exitWithError("Failed to load interview state: %v", err)
+		}
+	}
+
+	session, err := interview.NewSession(p, state)
+	if err != nil {
+		exitWithError("%v (use --restart to start a new interview)", err)
+	}
+
+	bold := color.New(color.Bold).SprintFunc()
+	fmt.Printf("%s %s\n", bold("Interview:"), p.Metadata.Title)
+	if state != nil {
+		fmt.Printf("Resuming interview started %s (%d answered, %d skipped)\n",
+			state.StartedAt.Local().Format("2006-01-02 15:04"), len(state.History), len(state.Skipped))
+	}
+
+	runner := &interview.Runner{
+		Session: session,
+		In:      os.Stdin,
+		Out:     os.Stdout,
+		Save: func() error {
+			if err := prd.Save(session.PRD, path); err != nil {
+				return fmt.Errorf("failed to save PRD: %w", err)
+			}
+			return session.State.Save(statePath)
+		},
+	}
+
+	err = runner.Run()
+	switch {
+	case errors.Is(err, interview.ErrQuit):
+		fmt.Printf("\nProgress saved to %s. Run 'prdtool interview' again to resume.\n", statePath)
+	case err != nil:
+		exitWithError("%v", err)
+	default:
+		fmt.Printf("Final score: %.1f/10\n", session.Score())
+	}
+}
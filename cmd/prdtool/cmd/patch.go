@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/agent-team-prd/pkg/service"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	patchJSON string
+	patchFile string
+)
+
+var patchCmd = &cobra.Command{
+	Use:   "patch [file]",
+	Short: "Edit PRD fields with a JSON Patch or merge patch",
+	Long: `Edit arbitrary PRD fields in place.
+
+The patch is either an RFC 6902 JSON Patch (a JSON array of operations)
+or an RFC 7396 JSON Merge Patch (a JSON object); the format is detected
+automatically. The patched PRD is validated before it is saved. If any
+operation fails, or the patch introduces validation errors, nothing is
+written.
+
+Examples:
+  prdtool patch --patch '[{"op":"replace","path":"/metadata/title","value":"Passwordless Login"}]'
+  prdtool patch PRD.json --patch '{"metadata":{"version":"1.1.0"}}'
+  prdtool patch --patch-file changes.json
+  cat changes.json | prdtool patch --patch-file -`,
+	Run: runPatch,
+}
+
+func init() {
+	rootCmd.AddCommand(patchCmd)
+
+	patchCmd.Flags().StringVar(&patchJSON, "patch", "", "Patch document as JSON")
+	patchCmd.Flags().StringVar(&patchFile, "patch-file", "", "File containing the patch document (- for stdin)")
+	patchCmd.MarkFlagsOneRequired("patch", "patch-file")
+	patchCmd.MarkFlagsMutuallyExclusive("patch", "patch-file")
+}
+
+func runPatch(cmd *cobra.Command, args []string) {
+	path := getPRDPath(args)
+
+	data, err := readPatch()
+	if err != nil {
+		exitWithError("Failed to read patch: %v", err)
+	}
+
+	ops, merge, err := prd.ParsePatch(data)
+	if err != nil {
+		exitWithError("%v", err)
+	}
+
+	res, err := prdService.Patch(service.PatchRequest{
+		Path:       path,
+		Operations: ops,
+		Merge:      merge,
+		DryRun:     dryRun,
+	})
+	if err != nil {
+		var verr *prd.PatchValidationError
+		if errors.As(err, &verr) {
+			red := color.New(color.FgRed).SprintFunc()
+			for _, e := range verr.Errors {
+				fmt.Fprintf(os.Stderr, "  %s %s: %s\n", red("✗"), e.Field, e.Message)
+			}
+			exitWithError("Patch rejected: it introduces %d validation errors", len(verr.Errors))
+		}
+		exitWithError("%v", err)
+	}
+	printResult(res, nil)
+
+	yellow := color.New(color.FgYellow).SprintFunc()
+	for _, w := range prd.Validate(res.PRD).Warnings {
+		fmt.Printf("  %s %s: %s\n", yellow("!"), w.Field, w.Message)
+	}
+}
+
+func readPatch() ([]byte, error) {
+	switch patchFile {
+	case "":
+		return []byte(patchJSON), nil
+	case "-":
+		return io.ReadAll(os.Stdin)
+	default:
+		return os.ReadFile(patchFile)
+	}
+}
@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/agent-team-prd/pkg/workspace"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	portfolioStatus string
+	portfolioTag    string
+	portfolioJSON   bool
+)
+
+var portfolioCmd = &cobra.Command{
+	Use:   "portfolio",
+	Short: "Work with all PRDs in a workspace",
+	Long: `Discover every PRD JSON file under a directory and report on them
+as a portfolio.
+
+A JSON file is treated as a PRD when it has a metadata object with an ID
+and title. Hidden directories, vendor and node_modules are skipped.
+
+Subcommands:
+  list     - List PRDs with status and owner
+  score    - Score every PRD
+  validate - Validate every PRD
+
+Examples:
+  prdtool portfolio list
+  prdtool portfolio list ./docs --status draft
+  prdtool portfolio score --tag platform
+  prdtool portfolio validate --json`,
+}
+
+var portfolioListCmd = &cobra.Command{
+	Use:   "list [dir]",
+	Short: "List PRDs in a workspace",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runPortfolio(args, workspace.PortfolioOptions{})
+	},
+}
+
+var portfolioScoreCmd = &cobra.Command{
+	Use:   "score [dir]",
+	Short: "Score every PRD in a workspace",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runPortfolio(args, workspace.PortfolioOptions{Score: true})
+	},
+}
+
+var portfolioValidateCmd = &cobra.Command{
+	Use:   "validate [dir]",
+	Short: "Validate every PRD in a workspace",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		pf := runPortfolio(args, workspace.PortfolioOptions{Validate: true})
+		if pf.Summary.Invalid != nil && *pf.Summary.Invalid > 0 {
+			exitWithError("%d of %d PRDs failed validation", *pf.Summary.Invalid, pf.Summary.Total+pf.Summary.Failed)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(portfolioCmd)
+
+	portfolioCmd.AddCommand(portfolioListCmd)
+	portfolioCmd.AddCommand(portfolioScoreCmd)
+	portfolioCmd.AddCommand(portfolioValidateCmd)
+
+	portfolioCmd.PersistentFlags().StringVar(&portfolioStatus, "status", "", valuesHelp("Filter by status", prd.StatusValues()))
+	portfolioCmd.PersistentFlags().StringVar(&portfolioTag, "tag", "", "Filter by metadata tag")
+	portfolioCmd.PersistentFlags().BoolVar(&portfolioJSON, "json", false, "Output as JSON")
+	mustCompleteValues(portfolioCmd, "status", prd.StatusValues())
+}
+
+// getWorkspaceDir returns the workspace directory from args, defaulting to
+// the current directory.
+func getWorkspaceDir(args []string) string {
+	if len(args) > 0 {
+		return args[0]
+	}
+	return "."
+}
+
+func runPortfolio(args []string, opts workspace.PortfolioOptions) *workspace.Portfolio {
+	root := getWorkspaceDir(args)
+
+	ws, err := workspace.Discover(root)
+	if err != nil {
+		exitWithError("Failed to discover PRDs: %v", err)
+	}
+
+	docs := ws.Select(workspace.Filter{Status: portfolioStatus, Tag: portfolioTag})
+	pf := workspace.Summarize(root, docs, opts)
+	pf.AddFailures(ws.Failures)
+
+	if portfolioJSON {
+		output, err := json.MarshalIndent(pf, "", "  ")
+		if err != nil {
+			exitWithError("Failed to marshal JSON: %v", err)
+		}
+		fmt.Println(string(output))
+		return pf
+	}
+
+	printPortfolio(pf, opts)
+	return pf
+}
+
+func printPortfolio(pf *workspace.Portfolio, opts workspace.PortfolioOptions) {
+	bold := color.New(color.Bold).SprintFunc()
+
+	fmt.Printf("%s\n", bold("PRD Portfolio"))
+	fmt.Printf("════════════════════════════════════════\n\n")
+
+	if len(pf.Entries) == 0 && len(pf.Failures) == 0 {
+		fmt.Printf("No PRDs found in %s\n", pf.Root)
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	header := "ID\tTITLE\tSTATUS\tOWNER"
+	if opts.Score {
+		header += "\tSCORE\tDECISION"
+	}
+	if opts.Validate {
+		header += "\tVALID\tERRORS\tWARNINGS"
+	}
+	fmt.Fprintln(w, header+"\tPATH")
+
+	for _, e := range pf.Entries {
+		row := fmt.Sprintf("%s\t%s\t%s\t%s", e.ID, e.Title, e.Status, e.Owner)
+		if opts.Score && e.Score != nil {
+			row += fmt.Sprintf("\t%.1f\t%s", *e.Score, e.Decision)
+		}
+		if opts.Validate && e.Valid != nil {
+			valid := "yes"
+			if !*e.Valid {
+				valid = "no"
+			}
+			row += fmt.Sprintf("\t%s\t%d\t%d", valid, e.Errors, e.Warnings)
+		}
+		fmt.Fprintln(w, row+"\t"+e.Path)
+	}
+	if err := w.Flush(); err != nil {
+		exitWithError("Failed to write table: %v", err)
+	}
+
+	if len(pf.Failures) > 0 {
+		red := color.New(color.FgRed).SprintFunc()
+		fmt.Printf("\n%s\n", bold("Failed to Load"))
+		for _, f := range pf.Failures {
+			fmt.Printf("  %s %s: %s\n", red("✗"), f.Path, f.Error)
+		}
+	}
+
+	// Summary
+	fmt.Printf("\n%s\n", bold("Summary"))
+	fmt.Printf("  Total: %d\n", pf.Summary.Total)
+	statuses := make([]string, 0, len(pf.Summary.ByStatus))
+	for s := range pf.Summary.ByStatus {
+		statuses = append(statuses, s)
+	}
+	sort.Strings(statuses)
+	for _, s := range statuses {
+		fmt.Printf("  %-12s %d\n", s+":", pf.Summary.ByStatus[s])
+	}
+	if pf.Summary.AverageScore != nil {
+		fmt.Printf("  Average score: %.1f\n", *pf.Summary.AverageScore)
+	}
+	if pf.Summary.Failed > 0 {
+		fmt.Printf("  Failed to load: %d\n", pf.Summary.Failed)
+	}
+	if pf.Summary.Invalid != nil {
+		fmt.Printf("  Invalid: %d\n", *pf.Summary.Invalid)
+	}
+}
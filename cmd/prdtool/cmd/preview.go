@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/agent-team-prd/pkg/scoring"
+	"github.com/agentplexus/agent-team-prd/pkg/service"
+	"github.com/fatih/color"
+)
+
+// maxPreviewValue is the longest value printed in a dry-run diff.
+const maxPreviewValue = 60
+
+// prdService runs the commands that change a PRD.
+var prdService = service.New()
+
+// savePRD saves p to path and reports whether it did. With --dry-run it
+// prints what would change to w instead of writing it.
+func savePRD(w io.Writer, p *prd.PRD, path string) bool {
+	preview, err := service.Save(path, p, dryRun)
+	if err != nil {
+		exitWithError("%v", err)
+	}
+	if preview != nil {
+		printPreview(w, path, preview)
+		return false
+	}
+	return true
+}
+
+// printResult prints the changes of a dry run, or the result's message,
+// or exits with err.
+func printResult(res *service.Result, err error) {
+	if err != nil {
+		exitWithError("%v", err)
+	}
+	if res.Preview != nil {
+		printPreview(os.Stdout, res.Path, res.Preview)
+		return
+	}
+	fmt.Println(res.Message)
+}
+
+func printPreview(w io.Writer, path string, preview *service.Preview) {
+	green := color.New(color.FgGreen).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	fmt.Fprintf(w, "Dry run: %s was not modified\n\n", path)
+
+	fmt.Fprintf(w, "Changes (%d):\n", len(preview.Changes))
+	for _, c := range preview.Changes {
+		switch c.Type {
+		case prd.ChangeAdded:
+			fmt.Fprintf(w, "  %s %s: %s\n", green("+"), c.Path, previewValue(c.After))
+		case prd.ChangeRemoved:
+			fmt.Fprintf(w, "  %s %s: %s\n", red("-"), c.Path, previewValue(c.Before))
+		default:
+			fmt.Fprintf(w, "  %s %s: %s → %s\n", yellow("~"), c.Path, previewValue(c.Before), previewValue(c.After))
+		}
+	}
+
+	delta := preview.Score
+	fmt.Fprintf(w, "\nScore: %.1f → %.1f (%+.1f)\n", delta.Before, delta.After, delta.Change)
+	if delta.DecisionBefore != delta.DecisionAfter {
+		fmt.Fprintf(w, "Decision: %s → %s\n", valueOrNone(delta.DecisionBefore), delta.DecisionAfter)
+	}
+	for _, cat := range delta.Categories {
+		fmt.Fprintf(w, "  %-25s %4.1f → %4.1f\n", scoring.CategoryName(cat.Category), cat.Before, cat.After)
+	}
+	fmt.Fprintln(w)
+}
+
+func previewValue(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	s := string(data)
+	if len(s) > maxPreviewValue {
+		s = s[:maxPreviewValue-3] + "..."
+	}
+	return s
+}
+
+func valueOrNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
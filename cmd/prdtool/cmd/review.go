@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/agentplexus/agent-team-prd/pkg/service"
+	"github.com/agentplexus/structured-evaluation/evaluation"
+	"github.com/spf13/cobra"
+)
+
+var reviewCmd = &cobra.Command{
+	Use:   "review",
+	Short: "Manage the review recorded in a PRD",
+	Long: `Manage the review stored in the PRD's reviews section.
+
+Subcommands:
+  record - Record an EvaluationReport in the PRD`,
+}
+
+var reviewRecordCmd = &cobra.Command{
+	Use:   "record <report.json> [file]",
+	Short: "Record an EvaluationReport in the PRD",
+	Long: `Write a finalized EvaluationReport's category scores, decision, blockers
+and revision triggers into the PRD's reviews section, replacing any
+earlier review.
+
+The report can come from 'prdtool eval deterministic', an LLM judge, or
+'prdtool eval merge --json' (its merged report is used). Critical and high
+findings become blockers; every finding above info severity becomes a
+revision trigger.
+
+The exec view uses the recorded review instead of rescoring the PRD.
+
+Examples:
+  prdtool review record merged.json
+  prdtool review record eval-llm.json docs/auth.json
+  prdtool review record merged.json --dry-run`,
+	Args: cobra.RangeArgs(1, 2),
+	Run:  runReviewRecord,
+}
+
+func init() {
+	rootCmd.AddCommand(reviewCmd)
+	reviewCmd.AddCommand(reviewRecordCmd)
+}
+
+func runReviewRecord(cmd *cobra.Command, args []string) {
+	report, err := readReviewReport(args[0])
+	if err != nil {
+		exitWithError("%v", err)
+	}
+
+	res, err := prdService.RecordReview(service.RecordReviewRequest{
+		Path:   getPRDPath(args[1:]),
+		Report: report,
+		DryRun: dryRun,
+	})
+	if err != nil {
+		exitWithError("Failed to record review: %v", err)
+	}
+	if res.Preview != nil {
+		printPreview(os.Stdout, res.Path, res.Preview)
+		return
+	}
+
+	reviews := res.PRD.Reviews
+	fmt.Printf("Recorded review: %s (%.1f/10)\n", reviews.Decision, reviews.QualityScores.OverallScore)
+	fmt.Printf("  Blockers:          %d\n", len(reviews.Blockers))
+	fmt.Printf("  Revision triggers: %d\n", len(reviews.RevisionTriggers))
+}
+
+// readReviewReport reads an EvaluationReport, or the merged report from the
+// JSON output of 'prdtool eval merge'.
+func readReviewReport(path string) (*evaluation.EvaluationReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var merged struct {
+		Report *evaluation.EvaluationReport `json:"report"`
+	}
+	if err := json.Unmarshal(data, &merged); err == nil && merged.Report != nil {
+		return merged.Report, nil
+	}
+
+	return readEvaluationReport(path)
+}
@@ -9,6 +9,7 @@ import (
 
 var (
 	prdFile string
+	dryRun  bool
 	version = "0.1.0"
 )
 
@@ -38,6 +39,7 @@ func Execute() error {
 
 func init() {
 	rootCmd.PersistentFlags().StringVarP(&prdFile, "file", "f", "PRD.json", "PRD file path")
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Show the changes and score delta without saving")
 }
 
 // getPRDPath returns the PRD file path from args or flag.
@@ -13,6 +13,8 @@ import (
 var (
 	scoreJSON    bool
 	scoreVerbose bool
+	scoreExplain bool
+	nextLimit    int
 )
 
 var scoreCmd = &cobra.Command{
@@ -35,6 +37,10 @@ Categories scored:
   - Technical Feasibility (5%)
   - Risk Management (5%)
 
+Use --explain to list each category's score, whether it passed, and the
+checks it failed, with the JSON Pointers of the content they are about,
+for use with 'prdtool patch'.
+
 Thresholds:
   ≥8.0  → Approve (ready for implementation)
   ≥6.5  → Revise (minor issues)
@@ -44,15 +50,40 @@ Thresholds:
 Examples:
   prdtool score PRD.json
   prdtool score --verbose PRD.json
+  prdtool score --explain PRD.json
   prdtool score --json PRD.json`,
 	Run: runScore,
 }
 
+var scoreNextCmd = &cobra.Command{
+	Use:   "next [file]",
+	Short: "Suggest the changes that would most improve the score",
+	Long: `List concrete next actions, such as "Add a baseline to KR-2", ranked by
+projected gain in the weighted score.
+
+Each failing rubric check is simulated as fixed with placeholder content,
+and the resulting change in score is the action's gain. Every action comes
+with the prdtool command and MCP tool call that makes the change; replace
+the values in angle brackets before running them.
+
+Examples:
+  prdtool score next
+  prdtool score next --limit 3 PRD.json
+  prdtool score next --json`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runScoreNext,
+}
+
 func init() {
 	rootCmd.AddCommand(scoreCmd)
+	scoreCmd.AddCommand(scoreNextCmd)
 
 	scoreCmd.Flags().BoolVar(&scoreJSON, "json", false, "Output as JSON")
 	scoreCmd.Flags().BoolVarP(&scoreVerbose, "verbose", "v", false, "Show detailed scoring breakdown")
+	scoreCmd.Flags().BoolVar(&scoreExplain, "explain", false, "Show the checks behind each category score")
+
+	scoreNextCmd.Flags().BoolVar(&scoreJSON, "json", false, "Output as JSON")
+	scoreNextCmd.Flags().IntVarP(&nextLimit, "limit", "n", 10, "Maximum number of actions (0 for all)")
 }
 
 func runScore(cmd *cobra.Command, args []string) {
@@ -68,7 +99,14 @@ func runScore(cmd *cobra.Command, args []string) {
 	result := scoring.Score(p)
 
 	if scoreJSON {
-		output, err := json.MarshalIndent(result, "", "  ")
+		var v interface{} = result
+		if scoreExplain {
+			v = struct {
+				*scoring.ScoringResult
+				Explanation []scoring.CategoryExplanation `json:"explanation"`
+			}{result, scoring.Explain(p)}
+		}
+		output, err := json.MarshalIndent(v, "", "  ")
 		if err != nil {
 			exitWithError("Failed to marshal JSON: %v", err)
 		}
@@ -121,6 +159,10 @@ func runScore(cmd *cobra.Command, args []string) {
 		fmt.Println()
 	}
 
+	if scoreExplain {
+		printExplanation(scoring.Explain(p))
+	}
+
 	// Strengths (categories with score >= 8)
 	var strengths []string
 	for _, cat := range result.CategoryScores {
@@ -173,6 +215,83 @@ func runScore(cmd *cobra.Command, args []string) {
 	fmt.Printf("ID:  %s\n", p.Metadata.ID)
 }
 
+func runScoreNext(cmd *cobra.Command, args []string) {
+	path := getPRDPath(args)
+
+	p, err := prd.Load(path)
+	if err != nil {
+		exitWithError("Failed to load PRD: %v", err)
+	}
+
+	commandPath := path
+	if commandPath == "PRD.json" {
+		commandPath = ""
+	}
+	actions := scoring.NextActions(p, commandPath)
+	if nextLimit > 0 && len(actions) > nextLimit {
+		actions = actions[:nextLimit]
+	}
+
+	if scoreJSON {
+		output, err := json.MarshalIndent(actions, "", "  ")
+		if err != nil {
+			exitWithError("Failed to marshal JSON: %v", err)
+		}
+		fmt.Println(string(output))
+		return
+	}
+
+	if len(actions) == 0 {
+		fmt.Println("No further improvements found: every rubric check passes.")
+		return
+	}
+
+	bold := color.New(color.Bold).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+	dim := color.New(color.Faint).SprintFunc()
+
+	fmt.Printf("%s (current score %.1f)\n", bold("Next Actions"), scoring.Score(p).WeightedScore)
+	fmt.Printf("────────────────────────────────────────\n")
+	for i, a := range actions {
+		fmt.Printf("%2d. %s %s\n", i+1, a.Action, green(fmt.Sprintf("+%.2f", a.Gain)))
+		fmt.Printf("    %s\n", dim(formatCategoryName(a.Category)))
+		fmt.Printf("    $ %s\n", a.Command)
+	}
+}
+
+func printExplanation(explanations []scoring.CategoryExplanation) {
+	bold := color.New(color.Bold).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+	dim := color.New(color.Faint).SprintFunc()
+
+	fmt.Printf("%s\n", bold("Score Explanation"))
+	fmt.Printf("────────────────────────────────────────\n")
+
+	for _, e := range explanations {
+		icon := green("✓")
+		if !e.Passed {
+			icon = red("✗")
+		}
+		fmt.Printf("  %s %s  %.1f/%.0f (weight: %.0f%%)\n", icon, bold(e.Name), e.Score, e.MaxScore, e.Weight*100)
+		if e.Justification != "" {
+			fmt.Printf("      %s\n", dim(e.Justification))
+		}
+		for _, c := range e.Checks {
+			icon := yellow("!")
+			if c.Severity == "blocker" || c.Severity == "major" {
+				icon = red("!")
+			}
+			fmt.Printf("    %s %s (%s)\n", icon, c.Description, c.ID)
+			for _, path := range c.Paths {
+				fmt.Printf("        %s\n", dim(path))
+			}
+		}
+	}
+	fmt.Println()
+}
+
 func formatDecision(decision string) string {
 	green := color.New(color.FgGreen).SprintFunc()
 	yellow := color.New(color.FgYellow).SprintFunc()
@@ -193,20 +312,5 @@ func formatDecision(decision string) string {
 }
 
 func formatCategoryName(category string) string {
-	names := map[string]string{
-		"problem_definition":    "Problem Definition",
-		"user_understanding":    "User Understanding",
-		"market_awareness":      "Market Awareness",
-		"solution_fit":          "Solution Fit",
-		"scope_discipline":      "Scope Discipline",
-		"requirements_quality":  "Requirements Quality",
-		"ux_coverage":           "UX Coverage",
-		"technical_feasibility": "Technical Feasibility",
-		"metrics_quality":       "Metrics Quality",
-		"risk_management":       "Risk Management",
-	}
-	if name, ok := names[category]; ok {
-		return name
-	}
-	return category
+	return scoring.CategoryName(category)
 }
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/agentplexus/agent-team-prd/pkg/server"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveAddr     string
+	serveReadOnly bool
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve [dir]",
+	Short: "Browse and review PRDs in a local web UI",
+	Long: `Start a local web server for the PRDs in a workspace directory.
+
+The browser UI lists every PRD in the workspace with its status and score.
+For each PRD it shows the score and validation results, renders the PM,
+exec and six-pager views, and lets reviewers add comments and make simple
+edits to any entity. Changes are saved to the PRD files.
+
+The UI is backed by a JSON API under /api (see the documentation). The
+server listens on localhost by default and needs no external services.
+
+Examples:
+  prdtool serve
+  prdtool serve ./docs --addr 127.0.0.1:9000
+  prdtool serve --read-only`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runServe,
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "127.0.0.1:8080", "Address to listen on")
+	serveCmd.Flags().BoolVar(&serveReadOnly, "read-only", false, "Disable comments and edits")
+}
+
+func runServe(cmd *cobra.Command, args []string) {
+	root := "."
+	if len(args) > 0 {
+		root = args[0]
+	}
+	if info, err := os.Stat(root); err != nil || !info.IsDir() {
+		exitWithError("Not a directory: %s", root)
+	}
+
+	s := server.New(root)
+	s.Addr = serveAddr
+	s.ReadOnly = serveReadOnly || dryRun
+
+	ln, err := net.Listen("tcp", serveAddr)
+	if err != nil {
+		exitWithError("Failed to listen on %s: %v", serveAddr, err)
+	}
+
+	srv := &http.Server{
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	bold := color.New(color.Bold).SprintFunc()
+	fmt.Printf("Serving PRDs in %s at %s\n", root, bold("http://"+ln.Addr().String()))
+	if s.ReadOnly {
+		fmt.Println("Read-only: comments and edits are disabled")
+	}
+	fmt.Println("Press Ctrl-C to stop")
+
+	if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		exitWithError("Server failed: %v", err)
+	}
+}
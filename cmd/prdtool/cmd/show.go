@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/agentplexus/agent-team-prd/pkg/coverage"
 	"github.com/agentplexus/agent-team-prd/pkg/prd"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -22,7 +23,8 @@ var showCmd = &cobra.Command{
 Shows the entire PRD or a specific section.
 
 Sections: metadata, problem, personas, market, objectives, solution,
-          requirements, ux, technical, risks, decisions
+          requirements, ux, technical, risks, decisions, dependencies,
+          approvals
 
 Examples:
   prdtool show PRD.json
@@ -36,6 +38,7 @@ func init() {
 
 	showCmd.Flags().StringVarP(&showSection, "section", "s", "", "Show specific section")
 	showCmd.Flags().BoolVar(&showJSON, "json", false, "Output as JSON")
+	mustCompleteValues(showCmd, "section", showSections)
 }
 
 func runShow(cmd *cobra.Command, args []string) {
@@ -94,6 +97,14 @@ func showAsJSON(p *prd.PRD, section string) {
 		}
 	case "decisions":
 		data = p.Decisions
+	case "dependencies":
+		deps, err := prd.GetDependencies(p)
+		if err != nil {
+			exitWithError("Failed to read dependencies: %v", err)
+		}
+		data = deps
+	case "approvals":
+		data = approvalStatus(p)
 	default:
 		exitWithError("Unknown section: %s", section)
 	}
@@ -231,10 +242,21 @@ func showSpecificSection(p *prd.PRD, section string) {
 
 	case "requirements":
 		fmt.Printf("%s\n\n", bold("REQUIREMENTS"))
+		report, _ := coverage.FromPRD(p)
+		tested := make(map[string]bool)
+		if report != nil {
+			for _, rc := range report.Requirements {
+				tested[rc.ID] = rc.Covered
+			}
+		}
 		if len(p.Requirements.Functional) > 0 {
 			fmt.Printf("  Functional Requirements:\n")
 			for _, r := range p.Requirements.Functional {
-				fmt.Printf("    [%s] (%s) %s\n", r.ID, r.Priority, r.Description)
+				marker := ""
+				if report != nil && !tested[r.ID] {
+					marker = " [untested]"
+				}
+				fmt.Printf("    [%s] (%s) %s%s\n", r.ID, r.Priority, r.Description, marker)
 			}
 		}
 		if len(p.Requirements.NonFunctional) > 0 {
@@ -246,6 +268,12 @@ func showSpecificSection(p *prd.PRD, section string) {
 		if len(p.Requirements.Functional) == 0 && len(p.Requirements.NonFunctional) == 0 {
 			fmt.Println("  No requirements defined")
 		}
+		if report != nil {
+			fmt.Printf("\n  Test Coverage: %d/%d requirements, %d/%d acceptance criteria (scanned %s)\n",
+				report.Summary.CoveredRequirements, report.Summary.TotalRequirements,
+				report.Summary.CoveredCriteria, report.Summary.TotalCriteria,
+				report.GeneratedAt.Format("2006-01-02"))
+		}
 
 	case "ux":
 		fmt.Printf("%s\n\n", bold("UX REQUIREMENTS"))
@@ -320,6 +348,40 @@ func showSpecificSection(p *prd.PRD, section string) {
 			fmt.Println()
 		}
 
+	case "dependencies":
+		fmt.Printf("%s\n\n", bold("DEPENDENCIES"))
+		deps, err := prd.GetDependencies(p)
+		if err != nil {
+			exitWithError("Failed to read dependencies: %v", err)
+		}
+		if deps.IsEmpty() {
+			fmt.Println("  No dependencies recorded")
+			return
+		}
+		for _, d := range deps.Documents {
+			fmt.Printf("  → %s", d.PRDID)
+			if d.Reason != "" {
+				fmt.Printf(": %s", d.Reason)
+			}
+			fmt.Println()
+		}
+		for _, d := range deps.Requirements {
+			fmt.Printf("  [%s] → %s %s", d.RequirementID, d.PRDID, d.ExternalRequirementID)
+			if d.Reason != "" {
+				fmt.Printf(": %s", d.Reason)
+			}
+			fmt.Println()
+		}
+
+	case "approvals":
+		fmt.Printf("%s\n\n", bold("APPROVALS"))
+		statuses := approvalStatus(p)
+		if len(statuses) == 0 {
+			fmt.Println("  No approvers listed")
+			return
+		}
+		printApprovals(statuses)
+
 	default:
 		exitWithError("Unknown section: %s", section)
 	}
@@ -407,5 +469,63 @@ func showFullPRD(p *prd.PRD) {
 		fmt.Printf("  %d total, %d high/critical impact\n\n", len(p.Risks), high)
 	}
 
+	// Approvals
+	if statuses := approvalStatus(p); len(statuses) > 0 {
+		fmt.Printf("%s\n", bold("APPROVALS"))
+		printApprovals(statuses)
+		fmt.Println()
+	}
+
 	fmt.Println("Use 'prdtool show --section <name>' for detailed section view")
 }
+
+// approvalStatus returns where each of the PRD's approvers stands on its
+// current content.
+func approvalStatus(p *prd.PRD) []prd.ApproverStatus {
+	statuses, err := prd.ApprovalStatus(p)
+	if err != nil {
+		exitWithError("Failed to read sign-offs: %v", err)
+	}
+	return statuses
+}
+
+// signOffSummary describes an approver's latest sign-off, with its
+// decision when the sign-off is stale.
+func signOffSummary(s prd.ApproverStatus) string {
+	summary := s.SignOff.SignedAt.Format("2006-01-02")
+	if s.State == prd.ApprovalStale {
+		summary = string(s.SignOff.Decision) + " " + summary
+	}
+	if s.SignOff.Version != "" {
+		summary += ", v" + s.SignOff.Version
+	}
+	return summary
+}
+
+func printApprovals(statuses []prd.ApproverStatus) {
+	green := color.New(color.FgGreen).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	for _, s := range statuses {
+		icon := yellow("○")
+		switch s.State {
+		case prd.ApprovalApproved:
+			icon = green("✓")
+		case prd.ApprovalRejected:
+			icon = red("✗")
+		}
+		name := s.Name
+		if s.Role != "" {
+			name += " (" + s.Role + ")"
+		}
+		fmt.Printf("  %s %s: %s", icon, name, s.State)
+		if s.SignOff != nil {
+			fmt.Printf(" (%s)", signOffSummary(s))
+		}
+		fmt.Println()
+		if s.SignOff != nil && s.SignOff.Comment != "" {
+			fmt.Printf("    %s\n", s.SignOff.Comment)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/agent-team-prd/pkg/service"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status [new-status] [file]",
+	Short: "Show or change the PRD status",
+	Long: `Show the PRD status, or move it through the review workflow.
+
+Statuses move draft → in_review → approved → deprecated. A PRD under
+review can be sent back to draft, and an approved PRD reopened for review.
+Deprecated is final.
+
+Each status has gates the PRD must pass to enter it:
+  in_review - valid: no validation errors
+  approved  - score:    weighted score of at least 8.0, from scoring the
+                        PRD (a recorded review's score doesn't count)
+              blockers: no open blockers from scoring or the recorded
+                        review
+              sign-off: every approver in metadata has approved
+
+Without a new status, the current status is shown with the gates of each
+status it can move to.
+
+Examples:
+  prdtool status
+  prdtool status in_review
+  prdtool status approved --file auth-prd.json`,
+	Args:              cobra.MaximumNArgs(2),
+	ValidArgsFunction: completeStatus,
+	Run:               runStatus,
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+}
+
+func runStatus(cmd *cobra.Command, args []string) {
+	if len(args) == 0 {
+		showStatus(prdFile)
+		return
+	}
+
+	res, err := prdService.UpdateStatus(service.UpdateStatusRequest{
+		Path:   getPRDPath(args[1:]),
+		Status: args[0],
+		DryRun: dryRun,
+	})
+	var terr *prd.TransitionError
+	if errors.As(err, &terr) && len(terr.Failed) > 0 {
+		fmt.Printf("Cannot move from %s to %s:\n", terr.From, terr.To)
+		printGates(terr.Failed)
+		exitWithError("%d of the gates for %s failed", len(terr.Failed), terr.To)
+	}
+	if err != nil {
+		exitWithError("%v", err)
+	}
+
+	if res.Preview != nil {
+		printPreview(os.Stdout, res.Path, res.Preview)
+	}
+	printGates(res.Gates)
+	if res.Preview == nil {
+		fmt.Println(res.Message)
+	}
+}
+
+// showStatus prints the status of the PRD at path and the gates of each
+// status it can move to.
+func showStatus(path string) {
+	p, err := prd.Load(path)
+	if err != nil {
+		exitWithError("Failed to load PRD: %v", err)
+	}
+
+	bold := color.New(color.Bold).SprintFunc()
+	fmt.Printf("%s %s\n", bold("Status:"), p.Metadata.Status)
+
+	next := prd.Transitions(p.Metadata.Status)
+	if len(next) == 0 {
+		fmt.Println("\nNo further transitions.")
+		return
+	}
+	for _, to := range next {
+		results, err := prdService.Workflow.Check(p, to)
+		ready := "ready"
+		if err != nil {
+			ready = "blocked"
+		}
+		fmt.Printf("\n%s (%s)\n", bold("→ "+string(to)), ready)
+		printGates(results)
+	}
+}
+
+func printGates(results []prd.GateResult) {
+	green := color.New(color.FgGreen).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+
+	for _, r := range results {
+		icon := green("✓")
+		if !r.Passed {
+			icon = red("✗")
+		}
+		fmt.Printf("  %s %s: %s\n", icon, r.Gate, r.Message)
+	}
+}
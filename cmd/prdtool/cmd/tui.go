@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/agent-team-prd/pkg/tui"
+	"github.com/spf13/cobra"
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui [file]",
+	Short: "Edit the PRD in a full-screen terminal UI",
+	Long: `Open the PRD in a full-screen terminal editor.
+
+The left pane lists each section (problem, personas, requirements, risks
+and so on) with its entries. The right pane edits the selected entry, and
+the panel below shows the score and validation results, updated after
+every change.
+
+Keys:
+  ↑/↓ or j/k    Move through sections, entries or fields
+  Enter         Open an entry, or edit the selected field
+  ←/→ or Space  Cycle a field with fixed choices (priority, impact, ...)
+  n             Add an entry to the selected section
+  d             Delete the selected entry
+  s or Ctrl-S   Save
+  Esc           Go back; q quits (asking to save unsaved changes)
+
+A new entry is created once its required fields (marked *) are filled in.
+List fields, such as pain points, are comma-separated.
+
+Examples:
+  prdtool tui
+  prdtool tui docs/PRD.json`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runTUI,
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+}
+
+func runTUI(cmd *cobra.Command, args []string) {
+	if dryRun {
+		exitWithError("tui does not support --dry-run")
+	}
+
+	path := getPRDPath(args)
+	p, err := prd.Load(path)
+	if err != nil {
+		exitWithError("Failed to load PRD: %v", err)
+	}
+
+	m := tui.New(p, path, func() error {
+		return prd.Save(p, path)
+	})
+	if err := tui.Run(m, os.Stdin, os.Stdout); err != nil {
+		exitWithError("%v", err)
+	}
+}
@@ -10,8 +10,9 @@ import (
 )
 
 var (
-	viewType   string
-	viewFormat string
+	viewType    string
+	viewFormat  string
+	viewRescore bool
 )
 
 var viewCmd = &cobra.Command{
@@ -20,8 +21,10 @@ var viewCmd = &cobra.Command{
 	Long: `Generate human-readable projections from a PRD.
 
 Available view types:
-  pm   - Product Manager view (detailed operational view)
-  exec - Executive view (high-level decision summary)
+  pm       - Product Manager view (detailed operational view, with diagrams)
+  exec     - Executive view (high-level decision summary; uses the review
+             recorded with 'prdtool review record' if there is one)
+  diagrams - Mermaid diagrams (roadmap, flows, OKR tree, risk matrix)
 
 Output formats:
   markdown - Rendered markdown (default)
@@ -30,15 +33,19 @@ Output formats:
 Examples:
   prdtool view PRD.json
   prdtool view --type exec PRD.json
-  prdtool view --type pm --format json PRD.json`,
+  prdtool view --type pm --format json PRD.json
+  prdtool view --type diagrams PRD.json`,
 	Run: runView,
 }
 
 func init() {
 	rootCmd.AddCommand(viewCmd)
 
-	viewCmd.Flags().StringVarP(&viewType, "type", "t", "pm", "View type: pm, exec")
+	viewCmd.Flags().StringVarP(&viewType, "type", "t", "pm", valuesHelp("View type", viewTypes))
 	viewCmd.Flags().StringVarP(&viewFormat, "format", "o", "markdown", "Output format: markdown, json")
+	viewCmd.Flags().BoolVar(&viewRescore, "rescore", false, "Score the PRD for the exec view even if a review is recorded")
+	mustCompleteValues(viewCmd, "type", viewTypes)
+	mustCompleteValues(viewCmd, "format", []string{"markdown", "json"})
 }
 
 func runView(cmd *cobra.Command, args []string) {
@@ -55,8 +62,10 @@ func runView(cmd *cobra.Command, args []string) {
 		generatePMView(p)
 	case "exec":
 		generateExecView(p)
+	case "diagrams":
+		generateDiagramsView(p)
 	default:
-		exitWithError("Unknown view type: %s. Use 'pm' or 'exec'", viewType)
+		exitWithError("Unknown view type: %s. Use 'pm', 'exec' or 'diagrams'", viewType)
 	}
 }
 
@@ -71,7 +80,10 @@ func generatePMView(p *prd.PRD) {
 		}
 		fmt.Println(output)
 	case "markdown":
-		output := views.RenderPMMarkdown(view)
+		output, err := views.RenderPMViewMarkdown(p)
+		if err != nil {
+			exitWithError("Failed to render PM view: %v", err)
+		}
 		fmt.Print(output)
 	default:
 		exitWithError("Unknown format: %s. Use 'markdown' or 'json'", viewFormat)
@@ -79,9 +91,15 @@ func generatePMView(p *prd.PRD) {
 }
 
 func generateExecView(p *prd.PRD) {
-	// Score the PRD first for exec view
-	scores := scoring.Score(p)
-	view := views.GenerateExecView(p, scores)
+	// Use the recorded review if there is one, unless asked to rescore
+	scores := scoring.ReviewOrScore(p)
+	if viewRescore {
+		scores = scoring.Score(p)
+	}
+	view, err := views.GenerateExecView(p, scores)
+	if err != nil {
+		exitWithError("Failed to generate exec view: %v", err)
+	}
 
 	switch viewFormat {
 	case "json":
@@ -91,8 +109,24 @@ func generateExecView(p *prd.PRD) {
 		}
 		fmt.Println(output)
 	case "markdown":
-		output := views.RenderExecMarkdown(view)
-		fmt.Print(output)
+		fmt.Print(views.RenderExecMarkdown(view))
+	default:
+		exitWithError("Unknown format: %s. Use 'markdown' or 'json'", viewFormat)
+	}
+}
+
+func generateDiagramsView(p *prd.PRD) {
+	diagrams := views.GenerateDiagrams(p)
+
+	switch viewFormat {
+	case "json":
+		output, err := views.ToJSON(diagrams)
+		if err != nil {
+			exitWithError("Failed to generate JSON: %v", err)
+		}
+		fmt.Println(output)
+	case "markdown":
+		fmt.Print(views.RenderDiagramsViewMarkdown(p))
 	default:
 		exitWithError("Unknown format: %s. Use 'markdown' or 'json'", viewFormat)
 	}
@@ -0,0 +1,78 @@
+// Package middleware provides the HTTP middleware shared by prdtool-api
+// and the http transport of prdtool-mcp.
+package middleware
+
+import (
+	"crypto/subtle"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// sessionHeader carries the MCP session ID of streamable HTTP requests.
+const sessionHeader = "Mcp-Session-Id"
+
+// RequireBearerToken rejects requests without "Authorization: Bearer
+// <token>" with 401 and a JSON error. realm names the server in the
+// WWW-Authenticate challenge.
+func RequireBearerToken(realm, token string, next http.Handler) http.Handler {
+	expected := []byte(token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		given, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(given), expected) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+realm+`"`)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error": "unauthorized"}` + "\n"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// LogRequests logs the method, path, status, duration and remote address
+// of each request, and its MCP session if it has one.
+func LogRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		line := r.Method + " " + r.URL.Path
+		session := r.Header.Get(sessionHeader)
+		if session == "" {
+			session = rec.Header().Get(sessionHeader)
+		}
+		if session != "" {
+			log.Printf("%s %d %s remote=%s session=%s",
+				line, rec.status, time.Since(start).Round(time.Millisecond), r.RemoteAddr, session)
+			return
+		}
+		log.Printf("%s %d %s remote=%s",
+			line, rec.status, time.Since(start).Round(time.Millisecond), r.RemoteAddr)
+	})
+}
+
+// statusRecorder captures the response status while still supporting
+// flushing, which the streaming MCP transports rely on.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequireBearerToken(t *testing.T) {
+	h := RequireBearerToken("test", "secret", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		auth string
+		want int
+	}{
+		{"Bearer secret", http.StatusNoContent},
+		{"Bearer wrong", http.StatusUnauthorized},
+		{"secret", http.StatusUnauthorized},
+		{"", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", "/", nil)
+		if tt.auth != "" {
+			req.Header.Set("Authorization", tt.auth)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("%q: expected %d, got %d", tt.auth, tt.want, rec.Code)
+		}
+		if rec.Code == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") != `Bearer realm="test"` {
+			t.Errorf("%q: unexpected challenge %q", tt.auth, rec.Header().Get("WWW-Authenticate"))
+		}
+	}
+}
+
+func TestLogRequestsKeepsFlusher(t *testing.T) {
+	var flushed bool
+	h := LogRequests(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		if err := http.NewResponseController(w).Flush(); err != nil {
+			t.Errorf("expected the writer to flush, got %v", err)
+		}
+		_, flushed = w.(http.Flusher)
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("POST", "/mcp", nil))
+	if !flushed {
+		t.Error("expected the recorder to implement http.Flusher")
+	}
+	if rec.Code != http.StatusAccepted || !rec.Flushed {
+		t.Errorf("expected a flushed 202, got %d flushed=%v", rec.Code, rec.Flushed)
+	}
+}
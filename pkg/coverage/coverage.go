@@ -0,0 +1,233 @@
+// Package coverage traces tests back to PRD requirements.
+//
+// Tests reference PRD IDs with "Covers:" comments in Go files
+// (// Covers: FR-12, AC-3) and with tags or comments in Gherkin feature
+// files (@FR-12, # Covers: FR-12). The scan result is matched against the
+// functional requirements and acceptance criteria in a PRD.
+package coverage
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+)
+
+// Report describes which requirements and acceptance criteria have tests.
+type Report struct {
+	GeneratedAt  time.Time             `json:"generatedAt"`
+	Requirements []RequirementCoverage `json:"requirements"`
+	Criteria     []CriterionCoverage   `json:"criteria"`
+	// UnknownIDs lists referenced IDs that do not exist in the PRD.
+	UnknownIDs []string `json:"unknownIds,omitempty"`
+	// AmbiguousIDs lists acceptance criterion IDs that were referenced
+	// without their requirement or story, such as AC-1 rather than
+	// US-2-AC-1, but belong to more than one. They count for none.
+	AmbiguousIDs []string `json:"ambiguousIds,omitempty"`
+	Summary      Summary  `json:"summary"`
+}
+
+// RequirementCoverage is the coverage status of a functional requirement.
+type RequirementCoverage struct {
+	ID       string     `json:"id"`
+	Title    string     `json:"title"`
+	Priority prd.MoSCoW `json:"priority,omitempty"`
+	Covered  bool       `json:"covered"`
+	Tests    []string   `json:"tests,omitempty"`
+}
+
+// CriterionCoverage is the coverage status of an acceptance criterion.
+type CriterionCoverage struct {
+	ID          string   `json:"id"`
+	ParentID    string   `json:"parentId"`
+	Description string   `json:"description,omitempty"`
+	Covered     bool     `json:"covered"`
+	Tests       []string `json:"tests,omitempty"`
+}
+
+// Summary holds coverage totals.
+type Summary struct {
+	TotalRequirements   int `json:"totalRequirements"`
+	CoveredRequirements int `json:"coveredRequirements"`
+	TotalCriteria       int `json:"totalCriteria"`
+	CoveredCriteria     int `json:"coveredCriteria"`
+}
+
+// RequirementPercent returns the percentage of requirements with tests.
+func (s Summary) RequirementPercent() float64 {
+	if s.TotalRequirements == 0 {
+		return 0
+	}
+	return float64(s.CoveredRequirements) / float64(s.TotalRequirements) * 100
+}
+
+// CriteriaPercent returns the percentage of acceptance criteria with tests.
+func (s Summary) CriteriaPercent() float64 {
+	if s.TotalCriteria == 0 {
+		return 0
+	}
+	return float64(s.CoveredCriteria) / float64(s.TotalCriteria) * 100
+}
+
+// UncoveredRequirements returns the requirements without tests.
+func (r *Report) UncoveredRequirements() []RequirementCoverage {
+	var out []RequirementCoverage
+	for _, req := range r.Requirements {
+		if !req.Covered {
+			out = append(out, req)
+		}
+	}
+	return out
+}
+
+// UncoveredCriteria returns the acceptance criteria without tests.
+func (r *Report) UncoveredCriteria() []CriterionCoverage {
+	var out []CriterionCoverage
+	for _, c := range r.Criteria {
+		if !c.Covered {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Analyze matches scanned references against the PRD's functional
+// requirements and the acceptance criteria of requirements and user stories.
+//
+// Acceptance criterion IDs are only unique within their requirement or
+// story, so a criterion is referenced as US-1-AC-2, or as AC-2 if no other
+// requirement or story has an AC-2.
+func Analyze(p *prd.PRD, refs []Reference) *Report {
+	tests := make(map[string][]string)
+	for _, ref := range refs {
+		tests[ref.ID] = append(tests[ref.ID], ref.Location())
+	}
+
+	// Count the owners of each criterion ID to find the ones that can be
+	// referenced unqualified.
+	owners := make(map[string]int)
+	for _, fr := range p.Requirements.Functional {
+		countCriteria(owners, fr.AcceptanceCriteria)
+	}
+	for _, story := range p.UserStories {
+		countCriteria(owners, story.AcceptanceCriteria)
+	}
+
+	known := make(map[string]bool)
+	ambiguous := make(map[string]bool)
+	report := &Report{GeneratedAt: time.Now().UTC()}
+
+	addCriteria := func(parentID string, criteria []prd.AcceptanceCriterion) {
+		for _, ac := range criteria {
+			if ac.ID == "" {
+				continue
+			}
+			qualified := criterionKey(parentID, ac.ID)
+			known[qualified] = true
+			c := CriterionCoverage{
+				ID:          ac.ID,
+				ParentID:    parentID,
+				Description: ac.Description,
+				Tests:       tests[qualified],
+			}
+			if owners[ac.ID] == 1 {
+				known[ac.ID] = true
+				c.Tests = append(c.Tests, tests[ac.ID]...)
+			} else if len(tests[ac.ID]) > 0 {
+				ambiguous[ac.ID] = true
+			}
+			c.Covered = len(c.Tests) > 0
+			report.Criteria = append(report.Criteria, c)
+		}
+	}
+
+	for _, fr := range p.Requirements.Functional {
+		known[fr.ID] = true
+		rc := RequirementCoverage{
+			ID:       fr.ID,
+			Title:    fr.Title,
+			Priority: fr.Priority,
+			Tests:    tests[fr.ID],
+		}
+		rc.Covered = len(rc.Tests) > 0
+		report.Requirements = append(report.Requirements, rc)
+		addCriteria(fr.ID, fr.AcceptanceCriteria)
+	}
+
+	for _, story := range p.UserStories {
+		known[story.ID] = true
+		addCriteria(story.ID, story.AcceptanceCriteria)
+	}
+
+	// IDs of other entities (NFRs, risks, ...) are valid references even
+	// though they are not part of the coverage totals.
+	for _, kind := range prd.EntityKinds() {
+		for _, e := range kind.List(p) {
+			if e.ID != "" {
+				known[e.ID] = true
+			}
+		}
+	}
+
+	for id := range tests {
+		switch {
+		case ambiguous[id]:
+			report.AmbiguousIDs = append(report.AmbiguousIDs, id)
+		case !known[id]:
+			report.UnknownIDs = append(report.UnknownIDs, id)
+		}
+	}
+	sort.Strings(report.UnknownIDs)
+	sort.Strings(report.AmbiguousIDs)
+
+	report.Summary.TotalRequirements = len(report.Requirements)
+	for _, rc := range report.Requirements {
+		if rc.Covered {
+			report.Summary.CoveredRequirements++
+		}
+	}
+	report.Summary.TotalCriteria = len(report.Criteria)
+	for _, c := range report.Criteria {
+		if c.Covered {
+			report.Summary.CoveredCriteria++
+		}
+	}
+
+	return report
+}
+
+func countCriteria(owners map[string]int, criteria []prd.AcceptanceCriterion) {
+	for _, ac := range criteria {
+		if ac.ID != "" {
+			owners[ac.ID]++
+		}
+	}
+}
+
+// criterionKey returns the reference ID of a criterion qualified with its
+// requirement or story, e.g. US-1-AC-2. Criterion IDs that already carry
+// the parent ID are returned as is.
+func criterionKey(parentID, id string) string {
+	if strings.HasPrefix(id, parentID+"-") {
+		return id
+	}
+	return parentID + "-" + id
+}
+
+// Apply stores the report in the PRD's test-coverage custom section so it
+// travels with the document and is picked up by views and scoring.
+func Apply(p *prd.PRD, report *Report) {
+	prd.SetCustomSection(p, prd.SectionTestCoverage, "Test Coverage", report)
+}
+
+// FromPRD returns the coverage report stored in the PRD, or nil if the PRD
+// has not been scanned.
+func FromPRD(p *prd.PRD) (*Report, error) {
+	var report Report
+	found, err := prd.DecodeCustomSection(p, prd.SectionTestCoverage, &report)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &report, nil
+}
@@ -0,0 +1,207 @@
+package coverage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+)
+
+func TestScanFile(t *testing.T) {
+	dir := t.TempDir()
+
+	goFile := writeFile(t, dir, "login_test.go", `package login
+
+// Covers: FR-1, AC-1, utf-8, x-request-id
+func TestLogin(t *testing.T) {}
+
+// covers: FR-2
+func TestLogout(t *testing.T) {}
+`)
+	featureFile := writeFile(t, dir, "login.feature", `@FR-3 @smoke
+Feature: Login
+  # Covers: AC-2
+  Scenario: Valid credentials
+`)
+
+	refs, err := ScanFile(goFile)
+	if err != nil {
+		t.Fatalf("ScanFile failed: %v", err)
+	}
+	if len(refs) != 3 {
+		t.Fatalf("expected 3 references in Go file, got %d", len(refs))
+	}
+	if refs[0].ID != "FR-1" || refs[0].Line != 3 || refs[0].Kind != KindGo {
+		t.Errorf("unexpected first reference: %+v", refs[0])
+	}
+
+	refs, err = ScanFile(featureFile)
+	if err != nil {
+		t.Fatalf("ScanFile failed: %v", err)
+	}
+	ids := make(map[string]bool)
+	for _, r := range refs {
+		ids[r.ID] = true
+	}
+	if len(refs) != 2 || !ids["FR-3"] || !ids["AC-2"] {
+		t.Errorf("expected FR-3 and AC-2 in feature file, got %+v", refs)
+	}
+	if ids["smoke"] {
+		t.Error("expected non-ID tags to be ignored")
+	}
+}
+
+func TestScanPatterns(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "a_test.go", "// Covers: FR-1\n")
+	writeFile(t, filepath.Join(dir, "sub"), "b_test.go", "// Covers: FR-2\n")
+	writeFile(t, filepath.Join(dir, "vendor"), "c_test.go", "// Covers: FR-3\n")
+	writeFile(t, dir, "notes.txt", "// Covers: FR-4\n")
+
+	tests := []struct {
+		name    string
+		pattern string
+		want    int
+	}{
+		{"directory only", dir, 1},
+		{"recursive", dir + "/...", 2},
+		{"single file", filepath.Join(dir, "sub", "b_test.go"), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refs, err := Scan([]string{tt.pattern})
+			if err != nil {
+				t.Fatalf("Scan failed: %v", err)
+			}
+			if len(refs) != tt.want {
+				t.Errorf("expected %d references, got %d", tt.want, len(refs))
+			}
+		})
+	}
+
+	if _, err := Scan([]string{filepath.Join(dir, "missing")}); err == nil {
+		t.Error("expected error for missing path")
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	p := prd.New("PRD-2026-001", "Test PRD", prd.Person{Name: "Owner"})
+	prd.AddFunctionalRequirement(p, "Login", "Users can log in", prd.MoSCoWMust)
+	prd.AddFunctionalRequirement(p, "Logout", "Users can log out", prd.MoSCoWShould)
+	p.UserStories = []prd.UserStory{{
+		ID:    "US-1",
+		Title: "Login story",
+		AcceptanceCriteria: []prd.AcceptanceCriterion{
+			{ID: "AC-1", Description: "Valid credentials log in"},
+			{ID: "AC-2", Description: "Invalid credentials are rejected"},
+		},
+	}}
+
+	refs := []Reference{
+		{ID: "FR-1", File: "login_test.go", Line: 3, Kind: KindGo},
+		{ID: "AC-1", File: "login.feature", Line: 2, Kind: KindGherkin},
+		{ID: "FR-99", File: "old_test.go", Line: 1, Kind: KindGo},
+	}
+
+	report := Analyze(p, refs)
+
+	if report.Summary.TotalRequirements != 2 || report.Summary.CoveredRequirements != 1 {
+		t.Errorf("expected 1/2 requirements covered, got %d/%d",
+			report.Summary.CoveredRequirements, report.Summary.TotalRequirements)
+	}
+	if report.Summary.TotalCriteria != 2 || report.Summary.CoveredCriteria != 1 {
+		t.Errorf("expected 1/2 criteria covered, got %d/%d",
+			report.Summary.CoveredCriteria, report.Summary.TotalCriteria)
+	}
+
+	uncovered := report.UncoveredRequirements()
+	if len(uncovered) != 1 || uncovered[0].ID != "FR-2" {
+		t.Errorf("expected FR-2 uncovered, got %+v", uncovered)
+	}
+	if report.Requirements[0].Tests[0] != "login_test.go:3" {
+		t.Errorf("expected test location login_test.go:3, got %s", report.Requirements[0].Tests[0])
+	}
+	if len(report.UnknownIDs) != 1 || report.UnknownIDs[0] != "FR-99" {
+		t.Errorf("expected unknown ID FR-99, got %v", report.UnknownIDs)
+	}
+}
+
+func TestAnalyzeQualifiedCriteria(t *testing.T) {
+	p := prd.New("PRD-2026-001", "Test PRD", prd.Person{Name: "Owner"})
+	prd.AddRisk(p, "OAuth provider outage", prd.RiskProbabilityMedium, prd.RiskImpactHigh, "Magic link fallback")
+	p.UserStories = []prd.UserStory{
+		{ID: "US-1", AcceptanceCriteria: []prd.AcceptanceCriterion{{ID: "AC-1"}, {ID: "AC-2"}}},
+		{ID: "US-2", AcceptanceCriteria: []prd.AcceptanceCriterion{{ID: "AC-1"}}},
+	}
+
+	report := Analyze(p, []Reference{
+		{ID: "US-2-AC-1", File: "a_test.go", Line: 1},
+		{ID: "AC-1", File: "b_test.go", Line: 1},
+		{ID: "AC-2", File: "c_test.go", Line: 1},
+		{ID: "RISK-1", File: "d_test.go", Line: 1},
+	})
+
+	covered := make(map[string]bool)
+	for _, c := range report.Criteria {
+		covered[c.ParentID+"/"+c.ID] = c.Covered
+	}
+	if covered["US-1/AC-1"] || !covered["US-2/AC-1"] || !covered["US-1/AC-2"] {
+		t.Errorf("unexpected criteria coverage: %v", covered)
+	}
+	if len(report.AmbiguousIDs) != 1 || report.AmbiguousIDs[0] != "AC-1" {
+		t.Errorf("expected AC-1 to be ambiguous, got %v", report.AmbiguousIDs)
+	}
+	if len(report.UnknownIDs) != 0 {
+		t.Errorf("expected risks to be known IDs, got unknown %v", report.UnknownIDs)
+	}
+}
+
+func TestApplyAndFromPRD(t *testing.T) {
+	p := prd.New("PRD-2026-001", "Test PRD", prd.Person{Name: "Owner"})
+	prd.AddFunctionalRequirement(p, "Login", "Users can log in", prd.MoSCoWMust)
+
+	report, err := FromPRD(p)
+	if err != nil || report != nil {
+		t.Fatalf("expected no coverage before apply, got %v, %v", report, err)
+	}
+
+	Apply(p, Analyze(p, nil))
+
+	// Round-trip through disk so content is decoded from untyped JSON.
+	path := filepath.Join(t.TempDir(), "PRD.json")
+	if err := prd.Save(p, path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := prd.Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	report, err = FromPRD(loaded)
+	if err != nil {
+		t.Fatalf("FromPRD failed: %v", err)
+	}
+	if report == nil || len(report.Requirements) != 1 || report.Requirements[0].Covered {
+		t.Errorf("expected one uncovered requirement after round-trip, got %+v", report)
+	}
+
+	// Applying again replaces rather than duplicates the section.
+	Apply(loaded, report)
+	if len(loaded.CustomSections) != 1 {
+		t.Errorf("expected 1 custom section, got %d", len(loaded.CustomSections))
+	}
+}
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
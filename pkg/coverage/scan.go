@@ -0,0 +1,185 @@
+package coverage
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Source kinds recognized by the scanner.
+const (
+	KindGo      = "go"
+	KindGherkin = "gherkin"
+)
+
+// Reference is a single mention of a PRD ID in a test source file.
+type Reference struct {
+	ID   string `json:"id"`
+	File string `json:"file"`
+	Line int    `json:"line"`
+	Kind string `json:"kind"`
+}
+
+// Location returns the reference as "file:line".
+func (r Reference) Location() string {
+	return fmt.Sprintf("%s:%d", r.File, r.Line)
+}
+
+var (
+	// coversPattern matches "Covers: FR-1, AC-2" in a Go (//) or Gherkin (#) comment.
+	coversPattern = regexp.MustCompile(`(?://|#)\s*[Cc]overs:\s*(.+)$`)
+
+	// idPattern matches PRD IDs such as FR-12, NFR-3 or US-1-AC-2: one of
+	// IDPrefixes and a number, optionally qualified with an acceptance
+	// criterion. Other hyphenated words (utf-8, x-request-id) don't match.
+	idPattern = regexp.MustCompile(`\b(?:` + strings.Join(IDPrefixes, "|") + `)-\d+(?:-AC-\d+)?\b`)
+
+	// tagPattern matches Gherkin tags such as @FR-12.
+	tagPattern = regexp.MustCompile(`@(` + idPattern.String() + `)`)
+)
+
+// IDPrefixes are the prefixes of the IDs prdtool assigns to PRD entities
+// (see prd.NextID) and acceptance criteria.
+var IDPrefixes = []string{
+	"PROB", "PER", "OBJ", "KR", "ALT", "SOL", "FR", "NFR", "US", "AC", "RISK", "DEC",
+}
+
+// Scan walks the given patterns and returns every PRD ID reference found in
+// Go and Gherkin (.feature) files.
+//
+// Patterns follow the go tool convention: "./..." or "dir/..." scans
+// recursively, a directory scans only that directory, and a file path scans
+// that file. Hidden directories, vendor, node_modules and testdata are skipped.
+func Scan(patterns []string) ([]Reference, error) {
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	seen := make(map[string]bool)
+	var refs []Reference
+
+	visit := func(path string) error {
+		if seen[path] || sourceKind(path) == "" {
+			return nil
+		}
+		seen[path] = true
+		fileRefs, err := ScanFile(path)
+		if err != nil {
+			return err
+		}
+		refs = append(refs, fileRefs...)
+		return nil
+	}
+
+	for _, pattern := range patterns {
+		recursive := false
+		root := pattern
+		if root == "..." || strings.HasSuffix(root, "/...") {
+			recursive = true
+			root = strings.TrimSuffix(strings.TrimSuffix(root, "..."), "/")
+			if root == "" {
+				root = "."
+			}
+		}
+
+		info, err := os.Stat(root)
+		if err != nil {
+			return nil, fmt.Errorf("invalid source pattern %q: %w", pattern, err)
+		}
+
+		if !info.IsDir() {
+			if err := visit(root); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		err = filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if path == root {
+					return nil
+				}
+				if !recursive || skipDir(d.Name()) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			return visit(path)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return refs, nil
+}
+
+// ScanFile returns the PRD ID references in a single Go or Gherkin file.
+func ScanFile(path string) ([]Reference, error) {
+	kind := sourceKind(path)
+	if kind == "" {
+		return nil, nil
+	}
+
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var refs []Reference
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+
+		var ids []string
+		if m := coversPattern.FindStringSubmatch(text); m != nil {
+			ids = append(ids, idPattern.FindAllString(m[1], -1)...)
+		}
+		if kind == KindGherkin && strings.HasPrefix(strings.TrimSpace(text), "@") {
+			for _, m := range tagPattern.FindAllStringSubmatch(text, -1) {
+				ids = append(ids, m[1])
+			}
+		}
+
+		for _, id := range ids {
+			refs = append(refs, Reference{ID: id, File: path, Line: line, Kind: kind})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", path, err)
+	}
+
+	return refs, nil
+}
+
+func sourceKind(path string) string {
+	switch filepath.Ext(path) {
+	case ".go":
+		return KindGo
+	case ".feature":
+		return KindGherkin
+	default:
+		return ""
+	}
+}
+
+func skipDir(name string) bool {
+	if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+		return true
+	}
+	switch name {
+	case "vendor", "node_modules", "testdata":
+		return true
+	}
+	return false
+}
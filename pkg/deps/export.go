@@ -0,0 +1,95 @@
+package deps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agentplexus/agent-team-prd/pkg/mermaid"
+)
+
+// DOT renders the graph in Graphviz DOT format. Missing PRDs are dashed and
+// deprecated PRDs are grey; requirement-level edges are labeled.
+func (g *Graph) DOT() string {
+	var sb strings.Builder
+	sb.WriteString("digraph prd_dependencies {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box];\n")
+
+	for _, n := range g.Nodes {
+		attrs := []string{fmt.Sprintf("label=%q", nodeLabel(n))}
+		switch {
+		case n.Missing:
+			attrs = append(attrs, "style=dashed")
+		case n.Status == "deprecated":
+			attrs = append(attrs, "style=filled", "fillcolor=lightgrey")
+		}
+		fmt.Fprintf(&sb, "  %q [%s];\n", n.ID, strings.Join(attrs, ", "))
+	}
+
+	for _, e := range g.Edges {
+		if label := edgeLabel(e); label != "" {
+			fmt.Fprintf(&sb, "  %q -> %q [label=%q];\n", e.From, e.To, label)
+		} else {
+			fmt.Fprintf(&sb, "  %q -> %q;\n", e.From, e.To)
+		}
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// Mermaid renders the graph as a Mermaid flowchart.
+func (g *Graph) Mermaid() string {
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&sb, "  %s[\"%s\"]\n", mermaid.ID(n.ID), mermaid.Text(nodeLabel(n)))
+	}
+
+	for _, e := range g.Edges {
+		if label := edgeLabel(e); label != "" {
+			fmt.Fprintf(&sb, "  %s -->|%s| %s\n", mermaid.ID(e.From), mermaid.Text(label), mermaid.ID(e.To))
+		} else {
+			fmt.Fprintf(&sb, "  %s --> %s\n", mermaid.ID(e.From), mermaid.ID(e.To))
+		}
+	}
+
+	var missing, deprecated []string
+	for _, n := range g.Nodes {
+		switch {
+		case n.Missing:
+			missing = append(missing, mermaid.ID(n.ID))
+		case n.Status == "deprecated":
+			deprecated = append(deprecated, mermaid.ID(n.ID))
+		}
+	}
+	if len(missing) > 0 {
+		sb.WriteString("  classDef missing stroke-dasharray: 5 5\n")
+		fmt.Fprintf(&sb, "  class %s missing\n", strings.Join(missing, ","))
+	}
+	if len(deprecated) > 0 {
+		sb.WriteString("  classDef deprecated fill:#ddd,color:#666\n")
+		fmt.Fprintf(&sb, "  class %s deprecated\n", strings.Join(deprecated, ","))
+	}
+
+	return sb.String()
+}
+
+func nodeLabel(n *Node) string {
+	switch {
+	case n.Missing:
+		return n.ID + " (missing)"
+	case n.Title == "":
+		return n.ID
+	default:
+		return fmt.Sprintf("%s: %s", n.ID, n.Title)
+	}
+}
+
+func edgeLabel(e Edge) string {
+	if e.FromRequirement == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s → %s", e.FromRequirement, e.ToRequirement)
+}
@@ -0,0 +1,294 @@
+// Package deps builds the PRD-to-PRD dependency graph for a workspace and
+// checks it for duplicate IDs, cycles, deprecated targets and dangling
+// references.
+package deps
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/agent-team-prd/pkg/workspace"
+)
+
+// Issue types reported by Check.
+const (
+	IssueDuplicateID        = "duplicate_id"
+	IssueCycle              = "cycle"
+	IssueDeprecated         = "deprecated_dependency"
+	IssueMissingPRD         = "missing_prd"
+	IssueMissingRequirement = "missing_requirement"
+)
+
+// Node is a PRD in the dependency graph.
+type Node struct {
+	ID     string `json:"id"`
+	Title  string `json:"title,omitempty"`
+	Status string `json:"status,omitempty"`
+	Path   string `json:"path,omitempty"`
+	// Missing is true for PRD IDs that are referenced but not in the workspace.
+	Missing bool `json:"missing,omitempty"`
+
+	requirements map[string]bool
+}
+
+// Edge is a dependency from one PRD to another. Requirement-level edges set
+// FromRequirement and ToRequirement.
+type Edge struct {
+	From            string `json:"from"`
+	To              string `json:"to"`
+	FromRequirement string `json:"fromRequirement,omitempty"`
+	ToRequirement   string `json:"toRequirement,omitempty"`
+	Reason          string `json:"reason,omitempty"`
+}
+
+// Issue is a problem found in the dependency graph.
+type Issue struct {
+	Type    string   `json:"type"`
+	PRDs    []string `json:"prds"`
+	Message string   `json:"message"`
+}
+
+// Graph is the dependency graph of a workspace.
+type Graph struct {
+	Nodes []*Node `json:"nodes"`
+	Edges []Edge  `json:"edges"`
+
+	index map[string]*Node
+
+	// duplicates maps an ID shared by several files to their paths, in
+	// the order they were found. Only the first file is in the graph.
+	duplicates map[string][]string
+}
+
+// Build reads the dependencies of every PRD in the workspace. When several
+// files have the same ID, only the first is added to the graph, with its
+// dependencies; Check reports the others.
+func Build(ws *workspace.Workspace) (*Graph, error) {
+	g := &Graph{Edges: []Edge{}, index: make(map[string]*Node), duplicates: make(map[string][]string)}
+
+	skip := make(map[int]bool)
+	for i, d := range ws.Documents {
+		m := d.PRD.Metadata
+		if first, ok := g.index[m.ID]; ok {
+			if len(g.duplicates[m.ID]) == 0 {
+				g.duplicates[m.ID] = []string{first.Path}
+			}
+			g.duplicates[m.ID] = append(g.duplicates[m.ID], d.Path)
+			skip[i] = true
+			continue
+		}
+		n := &Node{
+			ID:           m.ID,
+			Title:        m.Title,
+			Status:       string(m.Status),
+			Path:         d.Path,
+			requirements: make(map[string]bool),
+		}
+		for _, r := range d.PRD.Requirements.Functional {
+			n.requirements[r.ID] = true
+		}
+		for _, r := range d.PRD.Requirements.NonFunctional {
+			n.requirements[r.ID] = true
+		}
+		g.addNode(n)
+	}
+
+	for i, d := range ws.Documents {
+		if skip[i] {
+			continue
+		}
+		deps, err := prd.GetDependencies(d.PRD)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", d.Path, err)
+		}
+		from := d.PRD.Metadata.ID
+		for _, dep := range deps.Documents {
+			g.addEdge(Edge{From: from, To: dep.PRDID, Reason: dep.Reason})
+		}
+		for _, dep := range deps.Requirements {
+			g.addEdge(Edge{
+				From:            from,
+				To:              dep.PRDID,
+				FromRequirement: dep.RequirementID,
+				ToRequirement:   dep.ExternalRequirementID,
+				Reason:          dep.Reason,
+			})
+		}
+	}
+
+	sort.Slice(g.Nodes, func(i, j int) bool { return g.Nodes[i].ID < g.Nodes[j].ID })
+	return g, nil
+}
+
+// Node returns the node with the given PRD ID, or nil.
+func (g *Graph) Node(id string) *Node {
+	return g.index[id]
+}
+
+func (g *Graph) addNode(n *Node) {
+	if _, ok := g.index[n.ID]; ok {
+		return
+	}
+	g.index[n.ID] = n
+	g.Nodes = append(g.Nodes, n)
+}
+
+func (g *Graph) addEdge(e Edge) {
+	if _, ok := g.index[e.To]; !ok {
+		g.addNode(&Node{ID: e.To, Missing: true})
+	}
+	g.Edges = append(g.Edges, e)
+}
+
+// Dependencies returns the IDs of the PRDs that id depends on.
+func (g *Graph) Dependencies(id string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, e := range g.Edges {
+		if e.From == id && !seen[e.To] {
+			seen[e.To] = true
+			out = append(out, e.To)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Dependents returns the IDs of the PRDs that depend on id.
+func (g *Graph) Dependents(id string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, e := range g.Edges {
+		if e.To == id && !seen[e.From] {
+			seen[e.From] = true
+			out = append(out, e.From)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Cycles returns each group of PRDs that depend on each other, directly or
+// transitively. Each cycle is sorted by PRD ID.
+func (g *Graph) Cycles() [][]string {
+	// Tarjan's strongly connected components.
+	index := 0
+	indices := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var cycles [][]string
+
+	var strongConnect func(v string)
+	strongConnect = func(v string) {
+		indices[v] = index
+		lowlink[v] = index
+		index++
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, w := range g.Dependencies(v) {
+			if _, visited := indices[w]; !visited {
+				strongConnect(w)
+				lowlink[v] = min(lowlink[v], lowlink[w])
+			} else if onStack[w] {
+				lowlink[v] = min(lowlink[v], indices[w])
+			}
+		}
+
+		if lowlink[v] == indices[v] {
+			var component []string
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				component = append(component, w)
+				if w == v {
+					break
+				}
+			}
+			if len(component) > 1 {
+				sort.Strings(component)
+				cycles = append(cycles, component)
+			}
+		}
+	}
+
+	for _, n := range g.Nodes {
+		if _, visited := indices[n.ID]; !visited {
+			strongConnect(n.ID)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
+
+// Check reports IDs used by more than one file, cycles, dependencies on
+// deprecated PRDs, and references to PRDs or requirements that do not
+// exist in the workspace.
+func (g *Graph) Check() []Issue {
+	issues := []Issue{}
+
+	ids := make([]string, 0, len(g.duplicates))
+	for id := range g.duplicates {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		paths := g.duplicates[id]
+		issues = append(issues, Issue{
+			Type: IssueDuplicateID,
+			PRDs: []string{id},
+			Message: fmt.Sprintf("%s is the ID of %d files (%s); only %s is in the graph",
+				id, len(paths), strings.Join(paths, ", "), paths[0]),
+		})
+	}
+
+	for _, cycle := range g.Cycles() {
+		issues = append(issues, Issue{
+			Type:    IssueCycle,
+			PRDs:    cycle,
+			Message: fmt.Sprintf("Dependency cycle between %v", cycle),
+		})
+	}
+
+	reported := make(map[string]bool)
+	for _, e := range g.Edges {
+		to := g.index[e.To]
+		key := e.From + "->" + e.To
+
+		switch {
+		case to.Missing:
+			if !reported[key] {
+				issues = append(issues, Issue{
+					Type:    IssueMissingPRD,
+					PRDs:    []string{e.From, e.To},
+					Message: fmt.Sprintf("%s depends on %s, which is not in the workspace", e.From, e.To),
+				})
+			}
+		case to.Status == string(prd.StatusDeprecated):
+			if !reported[key] {
+				issues = append(issues, Issue{
+					Type:    IssueDeprecated,
+					PRDs:    []string{e.From, e.To},
+					Message: fmt.Sprintf("%s depends on deprecated PRD %s", e.From, e.To),
+				})
+			}
+		}
+		reported[key] = true
+
+		if e.ToRequirement != "" && !to.Missing && !to.requirements[e.ToRequirement] {
+			issues = append(issues, Issue{
+				Type: IssueMissingRequirement,
+				PRDs: []string{e.From, e.To},
+				Message: fmt.Sprintf("%s %s depends on %s %s, which does not exist",
+					e.From, e.FromRequirement, e.To, e.ToRequirement),
+			})
+		}
+	}
+
+	return issues
+}
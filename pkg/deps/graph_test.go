@@ -0,0 +1,156 @@
+package deps
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/agent-team-prd/pkg/workspace"
+)
+
+func TestBuildAndCheck(t *testing.T) {
+	platform := prd.New("PRD-2026-001", "Platform", prd.Person{Name: "Owner"})
+	prd.AddFunctionalRequirement(platform, "Auth API", "Token endpoint", prd.MoSCoWMust)
+
+	feature := prd.New("PRD-2026-002", "Feature", prd.Person{Name: "Owner"})
+	reqID := prd.AddFunctionalRequirement(feature, "Login", "Users can log in", prd.MoSCoWMust)
+	mustNoErr(t, prd.AddDocumentDependency(feature, "PRD-2026-001", "Needs platform auth"))
+	mustNoErr(t, prd.AddRequirementDependency(feature, reqID, "PRD-2026-001", "FR-1", ""))
+	mustNoErr(t, prd.AddRequirementDependency(feature, reqID, "PRD-2026-001", "FR-9", ""))
+	mustNoErr(t, prd.AddDocumentDependency(feature, "PRD-2026-099", ""))
+
+	legacy := prd.New("PRD-2026-003", "Legacy", prd.Person{Name: "Owner"})
+	legacy.Metadata.Status = prd.StatusDeprecated
+	mustNoErr(t, prd.AddDocumentDependency(platform, "PRD-2026-003", ""))
+
+	g, err := Build(newWorkspace(platform, feature, legacy))
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	if len(g.Nodes) != 4 {
+		t.Errorf("expected 4 nodes including the missing PRD, got %d", len(g.Nodes))
+	}
+	if n := g.Node("PRD-2026-099"); n == nil || !n.Missing {
+		t.Error("expected PRD-2026-099 to be a missing node")
+	}
+	if deps := g.Dependencies("PRD-2026-002"); len(deps) != 2 {
+		t.Errorf("expected 2 distinct dependencies, got %v", deps)
+	}
+	if dependents := g.Dependents("PRD-2026-001"); len(dependents) != 1 || dependents[0] != "PRD-2026-002" {
+		t.Errorf("expected PRD-2026-002 to depend on the platform, got %v", dependents)
+	}
+
+	counts := make(map[string]int)
+	for _, issue := range g.Check() {
+		counts[issue.Type]++
+	}
+	if counts[IssueMissingPRD] != 1 {
+		t.Errorf("expected 1 missing PRD issue, got %d", counts[IssueMissingPRD])
+	}
+	if counts[IssueDeprecated] != 1 {
+		t.Errorf("expected 1 deprecated dependency issue, got %d", counts[IssueDeprecated])
+	}
+	if counts[IssueMissingRequirement] != 1 {
+		t.Errorf("expected 1 missing requirement issue, got %d", counts[IssueMissingRequirement])
+	}
+	if counts[IssueCycle] != 0 {
+		t.Errorf("expected no cycles, got %d", counts[IssueCycle])
+	}
+}
+
+func TestCycles(t *testing.T) {
+	a := prd.New("PRD-A", "Alpha", prd.Person{Name: "Owner"})
+	b := prd.New("PRD-B", "Beta", prd.Person{Name: "Owner"})
+	c := prd.New("PRD-C", "Gamma", prd.Person{Name: "Owner"})
+	d := prd.New("PRD-D", "Delta", prd.Person{Name: "Owner"})
+	mustNoErr(t, prd.AddDocumentDependency(a, "PRD-B", ""))
+	mustNoErr(t, prd.AddDocumentDependency(b, "PRD-C", ""))
+	mustNoErr(t, prd.AddDocumentDependency(c, "PRD-A", ""))
+	mustNoErr(t, prd.AddDocumentDependency(d, "PRD-A", ""))
+
+	g, err := Build(newWorkspace(a, b, c, d))
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	cycles := g.Cycles()
+	if len(cycles) != 1 {
+		t.Fatalf("expected 1 cycle, got %d", len(cycles))
+	}
+	if strings.Join(cycles[0], ",") != "PRD-A,PRD-B,PRD-C" {
+		t.Errorf("expected cycle PRD-A,PRD-B,PRD-C, got %v", cycles[0])
+	}
+}
+
+func TestDuplicateIDs(t *testing.T) {
+	first := prd.New("PRD-2026-001", "Platform", prd.Person{Name: "Owner"})
+	copied := prd.New("PRD-2026-001", "Platform (copy)", prd.Person{Name: "Owner"})
+	mustNoErr(t, prd.AddDocumentDependency(copied, "PRD-2026-002", ""))
+	other := prd.New("PRD-2026-002", "Feature", prd.Person{Name: "Owner"})
+
+	ws := &workspace.Workspace{Root: ".", Documents: []*workspace.Document{
+		{Path: "platform.json", PRD: first},
+		{Path: "old/platform.json", PRD: copied},
+		{Path: "feature.json", PRD: other},
+	}}
+	g, err := Build(ws)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	if len(g.Nodes) != 2 || g.Node("PRD-2026-001").Path != "platform.json" {
+		t.Errorf("expected the first file to be the node, got %+v", g.Node("PRD-2026-001"))
+	}
+	if deps := g.Dependencies("PRD-2026-001"); len(deps) != 0 {
+		t.Errorf("expected the duplicate's dependencies to be left out, got %v", deps)
+	}
+
+	issues := g.Check()
+	if len(issues) != 1 || issues[0].Type != IssueDuplicateID {
+		t.Fatalf("expected a duplicate ID issue, got %+v", issues)
+	}
+	if !strings.Contains(issues[0].Message, "platform.json, old/platform.json") {
+		t.Errorf("expected both files in %q", issues[0].Message)
+	}
+}
+
+func TestExport(t *testing.T) {
+	a := prd.New("PRD-2026-001", "Platform", prd.Person{Name: "Owner"})
+	b := prd.New("PRD-2026-002", "Feature", prd.Person{Name: "Owner"})
+	reqID := prd.AddFunctionalRequirement(b, "Login", "Users can log in", prd.MoSCoWMust)
+	mustNoErr(t, prd.AddRequirementDependency(b, reqID, "PRD-2026-001", "FR-1", ""))
+
+	g, err := Build(newWorkspace(a, b))
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	dot := g.DOT()
+	if !strings.Contains(dot, `"PRD-2026-002" -> "PRD-2026-001" [label="FR-1 → FR-1"]`) {
+		t.Errorf("expected labeled edge in DOT output, got:\n%s", dot)
+	}
+
+	mermaid := g.Mermaid()
+	if !strings.HasPrefix(mermaid, "flowchart LR") {
+		t.Error("expected Mermaid flowchart header")
+	}
+	if !strings.Contains(mermaid, "PRD_2026_002 -->|FR-1 → FR-1| PRD_2026_001") {
+		t.Errorf("expected labeled edge in Mermaid output, got:\n%s", mermaid)
+	}
+}
+
+func newWorkspace(prds ...*prd.PRD) *workspace.Workspace {
+	ws := &workspace.Workspace{Root: "."}
+	for _, p := range prds {
+		ws.Documents = append(ws.Documents, &workspace.Document{Path: p.Metadata.ID + ".json", PRD: p})
+	}
+	return ws
+}
+
+func mustNoErr(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
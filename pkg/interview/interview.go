@@ -0,0 +1,207 @@
+// Package interview runs a question session that fills the gaps in a PRD.
+//
+// The session scores the PRD, asks about its weakest categories first, and
+// turns each answer into pkg/prd operations. It rescores after every answer
+// and ends once the score reaches the approval threshold or no questions
+// remain. Progress is kept in a State that can be saved and resumed.
+package interview
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/agent-team-prd/pkg/scoring"
+)
+
+// State is the saved progress of an interview.
+type State struct {
+	PRDID     string    `json:"prdId"`
+	StartedAt time.Time `json:"startedAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	// History lists the answered questions. A question is asked again
+	// while its gap remains (for example, one risk after another), unless
+	// its ID is in Skipped.
+	History []Turn   `json:"history,omitempty"`
+	Skipped []string `json:"skipped,omitempty"`
+
+	Completed bool `json:"completed,omitempty"`
+}
+
+// Turn records an answered question and its effect on the score.
+type Turn struct {
+	QuestionID  string            `json:"questionId"`
+	Answer      map[string]string `json:"answer"`
+	ScoreBefore float64           `json:"scoreBefore"`
+	ScoreAfter  float64           `json:"scoreAfter"`
+	AnsweredAt  time.Time         `json:"answeredAt"`
+}
+
+// NewState starts an interview for a PRD.
+func NewState(p *prd.PRD) *State {
+	now := time.Now().UTC()
+	return &State{PRDID: p.Metadata.ID, StartedAt: now, UpdatedAt: now}
+}
+
+// StatePath returns the default state file for a PRD file:
+// PRD.json is interviewed with PRD.interview.json.
+func StatePath(prdPath string) string {
+	ext := filepath.Ext(prdPath)
+	return strings.TrimSuffix(prdPath, ext) + ".interview.json"
+}
+
+// LoadState reads a saved interview.
+func LoadState(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// Save writes the interview state to path.
+func (s *State) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0600)
+}
+
+func (s *State) skipped(id string) bool {
+	for _, skipped := range s.Skipped {
+		if skipped == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Session is an interview in progress.
+type Session struct {
+	PRD   *prd.PRD
+	State *State
+}
+
+// NewSession resumes state for p, or starts a new interview if state is
+// nil. It fails if the state belongs to a different PRD.
+func NewSession(p *prd.PRD, state *State) (*Session, error) {
+	if state == nil {
+		state = NewState(p)
+	}
+	if state.PRDID != p.Metadata.ID {
+		return nil, fmt.Errorf("interview state is for %s, not %s", state.PRDID, p.Metadata.ID)
+	}
+	return &Session{PRD: p, State: state}, nil
+}
+
+// Score returns the PRD's current weighted score.
+func (s *Session) Score() float64 {
+	return scoring.Score(s.PRD).WeightedScore
+}
+
+// Done reports whether the score has reached the approval threshold.
+func (s *Session) Done() bool {
+	return s.Score() >= scoring.ThresholdApprove
+}
+
+// Next returns the question to ask next, or nil if none remain.
+//
+// Questions are taken from the weakest scoring category first; within a
+// category they are asked in catalog order. Skipped questions, and those
+// whose gap has been filled, are left out.
+func (s *Session) Next() *Question {
+	scores := make(map[string]float64)
+	for _, cs := range scoring.Score(s.PRD).CategoryScores {
+		scores[cs.Category] = cs.Score
+	}
+
+	var candidates []*Question
+	for i := range catalog {
+		q := &catalog[i]
+		if !s.State.skipped(q.ID) && q.applies(s.PRD) {
+			candidates = append(candidates, q)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return scores[candidates[i].Category] < scores[candidates[j].Category]
+	})
+
+	if len(candidates) == 0 {
+		return nil
+	}
+	return candidates[0]
+}
+
+// Answer applies an answer to the PRD, records it and returns the scores
+// before and after. Fields left empty take their defaults; a required
+// field without a value is an error.
+func (s *Session) Answer(q *Question, answer map[string]string) (before, after float64, err error) {
+	values := make(map[string]string, len(q.Fields))
+	for _, f := range q.Fields {
+		v := strings.TrimSpace(answer[f.Name])
+		if v == "" {
+			v = f.Default
+		}
+		if v == "" && !f.Optional {
+			return 0, 0, fmt.Errorf("%s is required", strings.ToLower(f.Prompt))
+		}
+		if v != "" && len(f.Choices) > 0 && !contains(f.Choices, v) {
+			return 0, 0, fmt.Errorf("%s must be one of: %s", strings.ToLower(f.Prompt), strings.Join(f.Choices, ", "))
+		}
+		values[f.Name] = v
+	}
+
+	before = s.Score()
+	if err := q.apply(s.PRD, values); err != nil {
+		return 0, 0, err
+	}
+	after = s.Score()
+
+	now := time.Now().UTC()
+	s.State.History = append(s.State.History, Turn{
+		QuestionID:  q.ID,
+		Answer:      values,
+		ScoreBefore: before,
+		ScoreAfter:  after,
+		AnsweredAt:  now,
+	})
+	s.State.UpdatedAt = now
+	return before, after, nil
+}
+
+// Skip marks a question as skipped.
+func (s *Session) Skip(q *Question) {
+	s.State.Skipped = append(s.State.Skipped, q.ID)
+	s.State.UpdatedAt = time.Now().UTC()
+}
+
+func contains(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// splitList splits a comma-separated answer into trimmed, non-empty items.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,194 @@
+package interview
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/agent-team-prd/pkg/scoring"
+)
+
+func newTestSession(t *testing.T) *Session {
+	t.Helper()
+	p := prd.New("PRD-2026-001", "Test PRD", prd.Person{Name: "Owner"})
+	s, err := NewSession(p, nil)
+	if err != nil {
+		t.Fatalf("NewSession failed: %v", err)
+	}
+	return s
+}
+
+func question(t *testing.T, id string) *Question {
+	t.Helper()
+	for i := range catalog {
+		if catalog[i].ID == id {
+			return &catalog[i]
+		}
+	}
+	t.Fatalf("question %s not found", id)
+	return nil
+}
+
+func TestCatalogCoversCategories(t *testing.T) {
+	seen := make(map[string]bool)
+	ids := make(map[string]bool)
+	for _, q := range catalog {
+		if !scoring.IsCategory(q.Category) {
+			t.Errorf("question %s has unknown category %s", q.ID, q.Category)
+		}
+		if ids[q.ID] {
+			t.Errorf("duplicate question ID %s", q.ID)
+		}
+		seen[q.Category] = true
+		ids[q.ID] = true
+	}
+	for _, c := range scoring.Categories() {
+		if !seen[c.ID] {
+			t.Errorf("no questions for %s", c.ID)
+		}
+	}
+}
+
+func TestAnswer(t *testing.T) {
+	s := newTestSession(t)
+
+	if _, _, err := s.Answer(question(t, "risk"), map[string]string{"description": "Provider outage", "impact": "severe"}); err == nil {
+		t.Error("expected error for an invalid choice")
+	}
+	if _, _, err := s.Answer(question(t, "risk"), map[string]string{"mitigation": "Fallback"}); err == nil {
+		t.Error("expected error for a missing required field")
+	}
+
+	_, _, err := s.Answer(question(t, "risk"), map[string]string{"description": "Provider outage", "impact": "high"})
+	if err != nil {
+		t.Fatalf("Answer failed: %v", err)
+	}
+	if len(s.PRD.Risks) != 1 || s.PRD.Risks[0].Impact != prd.RiskImpactHigh || s.PRD.Risks[0].Probability != prd.RiskProbabilityMedium {
+		t.Errorf("expected one high impact risk with default probability, got %v", s.PRD.Risks)
+	}
+	if len(s.State.History) != 1 || s.State.History[0].QuestionID != "risk" {
+		t.Errorf("expected the answer in history, got %v", s.State.History)
+	}
+
+	// The risk has no mitigation, so the follow-up question applies.
+	mitigation := question(t, "risk.mitigation")
+	if !mitigation.applies(s.PRD) || !strings.Contains(mitigation.Text(s.PRD), "RISK-1") {
+		t.Errorf("expected a mitigation question about RISK-1, got %q", mitigation.Text(s.PRD))
+	}
+}
+
+func TestNextSkipsAnsweredAndSkipped(t *testing.T) {
+	s := newTestSession(t)
+
+	first := s.Next()
+	if first == nil {
+		t.Fatal("expected a question for an empty PRD")
+	}
+	s.Skip(first)
+	if next := s.Next(); next == nil || next.ID == first.ID {
+		t.Errorf("expected a different question after skipping %s", first.ID)
+	}
+
+	if _, _, err := s.Answer(question(t, "nongoals"), map[string]string{"items": "Mobile app, SSO"}); err != nil {
+		t.Fatalf("Answer failed: %v", err)
+	}
+	if len(s.PRD.OutOfScope) != 2 {
+		t.Errorf("expected 2 non-goals, got %v", s.PRD.OutOfScope)
+	}
+	for q := s.Next(); q != nil; q = s.Next() {
+		if q.ID == "nongoals" {
+			t.Fatal("expected answered non-goals question not to be asked again")
+		}
+		s.Skip(q)
+	}
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	s := newTestSession(t)
+	s.Skip(question(t, "persona"))
+
+	path := filepath.Join(t.TempDir(), "PRD.interview.json")
+	if err := s.State.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := LoadState(path)
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+
+	resumed, err := NewSession(s.PRD, loaded)
+	if err != nil {
+		t.Fatalf("NewSession failed: %v", err)
+	}
+	if !resumed.State.skipped("persona") {
+		t.Error("expected skipped question to be restored")
+	}
+
+	other := prd.New("PRD-2026-002", "Other PRD", prd.Person{Name: "Owner"})
+	if _, err := NewSession(other, loaded); err == nil {
+		t.Error("expected error resuming another PRD's interview")
+	}
+}
+
+func TestStatePath(t *testing.T) {
+	if got := StatePath("docs/PRD.json"); got != "docs/PRD.interview.json" {
+		t.Errorf("unexpected state path %s", got)
+	}
+}
+
+func TestRunner(t *testing.T) {
+	s := newTestSession(t)
+	saves := 0
+	var out bytes.Buffer
+	r := &Runner{
+		Session: s,
+		In:      strings.NewReader(CommandQuit + "\n"),
+		Out:     &out,
+		Save:    func() error { saves++; return nil },
+	}
+	if err := r.Run(); !errors.Is(err, ErrQuit) {
+		t.Fatalf("expected ErrQuit, got %v", err)
+	}
+	if saves != 1 {
+		t.Errorf("expected state to be saved on quit, got %d saves", saves)
+	}
+
+	// Skipping everything (empty required fields) ends the interview.
+	r.In = strings.NewReader(strings.Repeat("\n", 100))
+	if err := r.Run(); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !s.State.Completed || len(s.State.Skipped) == 0 {
+		t.Errorf("expected a completed interview with skipped questions, got %+v", s.State)
+	}
+}
+
+func TestRunnerAnswers(t *testing.T) {
+	s := newTestSession(t)
+	q := s.Next()
+
+	// Answer the first question with "x" for every field, then quit.
+	var input strings.Builder
+	for _, f := range q.Fields {
+		switch {
+		case len(f.Choices) > 0:
+			input.WriteString(f.Choices[0] + "\n")
+		case f.Name == "id":
+			input.WriteString("SOL-1\n")
+		default:
+			input.WriteString("x\n")
+		}
+	}
+	input.WriteString(CommandQuit + "\n")
+
+	r := &Runner{Session: s, In: strings.NewReader(input.String()), Out: &bytes.Buffer{}, Save: func() error { return nil }}
+	if err := r.Run(); !errors.Is(err, ErrQuit) {
+		t.Fatalf("expected ErrQuit, got %v", err)
+	}
+	if len(s.State.History) != 1 || s.State.History[0].QuestionID != q.ID {
+		t.Errorf("expected %s to be answered, got %v", q.ID, s.State.History)
+	}
+}
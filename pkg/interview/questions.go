@@ -0,0 +1,451 @@
+package interview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+)
+
+// Question is a targeted question about a gap in the PRD.
+type Question struct {
+	ID       string
+	Category string
+	Fields   []Field
+
+	text    func(p *prd.PRD) string
+	applies func(p *prd.PRD) bool
+	apply   func(p *prd.PRD, a map[string]string) error
+}
+
+// Text returns the question as asked about p.
+func (q *Question) Text(p *prd.PRD) string {
+	return q.text(p)
+}
+
+// Field is one value asked for by a question.
+type Field struct {
+	Name     string
+	Prompt   string
+	Default  string
+	Optional bool
+
+	// List fields accept several comma-separated values.
+	List bool
+
+	// Choices, if set, are the only accepted values.
+	Choices []string
+}
+
+func static(text string) func(p *prd.PRD) string {
+	return func(*prd.PRD) string { return text }
+}
+
+var (
+	probabilities   = prd.RiskProbabilityValues()
+	impacts         = prd.RiskImpactValues()
+	priorities      = []string{"must", "should", "could"}
+	nfrCategories   = prd.NFRCategoryValues()
+	evidenceTypes   = []string{string(prd.EvidenceInterview), string(prd.EvidenceSurvey), string(prd.EvidenceAnalytics), string(prd.EvidenceSupportTicket), string(prd.EvidenceMarketResearch)}
+	alternativeKind = []string{string(prd.AlternativeCompetitor), string(prd.AlternativeWorkaround), string(prd.AlternativeDoNothing), string(prd.AlternativeInternalTool)}
+)
+
+// catalog lists every question, grouped by category in rubric order.
+var catalog = []Question{
+	// Problem Definition
+	{
+		ID:       "problem",
+		Category: "problem_definition",
+		Fields: []Field{
+			{Name: "statement", Prompt: "Problem statement"},
+			{Name: "impact", Prompt: "User impact", Optional: true},
+		},
+		text: static("What problem does this product solve, and who feels it?"),
+		applies: func(p *prd.PRD) bool {
+			return p.Problem == nil || p.Problem.Statement == ""
+		},
+		apply: func(p *prd.PRD, a map[string]string) error {
+			confidence := 0.5
+			if p.Problem != nil && p.Problem.Confidence > 0 {
+				confidence = p.Problem.Confidence
+			}
+			prd.SetProblemStatement(p, a["statement"], a["impact"], confidence)
+			return nil
+		},
+	},
+	{
+		ID:       "problem.evidence",
+		Category: "problem_definition",
+		Fields: []Field{
+			{Name: "summary", Prompt: "What the evidence shows"},
+			{Name: "type", Prompt: "Evidence type", Default: string(prd.EvidenceInterview), Choices: evidenceTypes},
+			{Name: "source", Prompt: "Source", Optional: true},
+		},
+		text: static("What evidence shows this problem is real (interviews, surveys, analytics, tickets)?"),
+		applies: func(p *prd.PRD) bool {
+			if p.Problem == nil || p.Problem.Statement == "" {
+				return false
+			}
+			for _, e := range p.Problem.Evidence {
+				if e.Type != prd.EvidenceAssumption {
+					return false
+				}
+			}
+			return true
+		},
+		apply: func(p *prd.PRD, a map[string]string) error {
+			prd.AddEvidence(p, prd.EvidenceType(a["type"]), a["summary"], a["source"])
+			return nil
+		},
+	},
+	{
+		ID:       "problem.root_causes",
+		Category: "problem_definition",
+		Fields:   []Field{{Name: "causes", Prompt: "Root causes", List: true}},
+		text:     static("What are the root causes of the problem?"),
+		applies: func(p *prd.PRD) bool {
+			return p.Problem != nil && p.Problem.Statement != "" && len(p.Problem.RootCauses) == 0
+		},
+		apply: func(p *prd.PRD, a map[string]string) error {
+			p.Problem.RootCauses = splitList(a["causes"])
+			return nil
+		},
+	},
+
+	// User Understanding
+	{
+		ID:       "persona",
+		Category: "user_understanding",
+		Fields: []Field{
+			{Name: "name", Prompt: "Persona name"},
+			{Name: "role", Prompt: "Role", Optional: true},
+			{Name: "pain_points", Prompt: "Pain points", Optional: true, List: true},
+		},
+		text: static("Who is the primary user, and what frustrates them today?"),
+		applies: func(p *prd.PRD) bool {
+			return len(p.Personas) == 0
+		},
+		apply: func(p *prd.PRD, a map[string]string) error {
+			prd.AddPersona(p, a["name"], a["role"], splitList(a["pain_points"]))
+			return nil
+		},
+	},
+	{
+		ID:       "persona.pain_points",
+		Category: "user_understanding",
+		Fields:   []Field{{Name: "pain_points", Prompt: "Pain points", List: true}},
+		text: func(p *prd.PRD) string {
+			return fmt.Sprintf("What pain points does %s have?", p.Personas[personaWithoutPainPoints(p)].Name)
+		},
+		applies: func(p *prd.PRD) bool {
+			return personaWithoutPainPoints(p) >= 0
+		},
+		apply: func(p *prd.PRD, a map[string]string) error {
+			p.Personas[personaWithoutPainPoints(p)].PainPoints = splitList(a["pain_points"])
+			return nil
+		},
+	},
+
+	// Market Awareness
+	{
+		ID:       "alternative",
+		Category: "market_awareness",
+		Fields: []Field{
+			{Name: "name", Prompt: "Alternative"},
+			{Name: "type", Prompt: "Type", Default: string(prd.AlternativeCompetitor), Choices: alternativeKind},
+		},
+		text: static("What do users use instead today: a competitor, a workaround, or nothing?"),
+		applies: func(p *prd.PRD) bool {
+			return p.Market == nil || len(p.Market.Alternatives) == 0
+		},
+		apply: func(p *prd.PRD, a map[string]string) error {
+			prd.AddAlternative(p, a["name"], prd.AlternativeType(a["type"]))
+			return nil
+		},
+	},
+
+	// Solution Fit
+	{
+		ID:       "solution",
+		Category: "solution_fit",
+		Fields: []Field{
+			{Name: "name", Prompt: "Solution name"},
+			{Name: "description", Prompt: "Description", Optional: true},
+			{Name: "tradeoffs", Prompt: "Tradeoffs", Optional: true, List: true},
+		},
+		text: func(p *prd.PRD) string {
+			if p.Solution != nil && len(p.Solution.SolutionOptions) > 0 {
+				return "What other solution did you consider?"
+			}
+			return "What solution do you propose?"
+		},
+		applies: func(p *prd.PRD) bool {
+			return p.Solution == nil || len(p.Solution.SolutionOptions) < 2
+		},
+		apply: func(p *prd.PRD, a map[string]string) error {
+			prd.AddSolution(p, a["name"], a["description"], splitList(a["tradeoffs"]))
+			return nil
+		},
+	},
+	{
+		ID:       "solution.select",
+		Category: "solution_fit",
+		Fields: []Field{
+			{Name: "id", Prompt: "Solution ID"},
+			{Name: "rationale", Prompt: "Why this one", Optional: true},
+		},
+		text: func(p *prd.PRD) string {
+			text := "Which solution do you recommend?"
+			for _, opt := range p.Solution.SolutionOptions {
+				text += fmt.Sprintf("\n  %s: %s", opt.ID, opt.Name)
+			}
+			return text
+		},
+		applies: func(p *prd.PRD) bool {
+			return p.Solution != nil && len(p.Solution.SolutionOptions) > 0 && p.Solution.SelectedSolutionID == ""
+		},
+		apply: func(p *prd.PRD, a map[string]string) error {
+			if !prd.SelectSolution(p, a["id"], a["rationale"]) {
+				return fmt.Errorf("solution not found: %s", a["id"])
+			}
+			return nil
+		},
+	},
+
+	// Scope Discipline
+	{
+		ID:       "nongoals",
+		Category: "scope_discipline",
+		Fields:   []Field{{Name: "items", Prompt: "Non-goals", List: true}},
+		text:     static("What is explicitly out of scope?"),
+		applies: func(p *prd.PRD) bool {
+			return len(p.OutOfScope) == 0
+		},
+		apply: func(p *prd.PRD, a map[string]string) error {
+			for _, item := range splitList(a["items"]) {
+				prd.AddOutOfScope(p, item)
+			}
+			return nil
+		},
+	},
+
+	// Requirements Quality
+	{
+		ID:       "requirement",
+		Category: "requirements_quality",
+		Fields: []Field{
+			{Name: "description", Prompt: "Requirement"},
+			{Name: "title", Prompt: "Title", Optional: true},
+			{Name: "priority", Prompt: "Priority", Default: "must", Choices: priorities},
+		},
+		text: static("What is the most important thing the product must do?"),
+		applies: func(p *prd.PRD) bool {
+			for _, fr := range p.Requirements.Functional {
+				if fr.Priority == prd.MoSCoWMust {
+					return false
+				}
+			}
+			return true
+		},
+		apply: func(p *prd.PRD, a map[string]string) error {
+			priority, err := prd.ParseMoSCoW(a["priority"])
+			if err != nil {
+				return err
+			}
+			prd.AddFunctionalRequirement(p, a["title"], a["description"], priority)
+			return nil
+		},
+	},
+	{
+		ID:       "nfr",
+		Category: "requirements_quality",
+		Fields: []Field{
+			{Name: "requirement", Prompt: "Requirement"},
+			{Name: "category", Prompt: "Category", Default: "performance", Choices: nfrCategories},
+			{Name: "target", Prompt: "Target", Optional: true},
+		},
+		text: static("What performance, security or reliability requirement must it meet?"),
+		applies: func(p *prd.PRD) bool {
+			return len(p.Requirements.NonFunctional) == 0
+		},
+		apply: func(p *prd.PRD, a map[string]string) error {
+			category, err := prd.ParseNFRCategory(a["category"])
+			if err != nil {
+				return err
+			}
+			prd.AddNonFunctionalRequirement(p, category, "", a["requirement"], a["target"], prd.MoSCoWShould)
+			return nil
+		},
+	},
+
+	// Metrics Quality
+	{
+		ID:       "metric",
+		Category: "metrics_quality",
+		Fields: []Field{
+			{Name: "name", Prompt: "Metric"},
+			{Name: "target", Prompt: "Target"},
+			{Name: "description", Prompt: "How it is measured", Optional: true},
+		},
+		text: static("How will you measure success?"),
+		applies: func(p *prd.PRD) bool {
+			for _, okr := range p.Objectives.OKRs {
+				if len(okr.KeyResults) > 0 {
+					return false
+				}
+			}
+			return true
+		},
+		apply: func(p *prd.PRD, a map[string]string) error {
+			prd.AddSuccessMetric(p, a["name"], a["description"], a["target"])
+			return nil
+		},
+	},
+	{
+		ID:       "metric.baseline",
+		Category: "metrics_quality",
+		Fields:   []Field{{Name: "baseline", Prompt: "Baseline"}},
+		text: func(p *prd.PRD) string {
+			i, j := keyResultWithoutBaseline(p)
+			kr := p.Objectives.OKRs[i].KeyResults[j]
+			return fmt.Sprintf("What is the current value of %s (%s)?", kr.Title, kr.ID)
+		},
+		applies: func(p *prd.PRD) bool {
+			i, _ := keyResultWithoutBaseline(p)
+			return i >= 0
+		},
+		apply: func(p *prd.PRD, a map[string]string) error {
+			i, j := keyResultWithoutBaseline(p)
+			p.Objectives.OKRs[i].KeyResults[j].Baseline = a["baseline"]
+			return nil
+		},
+	},
+
+	// UX Coverage
+	{
+		ID:       "ux.principles",
+		Category: "ux_coverage",
+		Fields:   []Field{{Name: "principles", Prompt: "Design principles", List: true}},
+		text:     static("What design principles should guide the user experience?"),
+		applies: func(p *prd.PRD) bool {
+			return p.UXRequirements == nil || len(p.UXRequirements.DesignPrinciples) == 0
+		},
+		apply: func(p *prd.PRD, a map[string]string) error {
+			if p.UXRequirements == nil {
+				p.UXRequirements = &prd.UXRequirements{}
+			}
+			p.UXRequirements.DesignPrinciples = splitList(a["principles"])
+			return nil
+		},
+	},
+
+	// Technical Feasibility
+	{
+		ID:       "tech.overview",
+		Category: "technical_feasibility",
+		Fields:   []Field{{Name: "overview", Prompt: "Architecture overview"}},
+		text:     static("How will this be built, at a high level?"),
+		applies: func(p *prd.PRD) bool {
+			return p.TechArchitecture == nil || p.TechArchitecture.Overview == ""
+		},
+		apply: func(p *prd.PRD, a map[string]string) error {
+			if p.TechArchitecture == nil {
+				p.TechArchitecture = &prd.TechnicalArchitecture{}
+			}
+			p.TechArchitecture.Overview = a["overview"]
+			return nil
+		},
+	},
+
+	// Risk Management
+	{
+		ID:       "risk",
+		Category: "risk_management",
+		Fields: []Field{
+			{Name: "description", Prompt: "Risk"},
+			{Name: "probability", Prompt: "Probability", Default: "medium", Choices: probabilities},
+			{Name: "impact", Prompt: "Impact", Default: "medium", Choices: impacts},
+			{Name: "mitigation", Prompt: "Mitigation", Optional: true},
+		},
+		text: static("What is the biggest risk to this product, and how would you mitigate it?"),
+		applies: func(p *prd.PRD) bool {
+			return len(p.Risks) == 0
+		},
+		apply: func(p *prd.PRD, a map[string]string) error {
+			probability, err := prd.ParseRiskProbability(a["probability"])
+			if err != nil {
+				return err
+			}
+			impact, err := prd.ParseRiskImpact(a["impact"])
+			if err != nil {
+				return err
+			}
+			prd.AddRisk(p, a["description"], probability, impact, a["mitigation"])
+			return nil
+		},
+	},
+	{
+		ID:       "risk.mitigation",
+		Category: "risk_management",
+		Fields:   []Field{{Name: "mitigation", Prompt: "Mitigation"}},
+		text: func(p *prd.PRD) string {
+			r := p.Risks[riskWithoutMitigation(p)]
+			return fmt.Sprintf("How will you mitigate %s (%s)?", r.Description, r.ID)
+		},
+		applies: func(p *prd.PRD) bool {
+			return riskWithoutMitigation(p) >= 0
+		},
+		apply: func(p *prd.PRD, a map[string]string) error {
+			p.Risks[riskWithoutMitigation(p)].Mitigation = a["mitigation"]
+			return nil
+		},
+	},
+}
+
+func personaWithoutPainPoints(p *prd.PRD) int {
+	for i, persona := range p.Personas {
+		if len(persona.PainPoints) == 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+func keyResultWithoutBaseline(p *prd.PRD) (int, int) {
+	for i, okr := range p.Objectives.OKRs {
+		for j, kr := range okr.KeyResults {
+			if kr.Baseline == "" {
+				return i, j
+			}
+		}
+	}
+	return -1, -1
+}
+
+func riskWithoutMitigation(p *prd.PRD) int {
+	for i, r := range p.Risks {
+		if r.Mitigation == "" {
+			return i
+		}
+	}
+	return -1
+}
+
+// Describe returns the hint shown after a field's prompt, such as
+// "low/medium/high, default medium".
+func (f Field) Describe() string {
+	var hints []string
+	if len(f.Choices) > 0 {
+		hints = append(hints, strings.Join(f.Choices, "/"))
+	}
+	if f.Default != "" {
+		hints = append(hints, "default "+f.Default)
+	}
+	if f.List {
+		hints = append(hints, "comma-separated")
+	}
+	if f.Optional {
+		hints = append(hints, "optional")
+	}
+	return strings.Join(hints, ", ")
+}
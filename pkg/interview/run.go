@@ -0,0 +1,121 @@
+package interview
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/agentplexus/agent-team-prd/pkg/scoring"
+)
+
+// Commands recognized at any prompt.
+const (
+	CommandSkip = "/skip"
+	CommandQuit = "/quit"
+)
+
+// ErrQuit is returned by Run when the user quits before the interview is
+// complete.
+var ErrQuit = errors.New("interview paused")
+
+// Runner asks a session's questions on a reader and writer, such as stdin
+// and stdout.
+type Runner struct {
+	Session *Session
+	In      io.Reader
+	Out     io.Writer
+
+	// Save is called after each answer or skip, and when the user quits,
+	// to persist the PRD and the interview state.
+	Save func() error
+}
+
+// Run asks questions until the score reaches the approval threshold, no
+// questions remain, or the user quits. It returns ErrQuit if the user quit
+// (or input ended) with questions left.
+func (r *Runner) Run() error {
+	in := bufio.NewReader(r.In)
+	s := r.Session
+
+	fmt.Fprintf(r.Out, "Score: %.1f/10 (target %.1f)\n", s.Score(), scoring.ThresholdApprove)
+	fmt.Fprintf(r.Out, "Press Enter on a required field or type %s to skip a question, %s to stop.\n", CommandSkip, CommandQuit)
+
+	for {
+		if s.Done() {
+			s.State.Completed = true
+			fmt.Fprintf(r.Out, "\nThe PRD has reached the approval threshold.\n")
+			return r.Save()
+		}
+		q := s.Next()
+		if q == nil {
+			s.State.Completed = true
+			fmt.Fprintf(r.Out, "\nNo more questions. Run 'prdtool score next' for further improvements.\n")
+			return r.Save()
+		}
+
+		fmt.Fprintf(r.Out, "\n[%s] %s\n", scoring.CategoryName(q.Category), q.Text(s.PRD))
+		answer, command, err := r.ask(in, q)
+		if err != nil {
+			return err
+		}
+
+		switch command {
+		case CommandQuit:
+			if err := r.Save(); err != nil {
+				return err
+			}
+			return ErrQuit
+		case CommandSkip:
+			s.Skip(q)
+			if err := r.Save(); err != nil {
+				return err
+			}
+			continue
+		}
+
+		before, after, err := s.Answer(q, answer)
+		if err != nil {
+			fmt.Fprintf(r.Out, "  %v\n", err)
+			continue
+		}
+		if err := r.Save(); err != nil {
+			return err
+		}
+		fmt.Fprintf(r.Out, "  Score: %.1f → %.1f (%+.1f)\n", before, after, after-before)
+	}
+}
+
+// ask reads each field of a question. It returns a command instead of an
+// answer if the user types one, or leaves a required field empty (skip).
+// End of input is treated as quitting.
+func (r *Runner) ask(in *bufio.Reader, q *Question) (map[string]string, string, error) {
+	answer := make(map[string]string, len(q.Fields))
+	for _, f := range q.Fields {
+		prompt := f.Prompt
+		if hint := f.Describe(); hint != "" {
+			prompt += " (" + hint + ")"
+		}
+		fmt.Fprintf(r.Out, "  %s: ", prompt)
+
+		line, err := in.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, "", err
+		}
+		if errors.Is(err, io.EOF) && line == "" {
+			fmt.Fprintln(r.Out)
+			return nil, CommandQuit, nil
+		}
+
+		value := strings.TrimSpace(line)
+		switch {
+		case value == CommandQuit || value == CommandSkip:
+			return nil, value, nil
+		case value == "" && f.Default == "" && !f.Optional:
+			return nil, CommandSkip, nil
+		}
+		answer[f.Name] = value
+	}
+	return answer, "", nil
+}
@@ -0,0 +1,30 @@
+// Package mermaid holds the escaping helpers shared by the packages that
+// generate Mermaid diagrams.
+package mermaid
+
+import (
+	"regexp"
+	"strings"
+)
+
+var unsafeID = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// ID converts a PRD or entity ID into a valid Mermaid node identifier.
+func ID(id string) string {
+	return unsafeID.ReplaceAllString(id, "_")
+}
+
+// Words replaces the punctuation in an ID with spaces, for diagram types
+// such as quadrantChart that don't accept it in unquoted labels.
+func Words(id string) string {
+	return unsafeID.ReplaceAllString(id, " ")
+}
+
+// textReplacer escapes the characters that break quoted node labels and
+// |edge labels|, and flattens newlines.
+var textReplacer = strings.NewReplacer(`"`, "#quot;", "|", "#124;", "\r\n", " ", "\n", " ")
+
+// Text escapes s for use in a quoted node label or an edge label.
+func Text(s string) string {
+	return textReplacer.Replace(s)
+}
@@ -0,0 +1,19 @@
+package mermaid
+
+import "testing"
+
+func TestID(t *testing.T) {
+	if got := ID("PRD-2026-001"); got != "PRD_2026_001" {
+		t.Errorf("expected PRD_2026_001, got %s", got)
+	}
+	if got := Words("RISK-1"); got != "RISK 1" {
+		t.Errorf("expected 'RISK 1', got %s", got)
+	}
+}
+
+func TestText(t *testing.T) {
+	got := Text("Say \"hi\" | wave\nthen leave")
+	if want := "Say #quot;hi#quot; #124; wave then leave"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,90 @@
+package prd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Comment is a reviewer's comment on a PRD. Comments are stored in the
+// "comments" custom section.
+type Comment struct {
+	ID     string `json:"id"`
+	Author string `json:"author"`
+	Body   string `json:"body"`
+	// Target is the entity or section the comment is about, such as
+	// "FR-3" or "risks". Empty means the whole document.
+	Target    string    `json:"target,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	Resolved  bool      `json:"resolved,omitempty"`
+}
+
+// GetComments returns the comments recorded in the PRD, oldest first.
+func GetComments(p *PRD) ([]Comment, error) {
+	var comments []Comment
+	if _, err := DecodeCustomSection(p, SectionComments, &comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// SetComments stores comments in the PRD, removing the section when empty.
+func SetComments(p *PRD, comments []Comment) {
+	if len(comments) == 0 {
+		RemoveCustomSection(p, SectionComments)
+		return
+	}
+	SetCustomSection(p, SectionComments, "Comments", comments)
+}
+
+// AddComment records a comment and returns it with its generated ID.
+func AddComment(p *PRD, author, body, target string) (Comment, error) {
+	author, body = strings.TrimSpace(author), strings.TrimSpace(body)
+	if author == "" {
+		return Comment{}, fmt.Errorf("author is required")
+	}
+	if body == "" {
+		return Comment{}, fmt.Errorf("comment body is required")
+	}
+
+	comments, err := GetComments(p)
+	if err != nil {
+		return Comment{}, err
+	}
+
+	// Comment IDs are numbered separately from entity IDs, so deleting
+	// or resolving comments never affects NextID.
+	max := 0
+	for _, c := range comments {
+		var n int
+		if _, err := fmt.Sscanf(c.ID, "CMT-%d", &n); err == nil && n > max {
+			max = n
+		}
+	}
+
+	c := Comment{
+		ID:        fmt.Sprintf("CMT-%d", max+1),
+		Author:    author,
+		Body:      body,
+		Target:    strings.TrimSpace(target),
+		CreatedAt: time.Now().UTC(),
+	}
+	SetComments(p, append(comments, c))
+	return c, nil
+}
+
+// ResolveComment marks a comment as resolved, or reopens it.
+func ResolveComment(p *PRD, id string, resolved bool) (Comment, error) {
+	comments, err := GetComments(p)
+	if err != nil {
+		return Comment{}, err
+	}
+	for i := range comments {
+		if comments[i].ID == id {
+			comments[i].Resolved = resolved
+			SetComments(p, comments)
+			return comments[i], nil
+		}
+	}
+	return Comment{}, fmt.Errorf("comment %s: %w", id, ErrEntityNotFound)
+}
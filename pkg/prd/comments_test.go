@@ -0,0 +1,69 @@
+package prd
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestAddComment(t *testing.T) {
+	p := New("PRD-2026-001", "Test PRD", Person{Name: "Owner"})
+
+	c1, err := AddComment(p, "Alice", "Is the target realistic?", "NFR-1")
+	if err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	if c1.ID != "CMT-1" || c1.Target != "NFR-1" || c1.CreatedAt.IsZero() {
+		t.Errorf("unexpected comment: %+v", c1)
+	}
+	c2, _ := AddComment(p, "Bob", "Looks good", "")
+	if c2.ID != "CMT-2" {
+		t.Errorf("expected CMT-2, got %s", c2.ID)
+	}
+
+	if _, err := AddComment(p, "", "text", ""); err == nil {
+		t.Error("expected error for missing author")
+	}
+	if _, err := AddComment(p, "Alice", "  ", ""); err == nil {
+		t.Error("expected error for empty body")
+	}
+
+	// Comments survive a JSON round trip, as when the PRD is saved and
+	// loaded again.
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var loaded PRD
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	comments, err := GetComments(&loaded)
+	if err != nil {
+		t.Fatalf("GetComments failed: %v", err)
+	}
+	if len(comments) != 2 || comments[0].Body != "Is the target realistic?" {
+		t.Errorf("unexpected comments after round trip: %+v", comments)
+	}
+}
+
+func TestResolveComment(t *testing.T) {
+	p := New("PRD-2026-001", "Test PRD", Person{Name: "Owner"})
+	c, _ := AddComment(p, "Alice", "Missing baseline", "KR-1")
+
+	resolved, err := ResolveComment(p, c.ID, true)
+	if err != nil {
+		t.Fatalf("ResolveComment failed: %v", err)
+	}
+	if !resolved.Resolved {
+		t.Error("expected comment to be resolved")
+	}
+	comments, _ := GetComments(p)
+	if !comments[0].Resolved {
+		t.Error("expected resolution to be stored")
+	}
+
+	if _, err := ResolveComment(p, "CMT-9", true); !errors.Is(err, ErrEntityNotFound) {
+		t.Errorf("expected ErrEntityNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package prd
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Custom section IDs used by agent-team-prd to store data that has no
+// dedicated field in the canonical PRD schema.
+const (
+	SectionTestCoverage = "test-coverage"
+	SectionDependencies = "dependencies"
+	SectionComments     = "comments"
+	SectionSignOffs     = "sign-offs"
+	SectionReview       = "review"
+)
+
+// GetCustomSection returns the custom section with the given ID, or nil if
+// the PRD has no such section.
+func GetCustomSection(p *PRD, id string) *CustomSection {
+	for i := range p.CustomSections {
+		if p.CustomSections[i].ID == id {
+			return &p.CustomSections[i]
+		}
+	}
+	return nil
+}
+
+// SetCustomSection creates or replaces the custom section with the given ID.
+func SetCustomSection(p *PRD, id, title string, content interface{}) {
+	if cs := GetCustomSection(p, id); cs != nil {
+		cs.Title = title
+		cs.Content = content
+		return
+	}
+	p.CustomSections = append(p.CustomSections, CustomSection{
+		ID:      id,
+		Title:   title,
+		Content: content,
+	})
+}
+
+// RemoveCustomSection removes the custom section with the given ID.
+// Returns false if the section was not found.
+func RemoveCustomSection(p *PRD, id string) bool {
+	for i, cs := range p.CustomSections {
+		if cs.ID == id {
+			p.CustomSections = append(p.CustomSections[:i], p.CustomSections[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// DecodeCustomSection decodes the content of the custom section with the
+// given ID into v. Content loaded from disk is untyped, so it is round-tripped
+// through JSON. Returns false if the section does not exist.
+func DecodeCustomSection(p *PRD, id string, v interface{}) (bool, error) {
+	cs := GetCustomSection(p, id)
+	if cs == nil || cs.Content == nil {
+		return false, nil
+	}
+	data, err := json.Marshal(cs.Content)
+	if err != nil {
+		return true, fmt.Errorf("failed to encode custom section %s: %w", id, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return true, fmt.Errorf("failed to decode custom section %s: %w", id, err)
+	}
+	return true, nil
+}
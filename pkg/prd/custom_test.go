@@ -0,0 +1,37 @@
+package prd
+
+import (
+	"testing"
+)
+
+func TestCustomSections(t *testing.T) {
+	p := New("PRD-2026-001", "Test PRD", Person{Name: "Owner"})
+
+	if GetCustomSection(p, "notes") != nil {
+		t.Fatal("expected no custom section")
+	}
+
+	SetCustomSection(p, "notes", "Notes", map[string]interface{}{"count": 1})
+	SetCustomSection(p, "notes", "Notes", map[string]interface{}{"count": 2})
+	if len(p.CustomSections) != 1 {
+		t.Fatalf("expected 1 custom section, got %d", len(p.CustomSections))
+	}
+
+	var content struct {
+		Count int `json:"count"`
+	}
+	found, err := DecodeCustomSection(p, "notes", &content)
+	if err != nil || !found {
+		t.Fatalf("expected section to decode, got found=%v err=%v", found, err)
+	}
+	if content.Count != 2 {
+		t.Errorf("expected count 2, got %d", content.Count)
+	}
+
+	if !RemoveCustomSection(p, "notes") {
+		t.Error("expected section to be removed")
+	}
+	if RemoveCustomSection(p, "notes") {
+		t.Error("expected second remove to fail")
+	}
+}
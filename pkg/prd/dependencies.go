@@ -0,0 +1,165 @@
+package prd
+
+import (
+	"fmt"
+)
+
+// Dependencies records links from this PRD to other PRDs. They are stored in
+// the "dependencies" custom section.
+type Dependencies struct {
+	// Documents lists PRDs this whole document depends on.
+	Documents []DocumentDependency `json:"documents,omitempty"`
+	// Requirements lists requirements that depend on requirements in other PRDs.
+	Requirements []RequirementDependency `json:"requirements,omitempty"`
+}
+
+// DocumentDependency is a document-level dependency on another PRD.
+type DocumentDependency struct {
+	PRDID  string `json:"prdId"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// RequirementDependency links a local requirement to a requirement in another PRD.
+type RequirementDependency struct {
+	RequirementID         string `json:"requirementId"`
+	PRDID                 string `json:"prdId"`
+	ExternalRequirementID string `json:"externalRequirementId"`
+	Reason                string `json:"reason,omitempty"`
+}
+
+// IsEmpty reports whether there are no dependencies.
+func (d *Dependencies) IsEmpty() bool {
+	return len(d.Documents) == 0 && len(d.Requirements) == 0
+}
+
+// GetDependencies returns the dependencies recorded in the PRD.
+// A PRD without a dependencies section returns an empty value.
+func GetDependencies(p *PRD) (*Dependencies, error) {
+	deps := &Dependencies{}
+	if _, err := DecodeCustomSection(p, SectionDependencies, deps); err != nil {
+		return nil, err
+	}
+	return deps, nil
+}
+
+// SetDependencies stores dependencies in the PRD, removing the section when empty.
+func SetDependencies(p *PRD, deps *Dependencies) {
+	if deps == nil || deps.IsEmpty() {
+		RemoveCustomSection(p, SectionDependencies)
+		return
+	}
+	SetCustomSection(p, SectionDependencies, "Dependencies", deps)
+}
+
+// AddDocumentDependency records that this PRD depends on another PRD.
+// Adding an existing dependency updates its reason.
+func AddDocumentDependency(p *PRD, prdID, reason string) error {
+	if prdID == "" {
+		return fmt.Errorf("PRD ID is required")
+	}
+	if prdID == p.Metadata.ID {
+		return fmt.Errorf("PRD %s cannot depend on itself", prdID)
+	}
+
+	deps, err := GetDependencies(p)
+	if err != nil {
+		return err
+	}
+
+	for i, d := range deps.Documents {
+		if d.PRDID == prdID {
+			deps.Documents[i].Reason = reason
+			SetDependencies(p, deps)
+			return nil
+		}
+	}
+
+	deps.Documents = append(deps.Documents, DocumentDependency{PRDID: prdID, Reason: reason})
+	SetDependencies(p, deps)
+	return nil
+}
+
+// AddRequirementDependency records that a local requirement depends on a
+// requirement in another PRD. The local requirement must exist.
+func AddRequirementDependency(p *PRD, requirementID, prdID, externalRequirementID, reason string) error {
+	if prdID == "" || externalRequirementID == "" {
+		return fmt.Errorf("PRD ID and external requirement ID are required")
+	}
+	if prdID == p.Metadata.ID {
+		return fmt.Errorf("requirement %s cannot depend on its own PRD %s", requirementID, prdID)
+	}
+	if !HasRequirement(p, requirementID) {
+		return fmt.Errorf("requirement not found: %s", requirementID)
+	}
+
+	deps, err := GetDependencies(p)
+	if err != nil {
+		return err
+	}
+
+	for i, d := range deps.Requirements {
+		if d.RequirementID == requirementID && d.PRDID == prdID && d.ExternalRequirementID == externalRequirementID {
+			deps.Requirements[i].Reason = reason
+			SetDependencies(p, deps)
+			return nil
+		}
+	}
+
+	deps.Requirements = append(deps.Requirements, RequirementDependency{
+		RequirementID:         requirementID,
+		PRDID:                 prdID,
+		ExternalRequirementID: externalRequirementID,
+		Reason:                reason,
+	})
+	SetDependencies(p, deps)
+	return nil
+}
+
+// RemoveDependencies removes all document and requirement dependencies on
+// the given PRD. Returns the number of links removed.
+func RemoveDependencies(p *PRD, prdID string) (int, error) {
+	deps, err := GetDependencies(p)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	docs := deps.Documents[:0]
+	for _, d := range deps.Documents {
+		if d.PRDID == prdID {
+			removed++
+			continue
+		}
+		docs = append(docs, d)
+	}
+	deps.Documents = docs
+
+	reqs := deps.Requirements[:0]
+	for _, d := range deps.Requirements {
+		if d.PRDID == prdID {
+			removed++
+			continue
+		}
+		reqs = append(reqs, d)
+	}
+	deps.Requirements = reqs
+
+	SetDependencies(p, deps)
+	return removed, nil
+}
+
+// HasRequirement reports whether the PRD has a functional or non-functional
+// requirement with the given ID.
+func HasRequirement(p *PRD, id string) bool {
+	for _, r := range p.Requirements.Functional {
+		if r.ID == id {
+			return true
+		}
+	}
+	for _, r := range p.Requirements.NonFunctional {
+		if r.ID == id {
+			return true
+		}
+	}
+	return false
+}
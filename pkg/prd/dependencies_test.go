@@ -0,0 +1,53 @@
+package prd
+
+import (
+	"testing"
+)
+
+func TestAddDocumentDependency(t *testing.T) {
+	p := New("PRD-2026-002", "Feature PRD", Person{Name: "Owner"})
+
+	if err := AddDocumentDependency(p, "PRD-2026-001", "Needs auth"); err != nil {
+		t.Fatalf("AddDocumentDependency failed: %v", err)
+	}
+	if err := AddDocumentDependency(p, "PRD-2026-001", "Needs auth tokens"); err != nil {
+		t.Fatalf("AddDocumentDependency failed: %v", err)
+	}
+	if err := AddDocumentDependency(p, "PRD-2026-002", ""); err == nil {
+		t.Error("expected error for self-dependency")
+	}
+
+	deps, err := GetDependencies(p)
+	if err != nil {
+		t.Fatalf("GetDependencies failed: %v", err)
+	}
+	if len(deps.Documents) != 1 {
+		t.Fatalf("expected 1 dependency, got %d", len(deps.Documents))
+	}
+	if deps.Documents[0].Reason != "Needs auth tokens" {
+		t.Errorf("expected updated reason, got %s", deps.Documents[0].Reason)
+	}
+}
+
+func TestAddRequirementDependency(t *testing.T) {
+	p := New("PRD-2026-002", "Feature PRD", Person{Name: "Owner"})
+	reqID := AddFunctionalRequirement(p, "Login", "Users can log in", MoSCoWMust)
+
+	if err := AddRequirementDependency(p, reqID, "PRD-2026-001", "FR-3", ""); err != nil {
+		t.Fatalf("AddRequirementDependency failed: %v", err)
+	}
+	if err := AddRequirementDependency(p, "FR-99", "PRD-2026-001", "FR-3", ""); err == nil {
+		t.Error("expected error for unknown local requirement")
+	}
+
+	removed, err := RemoveDependencies(p, "PRD-2026-001")
+	if err != nil {
+		t.Fatalf("RemoveDependencies failed: %v", err)
+	}
+	if removed != 1 {
+		t.Errorf("expected 1 removed dependency, got %d", removed)
+	}
+	if GetCustomSection(p, SectionDependencies) != nil {
+		t.Error("expected empty dependencies section to be removed")
+	}
+}
@@ -0,0 +1,169 @@
+package prd
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// ChangeType describes how a field differs between two PRDs.
+type ChangeType string
+
+const (
+	ChangeAdded    ChangeType = "added"
+	ChangeRemoved  ChangeType = "removed"
+	ChangeModified ChangeType = "modified"
+)
+
+// Change is a single field-level difference between two PRDs.
+//
+// Path uses the dotted field names of ValidationError. Elements of lists
+// whose entries carry an ID are addressed by that ID, e.g.
+// "requirements.functional[FR-3].priority"; other list elements by index.
+type Change struct {
+	Path   string      `json:"path"`
+	Type   ChangeType  `json:"type"`
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+// Diff returns the semantic differences between two PRDs, ordered by path.
+// A nil before is treated as an empty document, so every field of after is
+// reported as added.
+func Diff(before, after *PRD) ([]Change, error) {
+	a, err := diffDocument(before)
+	if err != nil {
+		return nil, err
+	}
+	b, err := diffDocument(after)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+	diffValues("", a, b, &changes)
+	return changes, nil
+}
+
+func diffDocument(p *PRD) (interface{}, error) {
+	if p == nil {
+		return map[string]interface{}{}, nil
+	}
+	return toDocument(p)
+}
+
+func diffValues(path string, a, b interface{}, changes *[]Change) {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		if bv, ok := b.(map[string]interface{}); ok {
+			diffMaps(path, av, bv, changes)
+			return
+		}
+	case []interface{}:
+		if bv, ok := b.([]interface{}); ok {
+			diffLists(path, av, bv, changes)
+			return
+		}
+	}
+	if !reflect.DeepEqual(a, b) {
+		*changes = append(*changes, Change{Path: path, Type: ChangeModified, Before: a, After: b})
+	}
+}
+
+func diffMaps(path string, a, b map[string]interface{}, changes *[]Change) {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		av, inA := a[k]
+		bv, inB := b[k]
+		diffEntry(joinPath(path, k), av, inA, bv, inB, changes)
+	}
+}
+
+func diffLists(path string, a, b []interface{}, changes *[]Change) {
+	aIDs, bIDs := elementIDs(a), elementIDs(b)
+	if aIDs == nil || bIDs == nil {
+		for i := 0; i < len(a) || i < len(b); i++ {
+			elemPath := path + "[" + strconv.Itoa(i) + "]"
+			diffEntry(elemPath, elementAt(a, i), i < len(a), elementAt(b, i), i < len(b), changes)
+		}
+		return
+	}
+
+	// Match elements by ID so inserting or removing an entry doesn't show
+	// every later entry as modified.
+	seen := make(map[string]bool, len(b))
+	for i, id := range aIDs {
+		j, inB := indexOf(bIDs, id)
+		diffEntry(path+"["+id+"]", a[i], true, elementAt(b, j), inB, changes)
+		seen[id] = true
+	}
+	for j, id := range bIDs {
+		if !seen[id] {
+			diffEntry(path+"["+id+"]", nil, false, b[j], true, changes)
+		}
+	}
+}
+
+func diffEntry(path string, a interface{}, inA bool, b interface{}, inB bool, changes *[]Change) {
+	switch {
+	case inA && !inB:
+		*changes = append(*changes, Change{Path: path, Type: ChangeRemoved, Before: a})
+	case !inA && inB:
+		*changes = append(*changes, Change{Path: path, Type: ChangeAdded, After: b})
+	default:
+		diffValues(path, a, b, changes)
+	}
+}
+
+// elementIDs returns the "id" field of every element, or nil if any element
+// has no unique, non-empty ID.
+func elementIDs(list []interface{}) []string {
+	ids := make([]string, len(list))
+	seen := make(map[string]bool, len(list))
+	for i, v := range list {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		id, _ := m["id"].(string)
+		if id == "" || seen[id] {
+			return nil
+		}
+		ids[i] = id
+		seen[id] = true
+	}
+	return ids
+}
+
+func indexOf(ids []string, id string) (int, bool) {
+	for i, v := range ids {
+		if v == id {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+func elementAt(list []interface{}, i int) interface{} {
+	if i < 0 || i >= len(list) {
+		return nil
+	}
+	return list[i]
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,78 @@
+package prd
+
+import (
+	"testing"
+)
+
+func findChange(changes []Change, path string) *Change {
+	for i := range changes {
+		if changes[i].Path == path {
+			return &changes[i]
+		}
+	}
+	return nil
+}
+
+func TestDiff(t *testing.T) {
+	before := New("PRD-2026-001", "Diff Test PRD", Person{Name: "Owner"})
+	AddPersona(before, "Developer Dan", "Backend Developer", []string{"Slow builds"})
+	AddPersona(before, "Ops Olivia", "SRE", nil)
+
+	after, err := ApplyJSONPatch(before, []PatchOperation{
+		{Op: "replace", Path: "/metadata/title", Value: "Diff Test PRD v2"},
+		{Op: "remove", Path: "/personas/0"},
+		{Op: "add", Path: "/personas/0/painPoints", Value: []string{"Pager fatigue"}},
+	})
+	if err != nil {
+		t.Fatalf("ApplyJSONPatch failed: %v", err)
+	}
+
+	changes, err := Diff(before, after)
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+
+	title := findChange(changes, "metadata.title")
+	if title == nil || title.Type != ChangeModified || title.Before != "Diff Test PRD" || title.After != "Diff Test PRD v2" {
+		t.Errorf("expected modified metadata.title, got %+v", title)
+	}
+
+	dan := before.Personas[0].ID
+	if c := findChange(changes, "personas["+dan+"]"); c == nil || c.Type != ChangeRemoved {
+		t.Errorf("expected removed persona %s, got %+v", dan, c)
+	}
+
+	// The remaining persona is matched by ID, not reported as modified.
+	olivia := before.Personas[1].ID
+	if c := findChange(changes, "personas["+olivia+"].painPoints"); c == nil || c.Type != ChangeAdded {
+		t.Errorf("expected added painPoints on %s, got %+v", olivia, c)
+	}
+	if c := findChange(changes, "personas["+olivia+"].name"); c != nil {
+		t.Errorf("expected unchanged persona name, got %+v", c)
+	}
+}
+
+func TestDiffIdentical(t *testing.T) {
+	p := New("PRD-2026-001", "Diff Test PRD", Person{Name: "Owner"})
+
+	changes, err := Diff(p, p)
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("expected no changes, got %v", changes)
+	}
+}
+
+func TestDiffNilBefore(t *testing.T) {
+	p := New("PRD-2026-001", "Diff Test PRD", Person{Name: "Owner"})
+
+	changes, err := Diff(nil, p)
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+	c := findChange(changes, "metadata")
+	if c == nil || c.Type != ChangeAdded {
+		t.Errorf("expected added metadata, got %+v", c)
+	}
+}
@@ -0,0 +1,933 @@
+package prd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrEntityNotFound is returned when an entity ID does not exist in a PRD.
+var ErrEntityNotFound = errors.New("entity not found")
+
+// EntityField describes one editable field of an entity kind. Values are
+// exchanged as strings: list fields are comma-separated and boolean
+// fields are "true" or "false".
+type EntityField struct {
+	Name     string   `json:"name"`
+	Label    string   `json:"label"`
+	Required bool     `json:"required,omitempty"`
+	List     bool     `json:"list,omitempty"`
+	Number   bool     `json:"number,omitempty"`
+	Choices  []string `json:"choices,omitempty"`
+}
+
+// Entity is a PRD entity flattened to its editable field values.
+type Entity struct {
+	ID     string            `json:"id"`
+	Label  string            `json:"label"`
+	Values map[string]string `json:"values"`
+}
+
+// EntityKind is a type of PRD entity, such as personas or risks, with
+// generic create, read, update and delete operations. Creation goes
+// through the same operations as 'prdtool add', so IDs and defaults match.
+type EntityKind struct {
+	Name   string        `json:"name"`
+	Title  string        `json:"title"`
+	Fields []EntityField `json:"fields"`
+
+	// Singleton kinds, such as the problem definition, hold at most one
+	// entity.
+	Singleton bool `json:"singleton,omitempty"`
+
+	store entityStore
+}
+
+// entityStore reads and writes the entities of one kind. Values passed to
+// create and update are already checked against the fields, and complete
+// except that update leaves out list fields that are not changing: those
+// keep their stored items (see setList).
+type entityStore interface {
+	list(p *PRD) []map[string]string
+	create(p *PRD, values map[string]string) (string, error)
+	update(p *PRD, id string, values map[string]string) bool
+	remove(p *PRD, id string) bool
+}
+
+// EntityKinds returns every editable entity kind in document order.
+func EntityKinds() []*EntityKind {
+	return entityKinds
+}
+
+// LookupEntityKind returns the entity kind with the given name.
+func LookupEntityKind(name string) (*EntityKind, bool) {
+	for _, k := range entityKinds {
+		if k.Name == name {
+			return k, true
+		}
+	}
+	return nil, false
+}
+
+// Field returns the named field.
+func (k *EntityKind) Field(name string) (EntityField, bool) {
+	for _, f := range k.Fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return EntityField{}, false
+}
+
+// List returns the kind's entities in document order.
+func (k *EntityKind) List(p *PRD) []Entity {
+	rows := k.store.list(p)
+	entities := make([]Entity, 0, len(rows))
+	for _, values := range rows {
+		id := values["id"]
+		delete(values, "id")
+		entities = append(entities, Entity{ID: id, Label: values[k.Fields[0].Name], Values: values})
+	}
+	return entities
+}
+
+// Get returns the entity with the given ID.
+func (k *EntityKind) Get(p *PRD, id string) (Entity, error) {
+	for _, e := range k.List(p) {
+		if e.ID == id {
+			return e, nil
+		}
+	}
+	return Entity{}, fmt.Errorf("%s %s: %w", k.Name, id, ErrEntityNotFound)
+}
+
+// Create adds an entity and returns its ID. Fields missing from values
+// are left empty; required fields must be given.
+func (k *EntityKind) Create(p *PRD, values map[string]string) (string, error) {
+	if k.Singleton && len(k.store.list(p)) > 0 {
+		return "", fmt.Errorf("%s already exists", k.Name)
+	}
+	merged, err := k.merge(nil, values)
+	if err != nil {
+		return "", err
+	}
+	return k.store.create(p, merged)
+}
+
+// Update changes the given fields of an entity; fields missing from
+// values keep their current value. List fields are only split again when
+// their value changes, so unchanged items that contain commas, and the IDs
+// of acceptance criteria, are kept.
+func (k *EntityKind) Update(p *PRD, id string, values map[string]string) error {
+	current, err := k.Get(p, id)
+	if err != nil {
+		return err
+	}
+	merged, err := k.merge(current.Values, values)
+	if err != nil {
+		return err
+	}
+	for _, f := range k.Fields {
+		if v, ok := values[f.Name]; f.List && (!ok || strings.TrimSpace(v) == current.Values[f.Name]) {
+			delete(merged, f.Name)
+		}
+	}
+	if !k.store.update(p, id, merged) {
+		return fmt.Errorf("%s %s: %w", k.Name, id, ErrEntityNotFound)
+	}
+	return nil
+}
+
+// Delete removes an entity.
+func (k *EntityKind) Delete(p *PRD, id string) error {
+	if !k.store.remove(p, id) {
+		return fmt.Errorf("%s %s: %w", k.Name, id, ErrEntityNotFound)
+	}
+	return nil
+}
+
+// merge overlays values on current and checks the result against the
+// kind's fields.
+func (k *EntityKind) merge(current, values map[string]string) (map[string]string, error) {
+	merged := make(map[string]string, len(k.Fields))
+	for _, f := range k.Fields {
+		merged[f.Name] = current[f.Name]
+	}
+	for name, v := range values {
+		f, ok := k.Field(name)
+		if !ok {
+			return nil, fmt.Errorf("%s has no field %q", k.Name, name)
+		}
+		v = strings.TrimSpace(v)
+		if v != "" && len(f.Choices) > 0 && !containsString(f.Choices, v) {
+			return nil, fmt.Errorf("%s must be one of: %s", f.Label, strings.Join(f.Choices, ", "))
+		}
+		if v != "" && f.Number {
+			if _, err := strconv.ParseFloat(v, 64); err != nil {
+				return nil, fmt.Errorf("%s must be a number", f.Label)
+			}
+		}
+		merged[name] = v
+	}
+	for _, f := range k.Fields {
+		if f.Required && merged[f.Name] == "" {
+			return nil, fmt.Errorf("%s is required", f.Label)
+		}
+	}
+	return merged, nil
+}
+
+// sliceStore stores entities in a slice of T that has an ID field.
+type sliceStore[T any] struct {
+	// items returns the slice, or nil if its parent section is absent.
+	items func(p *PRD) *[]T
+	id    func(t *T) string
+	get   func(t *T) map[string]string
+	set   func(p *PRD, t *T, values map[string]string)
+
+	// add creates an entity with the existing operations and returns its
+	// ID; set then fills in the remaining fields.
+	add func(p *PRD, values map[string]string) string
+
+	// removed is called after an entity is deleted, to clear references.
+	removed func(p *PRD, id string)
+}
+
+func (s sliceStore[T]) list(p *PRD) []map[string]string {
+	items := s.items(p)
+	if items == nil {
+		return nil
+	}
+	rows := make([]map[string]string, 0, len(*items))
+	for i := range *items {
+		values := s.get(&(*items)[i])
+		values["id"] = s.id(&(*items)[i])
+		rows = append(rows, values)
+	}
+	return rows
+}
+
+func (s sliceStore[T]) create(p *PRD, values map[string]string) (string, error) {
+	id := s.add(p, values)
+	s.update(p, id, values)
+	return id, nil
+}
+
+func (s sliceStore[T]) find(p *PRD, id string) (*[]T, int) {
+	items := s.items(p)
+	if items == nil {
+		return nil, -1
+	}
+	for i := range *items {
+		if s.id(&(*items)[i]) == id {
+			return items, i
+		}
+	}
+	return items, -1
+}
+
+func (s sliceStore[T]) update(p *PRD, id string, values map[string]string) bool {
+	items, i := s.find(p, id)
+	if i < 0 {
+		return false
+	}
+	s.set(p, &(*items)[i], values)
+	return true
+}
+
+func (s sliceStore[T]) remove(p *PRD, id string) bool {
+	items, i := s.find(p, id)
+	if i < 0 {
+		return false
+	}
+	*items = append((*items)[:i], (*items)[i+1:]...)
+	if s.removed != nil {
+		s.removed(p, id)
+	}
+	return true
+}
+
+// textStore stores entities in a slice of strings without IDs, such as
+// non-goals. The ID is the 1-based position.
+type textStore struct {
+	items func(p *PRD) *[]string
+	field string
+}
+
+func (s textStore) list(p *PRD) []map[string]string {
+	items := s.items(p)
+	if items == nil {
+		return nil
+	}
+	rows := make([]map[string]string, 0, len(*items))
+	for i, item := range *items {
+		rows = append(rows, map[string]string{"id": strconv.Itoa(i + 1), s.field: item})
+	}
+	return rows
+}
+
+func (s textStore) create(p *PRD, values map[string]string) (string, error) {
+	items := s.items(p)
+	*items = append(*items, values[s.field])
+	return strconv.Itoa(len(*items)), nil
+}
+
+func (s textStore) index(p *PRD, id string) (*[]string, int) {
+	items := s.items(p)
+	i, err := strconv.Atoi(id)
+	if items == nil || err != nil || i < 1 || i > len(*items) {
+		return nil, -1
+	}
+	return items, i - 1
+}
+
+func (s textStore) update(p *PRD, id string, values map[string]string) bool {
+	items, i := s.index(p, id)
+	if i < 0 {
+		return false
+	}
+	(*items)[i] = values[s.field]
+	return true
+}
+
+func (s textStore) remove(p *PRD, id string) bool {
+	items, i := s.index(p, id)
+	if i < 0 {
+		return false
+	}
+	*items = append((*items)[:i], (*items)[i+1:]...)
+	return true
+}
+
+// problemStore stores the primary problem definition.
+type problemStore struct{}
+
+func (problemStore) list(p *PRD) []map[string]string {
+	if p.Problem == nil {
+		return nil
+	}
+	confidence := ""
+	if p.Problem.Confidence != 0 {
+		confidence = strconv.FormatFloat(p.Problem.Confidence, 'f', -1, 64)
+	}
+	return []map[string]string{{
+		"id":         p.Problem.ID,
+		"statement":  p.Problem.Statement,
+		"impact":     p.Problem.UserImpact,
+		"confidence": confidence,
+		"rootCauses": joinList(p.Problem.RootCauses),
+	}}
+}
+
+func (s problemStore) create(p *PRD, values map[string]string) (string, error) {
+	SetProblemStatement(p, values["statement"], values["impact"], 0)
+	s.update(p, p.Problem.ID, values)
+	return p.Problem.ID, nil
+}
+
+func (problemStore) update(p *PRD, id string, values map[string]string) bool {
+	if p.Problem == nil || p.Problem.ID != id {
+		return false
+	}
+	// Confidence has already been checked to be a number.
+	confidence, _ := strconv.ParseFloat(values["confidence"], 64)
+	SetProblemStatement(p, values["statement"], values["impact"], confidence)
+	setList(&p.Problem.RootCauses, values, "rootCauses")
+	return true
+}
+
+func (problemStore) remove(p *PRD, id string) bool {
+	if p.Problem == nil || p.Problem.ID != id {
+		return false
+	}
+	p.Problem = nil
+	p.ExecutiveSummary.ProblemStatement = ""
+	return true
+}
+
+// keyResultStore stores success metrics, which are key results spread
+// over every OKR. Both the OKR and the objective key result lists are
+// searched, as in NextID.
+type keyResultStore struct{}
+
+func (keyResultStore) each(p *PRD, fn func(items *[]KeyResult) bool) {
+	for i := range p.Objectives.OKRs {
+		okr := &p.Objectives.OKRs[i]
+		if !fn(&okr.KeyResults) || !fn(&okr.Objective.KeyResults) {
+			return
+		}
+	}
+}
+
+func (s keyResultStore) list(p *PRD) []map[string]string {
+	var rows []map[string]string
+	s.each(p, func(items *[]KeyResult) bool {
+		for _, kr := range *items {
+			rows = append(rows, map[string]string{
+				"id":          kr.ID,
+				"title":       kr.Title,
+				"description": kr.Description,
+				"baseline":    kr.Baseline,
+				"target":      kr.Target,
+			})
+		}
+		return true
+	})
+	return rows
+}
+
+func (s keyResultStore) create(p *PRD, values map[string]string) (string, error) {
+	id := AddSuccessMetric(p, values["title"], values["description"], values["target"])
+	s.update(p, id, values)
+	return id, nil
+}
+
+func (s keyResultStore) update(p *PRD, id string, values map[string]string) bool {
+	found := false
+	s.each(p, func(items *[]KeyResult) bool {
+		for i := range *items {
+			if kr := &(*items)[i]; kr.ID == id {
+				kr.Title = values["title"]
+				kr.Description = values["description"]
+				kr.Baseline = values["baseline"]
+				kr.Target = values["target"]
+				found = true
+				return false
+			}
+		}
+		return true
+	})
+	return found
+}
+
+func (s keyResultStore) remove(p *PRD, id string) bool {
+	found := false
+	s.each(p, func(items *[]KeyResult) bool {
+		for i := range *items {
+			if (*items)[i].ID == id {
+				*items = append((*items)[:i], (*items)[i+1:]...)
+				found = true
+				return false
+			}
+		}
+		return true
+	})
+	return found
+}
+
+var (
+	moscowChoices      = MoSCoWValues()
+	probabilityChoices = RiskProbabilityValues()
+	impactChoices      = RiskImpactValues()
+	booleanChoices     = []string{"true", "false"}
+	nfrChoices         = NFRCategoryValues()
+	evidenceChoices    = []string{"interview", "survey", "analytics", "support_ticket", "market_research", "assumption"}
+	strengthChoices    = []string{"low", "medium", "high"}
+	alternativeChoices = []string{"competitor", "workaround", "do_nothing", "internal_tool"}
+	decisionChoices    = []string{"proposed", "accepted", "superseded", "deprecated"}
+)
+
+var entityKinds = []*EntityKind{
+	{
+		Name:      "problem",
+		Title:     "Problem",
+		Singleton: true,
+		Fields: []EntityField{
+			{Name: "statement", Label: "Statement", Required: true},
+			{Name: "impact", Label: "User impact"},
+			{Name: "confidence", Label: "Confidence (0-1)", Number: true},
+			{Name: "rootCauses", Label: "Root causes", List: true},
+		},
+		store: problemStore{},
+	},
+	{
+		Name:  "evidence",
+		Title: "Evidence",
+		Fields: []EntityField{
+			{Name: "summary", Label: "Summary", Required: true},
+			{Name: "type", Label: "Type", Choices: evidenceChoices},
+			{Name: "source", Label: "Source"},
+			{Name: "strength", Label: "Strength", Choices: strengthChoices},
+		},
+		store: evidenceStore{},
+	},
+	{
+		Name:  "personas",
+		Title: "Personas",
+		Fields: []EntityField{
+			{Name: "name", Label: "Name", Required: true},
+			{Name: "role", Label: "Role"},
+			{Name: "description", Label: "Description"},
+			{Name: "painPoints", Label: "Pain points", List: true},
+			{Name: "goals", Label: "Goals", List: true},
+			{Name: "primary", Label: "Primary", Choices: booleanChoices},
+		},
+		store: sliceStore[Persona]{
+			items: func(p *PRD) *[]Persona { return &p.Personas },
+			id:    func(t *Persona) string { return t.ID },
+			get: func(t *Persona) map[string]string {
+				return map[string]string{
+					"name":        t.Name,
+					"role":        t.Role,
+					"description": t.Description,
+					"painPoints":  joinList(t.PainPoints),
+					"goals":       joinList(t.Goals),
+					"primary":     strconv.FormatBool(t.IsPrimary),
+				}
+			},
+			set: func(p *PRD, t *Persona, v map[string]string) {
+				t.Name = v["name"]
+				t.Role = v["role"]
+				t.Description = v["description"]
+				setList(&t.PainPoints, v, "painPoints")
+				setList(&t.Goals, v, "goals")
+				// Only one persona is primary.
+				if v["primary"] == "true" && !t.IsPrimary {
+					for i := range p.Personas {
+						p.Personas[i].IsPrimary = false
+					}
+				}
+				t.IsPrimary = v["primary"] == "true"
+			},
+			add: func(p *PRD, v map[string]string) string {
+				id := AddPersona(p, v["name"], v["role"], nil)
+				if v["primary"] == "" && len(p.Personas) == 1 {
+					v["primary"] = "true"
+				}
+				return id
+			},
+		},
+	},
+	{
+		Name:  "objectives",
+		Title: "Objectives",
+		Fields: []EntityField{
+			{Name: "title", Label: "Title", Required: true},
+			{Name: "description", Label: "Description"},
+		},
+		store: sliceStore[OKR]{
+			items: func(p *PRD) *[]OKR { return &p.Objectives.OKRs },
+			id:    func(t *OKR) string { return t.Objective.ID },
+			get: func(t *OKR) map[string]string {
+				return map[string]string{"title": t.Objective.Title, "description": t.Objective.Description}
+			},
+			set: func(_ *PRD, t *OKR, v map[string]string) {
+				t.Objective.Title = v["title"]
+				t.Objective.Description = v["description"]
+			},
+			add: func(p *PRD, v map[string]string) string {
+				return AddObjective(p, v["title"], v["description"])
+			},
+		},
+	},
+	{
+		Name:  "metrics",
+		Title: "Success Metrics",
+		Fields: []EntityField{
+			{Name: "title", Label: "Title", Required: true},
+			{Name: "description", Label: "Description"},
+			{Name: "baseline", Label: "Baseline"},
+			{Name: "target", Label: "Target"},
+		},
+		store: keyResultStore{},
+	},
+	{
+		Name:  "stories",
+		Title: "User Stories",
+		Fields: []EntityField{
+			{Name: "title", Label: "Title", Required: true},
+			{Name: "personaId", Label: "Persona ID"},
+			{Name: "story", Label: "Story"},
+			{Name: "acceptanceCriteria", Label: "Acceptance criteria", List: true},
+		},
+		store: sliceStore[UserStory]{
+			items: func(p *PRD) *[]UserStory { return &p.UserStories },
+			id:    func(t *UserStory) string { return t.ID },
+			get: func(t *UserStory) map[string]string {
+				return map[string]string{
+					"title":              t.Title,
+					"personaId":          t.PersonaID,
+					"story":              t.Story,
+					"acceptanceCriteria": joinCriteria(t.AcceptanceCriteria),
+				}
+			},
+			set: func(_ *PRD, t *UserStory, v map[string]string) {
+				t.Title = v["title"]
+				t.PersonaID = v["personaId"]
+				t.Story = v["story"]
+				if value, ok := v["acceptanceCriteria"]; ok {
+					t.AcceptanceCriteria = splitCriteria(t.ID, t.AcceptanceCriteria, value)
+				}
+			},
+			add: func(p *PRD, v map[string]string) string {
+				id := NextID(p, "US")
+				p.UserStories = append(p.UserStories, UserStory{ID: id, Title: v["title"]})
+				return id
+			},
+		},
+	},
+	{
+		Name:  "requirements",
+		Title: "Functional Requirements",
+		Fields: []EntityField{
+			{Name: "title", Label: "Title", Required: true},
+			{Name: "description", Label: "Description"},
+			{Name: "priority", Label: "Priority", Choices: moscowChoices},
+			{Name: "phaseId", Label: "Phase ID"},
+			{Name: "acceptanceCriteria", Label: "Acceptance criteria", List: true},
+		},
+		store: sliceStore[FunctionalRequirement]{
+			items: func(p *PRD) *[]FunctionalRequirement { return &p.Requirements.Functional },
+			id:    func(t *FunctionalRequirement) string { return t.ID },
+			get: func(t *FunctionalRequirement) map[string]string {
+				return map[string]string{
+					"title":              t.Title,
+					"description":        t.Description,
+					"priority":           string(t.Priority),
+					"phaseId":            t.PhaseID,
+					"acceptanceCriteria": joinCriteria(t.AcceptanceCriteria),
+				}
+			},
+			set: func(_ *PRD, t *FunctionalRequirement, v map[string]string) {
+				t.Title = v["title"]
+				t.Description = v["description"]
+				t.Priority = MoSCoW(v["priority"])
+				t.PhaseID = v["phaseId"]
+				if value, ok := v["acceptanceCriteria"]; ok {
+					t.AcceptanceCriteria = splitCriteria(t.ID, t.AcceptanceCriteria, value)
+				}
+			},
+			add: func(p *PRD, v map[string]string) string {
+				defaultValue(v, "priority", string(MoSCoWShould))
+				return AddFunctionalRequirement(p, v["title"], v["description"], MoSCoW(v["priority"]))
+			},
+		},
+	},
+	{
+		Name:  "nfrs",
+		Title: "Non-Functional Requirements",
+		Fields: []EntityField{
+			{Name: "title", Label: "Title", Required: true},
+			{Name: "category", Label: "Category", Choices: nfrChoices},
+			{Name: "description", Label: "Description"},
+			{Name: "metric", Label: "Metric"},
+			{Name: "target", Label: "Target"},
+			{Name: "priority", Label: "Priority", Choices: moscowChoices},
+		},
+		store: sliceStore[NonFunctionalRequirement]{
+			items: func(p *PRD) *[]NonFunctionalRequirement { return &p.Requirements.NonFunctional },
+			id:    func(t *NonFunctionalRequirement) string { return t.ID },
+			get: func(t *NonFunctionalRequirement) map[string]string {
+				return map[string]string{
+					"title":       t.Title,
+					"category":    string(t.Category),
+					"description": t.Description,
+					"metric":      t.Metric,
+					"target":      t.Target,
+					"priority":    string(t.Priority),
+				}
+			},
+			set: func(_ *PRD, t *NonFunctionalRequirement, v map[string]string) {
+				t.Title = v["title"]
+				t.Category = NFRCategory(v["category"])
+				t.Description = v["description"]
+				t.Metric = v["metric"]
+				t.Target = v["target"]
+				t.Priority = MoSCoW(v["priority"])
+			},
+			add: func(p *PRD, v map[string]string) string {
+				defaultValue(v, "category", string(NFRPerformance))
+				defaultValue(v, "priority", string(MoSCoWShould))
+				return AddNonFunctionalRequirement(p, NFRCategory(v["category"]), v["title"], v["description"], v["target"], MoSCoW(v["priority"]))
+			},
+		},
+	},
+	{
+		Name:  "nongoals",
+		Title: "Non-Goals",
+		Fields: []EntityField{
+			{Name: "text", Label: "Non-goal", Required: true},
+		},
+		store: textStore{
+			items: func(p *PRD) *[]string { return &p.OutOfScope },
+			field: "text",
+		},
+	},
+	{
+		Name:  "alternatives",
+		Title: "Alternatives",
+		Fields: []EntityField{
+			{Name: "name", Label: "Name", Required: true},
+			{Name: "type", Label: "Type", Choices: alternativeChoices},
+		},
+		store: sliceStore[Alternative]{
+			items: func(p *PRD) *[]Alternative {
+				if p.Market == nil {
+					return nil
+				}
+				return &p.Market.Alternatives
+			},
+			id: func(t *Alternative) string { return t.ID },
+			get: func(t *Alternative) map[string]string {
+				return map[string]string{"name": t.Name, "type": string(t.Type)}
+			},
+			set: func(_ *PRD, t *Alternative, v map[string]string) {
+				t.Name = v["name"]
+				t.Type = AlternativeType(v["type"])
+			},
+			add: func(p *PRD, v map[string]string) string {
+				defaultValue(v, "type", string(AlternativeCompetitor))
+				return AddAlternative(p, v["name"], AlternativeType(v["type"]))
+			},
+		},
+	},
+	{
+		Name:  "solutions",
+		Title: "Solutions",
+		Fields: []EntityField{
+			{Name: "name", Label: "Name", Required: true},
+			{Name: "description", Label: "Description"},
+			{Name: "tradeoffs", Label: "Tradeoffs", List: true},
+		},
+		store: sliceStore[SolutionOption]{
+			items: func(p *PRD) *[]SolutionOption {
+				if p.Solution == nil {
+					return nil
+				}
+				return &p.Solution.SolutionOptions
+			},
+			id: func(t *SolutionOption) string { return t.ID },
+			get: func(t *SolutionOption) map[string]string {
+				return map[string]string{
+					"name":        t.Name,
+					"description": t.Description,
+					"tradeoffs":   joinList(t.Tradeoffs),
+				}
+			},
+			set: func(_ *PRD, t *SolutionOption, v map[string]string) {
+				t.Name = v["name"]
+				t.Description = v["description"]
+				setList(&t.Tradeoffs, v, "tradeoffs")
+			},
+			add: func(p *PRD, v map[string]string) string {
+				return AddSolution(p, v["name"], v["description"], nil)
+			},
+			removed: func(p *PRD, id string) {
+				if p.Solution.SelectedSolutionID == id {
+					p.Solution.SelectedSolutionID = ""
+					p.Solution.SolutionRationale = ""
+				}
+			},
+		},
+	},
+	{
+		Name:  "risks",
+		Title: "Risks",
+		Fields: []EntityField{
+			{Name: "description", Label: "Description", Required: true},
+			{Name: "probability", Label: "Probability", Choices: probabilityChoices},
+			{Name: "impact", Label: "Impact", Choices: impactChoices},
+			{Name: "mitigation", Label: "Mitigation"},
+			{Name: "owner", Label: "Owner"},
+		},
+		store: sliceStore[Risk]{
+			items: func(p *PRD) *[]Risk { return &p.Risks },
+			id:    func(t *Risk) string { return t.ID },
+			get: func(t *Risk) map[string]string {
+				return map[string]string{
+					"description": t.Description,
+					"probability": string(t.Probability),
+					"impact":      string(t.Impact),
+					"mitigation":  t.Mitigation,
+					"owner":       t.Owner,
+				}
+			},
+			set: func(_ *PRD, t *Risk, v map[string]string) {
+				t.Description = v["description"]
+				t.Probability = RiskProbability(v["probability"])
+				t.Impact = RiskImpact(v["impact"])
+				t.Mitigation = v["mitigation"]
+				t.Owner = v["owner"]
+			},
+			add: func(p *PRD, v map[string]string) string {
+				defaultValue(v, "probability", string(RiskProbabilityMedium))
+				defaultValue(v, "impact", string(RiskImpactMedium))
+				return AddRisk(p, v["description"], RiskProbability(v["probability"]), RiskImpact(v["impact"]), v["mitigation"])
+			},
+		},
+	},
+	{
+		Name:  "decisions",
+		Title: "Decisions",
+		Fields: []EntityField{
+			{Name: "decision", Label: "Decision", Required: true},
+			{Name: "rationale", Label: "Rationale"},
+			{Name: "madeBy", Label: "Made by"},
+			{Name: "status", Label: "Status", Choices: decisionChoices},
+		},
+		store: sliceStore[DecisionRecord]{
+			items: func(p *PRD) *[]DecisionRecord {
+				if p.Decisions == nil {
+					return nil
+				}
+				return &p.Decisions.Records
+			},
+			id: func(t *DecisionRecord) string { return t.ID },
+			get: func(t *DecisionRecord) map[string]string {
+				return map[string]string{
+					"decision":  t.Decision,
+					"rationale": t.Rationale,
+					"madeBy":    t.MadeBy,
+					"status":    string(t.Status),
+				}
+			},
+			set: func(_ *PRD, t *DecisionRecord, v map[string]string) {
+				t.Decision = v["decision"]
+				t.Rationale = v["rationale"]
+				t.MadeBy = v["madeBy"]
+				t.Status = DecisionStatus(v["status"])
+			},
+			add: func(p *PRD, v map[string]string) string {
+				return AddDecision(p, v["decision"], v["rationale"], v["madeBy"])
+			},
+		},
+	},
+}
+
+// evidenceStore stores the problem's evidence, which has no IDs. The ID is
+// the 1-based position. Evidence can only be added once the problem is
+// defined.
+type evidenceStore struct{}
+
+func (evidenceStore) items(p *PRD) *[]Evidence {
+	if p.Problem == nil {
+		return nil
+	}
+	return &p.Problem.Evidence
+}
+
+func (s evidenceStore) list(p *PRD) []map[string]string {
+	items := s.items(p)
+	if items == nil {
+		return nil
+	}
+	rows := make([]map[string]string, 0, len(*items))
+	for i, e := range *items {
+		rows = append(rows, map[string]string{
+			"id":       strconv.Itoa(i + 1),
+			"summary":  e.Summary,
+			"type":     string(e.Type),
+			"source":   e.Source,
+			"strength": string(e.Strength),
+		})
+	}
+	return rows
+}
+
+func (s evidenceStore) create(p *PRD, values map[string]string) (string, error) {
+	defaultValue(values, "type", string(EvidenceInterview))
+	if !AddEvidence(p, EvidenceType(values["type"]), values["summary"], values["source"]) {
+		return "", errors.New("define the problem before adding evidence")
+	}
+	id := strconv.Itoa(len(p.Problem.Evidence))
+	s.update(p, id, values)
+	return id, nil
+}
+
+func (s evidenceStore) index(p *PRD, id string) (*[]Evidence, int) {
+	items := s.items(p)
+	i, err := strconv.Atoi(id)
+	if items == nil || err != nil || i < 1 || i > len(*items) {
+		return nil, -1
+	}
+	return items, i - 1
+}
+
+func (s evidenceStore) update(p *PRD, id string, values map[string]string) bool {
+	items, i := s.index(p, id)
+	if i < 0 {
+		return false
+	}
+	e := &(*items)[i]
+	e.Summary = values["summary"]
+	e.Type = EvidenceType(values["type"])
+	e.Source = values["source"]
+	e.Strength = EvidenceStrength(values["strength"])
+	return true
+}
+
+func (s evidenceStore) remove(p *PRD, id string) bool {
+	items, i := s.index(p, id)
+	if i < 0 {
+		return false
+	}
+	*items = append((*items)[:i], (*items)[i+1:]...)
+	return true
+}
+
+// joinCriteria flattens acceptance criteria to their descriptions.
+func joinCriteria(criteria []AcceptanceCriterion) string {
+	descriptions := make([]string, len(criteria))
+	for i, ac := range criteria {
+		descriptions[i] = ac.Description
+	}
+	return joinList(descriptions)
+}
+
+// splitCriteria rebuilds acceptance criteria from a list value. Existing
+// criteria keep their IDs and Given/When/Then by position.
+func splitCriteria(ownerID string, current []AcceptanceCriterion, value string) []AcceptanceCriterion {
+	descriptions := splitList(value)
+	if len(descriptions) == 0 {
+		return nil
+	}
+	criteria := make([]AcceptanceCriterion, len(descriptions))
+	for i, d := range descriptions {
+		if i < len(current) {
+			criteria[i] = current[i]
+		} else {
+			criteria[i].ID = fmt.Sprintf("%s-AC-%d", ownerID, i+1)
+		}
+		criteria[i].Description = d
+	}
+	return criteria
+}
+
+// setList sets items from the named list value. If values has no such
+// value, the field is not being updated and items are left as stored.
+func setList(items *[]string, values map[string]string, name string) {
+	if v, ok := values[name]; ok {
+		*items = splitList(v)
+	}
+}
+
+func joinList(items []string) string {
+	return strings.Join(items, ", ")
+}
+
+// splitList splits a comma-separated value into trimmed, non-empty items.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func defaultValue(values map[string]string, name, def string) {
+	if values[name] == "" {
+		values[name] = def
+	}
+}
+
+func containsString(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,214 @@
+package prd
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestEntityKindsCRUD(t *testing.T) {
+	for _, k := range EntityKinds() {
+		t.Run(k.Name, func(t *testing.T) {
+			p := New("PRD-2026-001", "Test PRD", Person{Name: "Owner"})
+			if k.Name == "evidence" {
+				SetProblemStatement(p, "Problem", "", 0)
+			}
+
+			values := make(map[string]string)
+			for _, f := range k.Fields {
+				switch {
+				case len(f.Choices) > 0:
+					values[f.Name] = f.Choices[0]
+				case f.Number:
+					values[f.Name] = "0.5"
+				default:
+					values[f.Name] = "First " + f.Name
+				}
+			}
+
+			id, err := k.Create(p, values)
+			if err != nil {
+				t.Fatalf("Create failed: %v", err)
+			}
+			e, err := k.Get(p, id)
+			if err != nil {
+				t.Fatalf("Get failed: %v", err)
+			}
+			for name, want := range values {
+				if e.Values[name] != want {
+					t.Errorf("%s = %q, want %q", name, e.Values[name], want)
+				}
+			}
+			if e.Label != values[k.Fields[0].Name] {
+				t.Errorf("label = %q, want %q", e.Label, values[k.Fields[0].Name])
+			}
+
+			first := k.Fields[0].Name
+			if err := k.Update(p, id, map[string]string{first: "Changed"}); err != nil {
+				t.Fatalf("Update failed: %v", err)
+			}
+			e, _ = k.Get(p, id)
+			if e.Values[first] != "Changed" {
+				t.Errorf("%s = %q after update, want Changed", first, e.Values[first])
+			}
+
+			if err := k.Delete(p, id); err != nil {
+				t.Fatalf("Delete failed: %v", err)
+			}
+			if _, err := k.Get(p, id); !errors.Is(err, ErrEntityNotFound) {
+				t.Errorf("expected ErrEntityNotFound after delete, got %v", err)
+			}
+			if err := k.Delete(p, id); !errors.Is(err, ErrEntityNotFound) {
+				t.Errorf("expected ErrEntityNotFound deleting twice, got %v", err)
+			}
+		})
+	}
+}
+
+func TestEntityValidation(t *testing.T) {
+	p := New("PRD-2026-001", "Test PRD", Person{Name: "Owner"})
+	risks, _ := LookupEntityKind("risks")
+
+	if _, err := risks.Create(p, map[string]string{"impact": "high"}); err == nil {
+		t.Error("expected error for missing required description")
+	}
+	if _, err := risks.Create(p, map[string]string{"description": "Outage", "impact": "huge"}); err == nil {
+		t.Error("expected error for invalid choice")
+	}
+	if _, err := risks.Create(p, map[string]string{"description": "Outage", "severity": "high"}); err == nil {
+		t.Error("expected error for unknown field")
+	}
+
+	problem, _ := LookupEntityKind("problem")
+	if _, err := problem.Create(p, map[string]string{"statement": "Slow", "confidence": "high"}); err == nil {
+		t.Error("expected error for non-numeric confidence")
+	}
+	if _, err := problem.Create(p, map[string]string{"statement": "Slow"}); err != nil {
+		t.Fatalf("Create problem failed: %v", err)
+	}
+	if _, err := problem.Create(p, map[string]string{"statement": "Again"}); err == nil {
+		t.Error("expected error creating a second problem")
+	}
+}
+
+func TestEntityCreateDefaults(t *testing.T) {
+	p := New("PRD-2026-001", "Test PRD", Person{Name: "Owner"})
+
+	risks, _ := LookupEntityKind("risks")
+	id, err := risks.Create(p, map[string]string{"description": "Outage"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if id != "RISK-1" {
+		t.Errorf("expected ID RISK-1, got %s", id)
+	}
+	if p.Risks[0].Impact != RiskImpactMedium || p.Risks[0].Probability != RiskProbabilityMedium {
+		t.Errorf("expected medium defaults, got %s/%s", p.Risks[0].Probability, p.Risks[0].Impact)
+	}
+
+	personas, _ := LookupEntityKind("personas")
+	first, _ := personas.Create(p, map[string]string{"name": "Dan"})
+	second, _ := personas.Create(p, map[string]string{"name": "Pat", "primary": "true"})
+	if p.Personas[0].IsPrimary {
+		t.Errorf("expected %s to lose primary to %s", first, second)
+	}
+	if !p.Personas[1].IsPrimary {
+		t.Errorf("expected %s to be primary", second)
+	}
+
+	evidence, _ := LookupEntityKind("evidence")
+	if _, err := evidence.Create(p, map[string]string{"summary": "Interviews"}); err == nil {
+		t.Error("expected error adding evidence without a problem")
+	}
+}
+
+func TestEntityAcceptanceCriteria(t *testing.T) {
+	p := New("PRD-2026-001", "Test PRD", Person{Name: "Owner"})
+	reqs, _ := LookupEntityKind("requirements")
+
+	id, err := reqs.Create(p, map[string]string{"title": "Login", "acceptanceCriteria": "Accepts email, Rejects bad password"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	fr := p.Requirements.Functional[0]
+	if len(fr.AcceptanceCriteria) != 2 || fr.AcceptanceCriteria[1].ID != id+"-AC-2" {
+		t.Fatalf("unexpected acceptance criteria: %+v", fr.AcceptanceCriteria)
+	}
+
+	p.Requirements.Functional[0].AcceptanceCriteria[0].Given = "a user"
+	if err := reqs.Update(p, id, map[string]string{"acceptanceCriteria": "Accepts any email"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	ac := p.Requirements.Functional[0].AcceptanceCriteria
+	if len(ac) != 1 || ac[0].Description != "Accepts any email" || ac[0].Given != "a user" {
+		t.Errorf("expected first criterion to be kept and renamed, got %+v", ac)
+	}
+}
+
+func TestEntityUpdateKeepsLists(t *testing.T) {
+	p := New("PRD-2026-001", "Test PRD", Person{Name: "Owner"})
+	AddPersona(p, "Dev Dan", "Developer", []string{"Slow, flaky builds", "Long reviews"})
+	AddFunctionalRequirement(p, "Login", "Users can log in", MoSCoWMust)
+	criteria := []AcceptanceCriterion{
+		{ID: "FR-1-AC-3", Description: "Accepts email, phone or username"},
+		{ID: "FR-1-AC-7", Description: "Locks out after 5 tries"},
+	}
+	p.Requirements.Functional[0].AcceptanceCriteria = append([]AcceptanceCriterion(nil), criteria...)
+	painPoints := append([]string(nil), p.Personas[0].PainPoints...)
+
+	personas, _ := LookupEntityKind("personas")
+	if err := personas.Update(p, p.Personas[0].ID, map[string]string{"role": "Staff Developer"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if got := p.Personas[0].PainPoints; !reflect.DeepEqual(got, painPoints) {
+		t.Errorf("expected pain points to be unchanged, got %q", got)
+	}
+
+	// Sending every field back unchanged, as an edit form does, keeps them
+	// too.
+	reqs, _ := LookupEntityKind("requirements")
+	e, err := reqs.Get(p, "FR-1")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	e.Values["title"] = "Sign in"
+	if err := reqs.Update(p, "FR-1", e.Values); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	fr := p.Requirements.Functional[0]
+	if fr.Title != "Sign in" || !reflect.DeepEqual(fr.AcceptanceCriteria, criteria) {
+		t.Errorf("expected acceptance criteria to be unchanged, got %+v", fr.AcceptanceCriteria)
+	}
+}
+
+func TestEntityDeleteSelectedSolution(t *testing.T) {
+	p := New("PRD-2026-001", "Test PRD", Person{Name: "Owner"})
+	id := AddSolution(p, "Cache", "Add a cache", nil)
+	SelectSolution(p, id, "Cheapest")
+
+	solutions, _ := LookupEntityKind("solutions")
+	if err := solutions.Delete(p, id); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if p.Solution.SelectedSolutionID != "" {
+		t.Errorf("expected selection to be cleared, got %s", p.Solution.SelectedSolutionID)
+	}
+}
+
+func TestEntityPositionalIDs(t *testing.T) {
+	p := New("PRD-2026-001", "Test PRD", Person{Name: "Owner"})
+	AddOutOfScope(p, "Mobile")
+	AddOutOfScope(p, "Billing")
+
+	nongoals, _ := LookupEntityKind("nongoals")
+	if err := nongoals.Delete(p, "1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	list := nongoals.List(p)
+	if len(list) != 1 || list[0].ID != "1" || list[0].Label != "Billing" {
+		t.Errorf("unexpected non-goals: %+v", list)
+	}
+	if err := nongoals.Update(p, "3", map[string]string{"text": "x"}); !errors.Is(err, ErrEntityNotFound) {
+		t.Errorf("expected ErrEntityNotFound, got %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 )
 
 // NextID generates the next ID for a given prefix based on existing IDs in the PRD.
@@ -110,6 +111,21 @@ func SetProblemStatement(p *PRD, statement, impact string, confidence float64) {
 	p.Problem.Confidence = confidence
 }
 
+// AddEvidence adds supporting evidence to the problem definition.
+// Returns false if no problem has been defined.
+func AddEvidence(p *PRD, evidenceType EvidenceType, summary, source string) bool {
+	if p.Problem == nil {
+		return false
+	}
+
+	p.Problem.Evidence = append(p.Problem.Evidence, Evidence{
+		Type:    evidenceType,
+		Summary: summary,
+		Source:  source,
+	})
+	return true
+}
+
 // AddPersona adds a user persona to the PRD.
 // Returns the generated ID.
 func AddPersona(p *PRD, name, role string, painPoints []string) string {
@@ -166,6 +182,23 @@ func AddOutOfScope(p *PRD, item string) {
 	p.OutOfScope = append(p.OutOfScope, item)
 }
 
+// AddAlternative adds a competitor, workaround or other alternative to the
+// market definition.
+// Returns the generated ID.
+func AddAlternative(p *PRD, name string, altType AlternativeType) string {
+	if p.Market == nil {
+		p.Market = &MarketDefinition{}
+	}
+
+	id := NextID(p, "ALT")
+	p.Market.Alternatives = append(p.Market.Alternatives, Alternative{
+		ID:   id,
+		Name: name,
+		Type: altType,
+	})
+	return id
+}
+
 // AddSolution adds a solution option to the PRD.
 // Returns the generated ID.
 func AddSolution(p *PRD, name, description string, tradeoffs []string) string {
@@ -313,90 +346,92 @@ func UpdateStatus(p *PRD, status Status) {
 	p.Metadata.Status = status
 }
 
-// ParseMoSCoW converts a string to MoSCoW priority type.
-func ParseMoSCoW(s string) MoSCoW {
-	switch s {
-	case "must":
-		return MoSCoWMust
-	case "should":
-		return MoSCoWShould
-	case "could":
-		return MoSCoWCould
-	case "wont", "won't":
-		return MoSCoWWont
-	default:
-		return MoSCoWShould
-	}
+// The values accepted by the Parse functions, in the order they are listed
+// in help text and errors.
+var (
+	moscowValues          = []MoSCoW{MoSCoWMust, MoSCoWShould, MoSCoWCould, MoSCoWWont}
+	riskImpactValues      = []RiskImpact{RiskImpactLow, RiskImpactMedium, RiskImpactHigh, RiskImpactCritical}
+	riskProbabilityValues = []RiskProbability{RiskProbabilityLow, RiskProbabilityMedium, RiskProbabilityHigh}
+	nfrCategoryValues     = []NFRCategory{
+		NFRPerformance, NFRScalability, NFRReliability, NFRAvailability, NFRSecurity, NFRMultiTenancy,
+		NFRObservability, NFRMaintainability, NFRUsability, NFRCompatibility, NFRCompliance,
+	}
+	statusValues = []Status{StatusDraft, StatusInReview, StatusApproved, StatusDeprecated}
+)
+
+// InvalidValueError is returned by the Parse functions for a value that is
+// not one of the valid values.
+type InvalidValueError struct {
+	Kind  string
+	Value string
+	Valid []string
 }
 
-// ParseRiskImpact converts a string to RiskImpact type.
-func ParseRiskImpact(s string) RiskImpact {
-	switch s {
-	case "low":
-		return RiskImpactLow
-	case "medium":
-		return RiskImpactMedium
-	case "high":
-		return RiskImpactHigh
-	case "critical":
-		return RiskImpactCritical
-	default:
-		return RiskImpactMedium
-	}
+func (e *InvalidValueError) Error() string {
+	return fmt.Sprintf("invalid %s %q (valid: %s)", e.Kind, e.Value, strings.Join(e.Valid, ", "))
 }
 
-// ParseRiskProbability converts a string to RiskProbability type.
-func ParseRiskProbability(s string) RiskProbability {
-	switch s {
-	case "low":
-		return RiskProbabilityLow
-	case "medium":
-		return RiskProbabilityMedium
-	case "high":
-		return RiskProbabilityHigh
-	default:
-		return RiskProbabilityMedium
+// parseEnum matches s against the valid values and aliases. Case, spaces
+// around the value and hyphens in place of underscores are ignored.
+func parseEnum[T ~string](kind, s string, valid []T, aliases map[string]T) (T, error) {
+	key := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(s)), "-", "_")
+	for _, v := range valid {
+		if string(v) == key {
+			return v, nil
+		}
+	}
+	if v, ok := aliases[key]; ok {
+		return v, nil
 	}
+	return "", &InvalidValueError{Kind: kind, Value: s, Valid: enumStrings(valid)}
 }
 
-// ParseNFRCategory converts a string to NFRCategory type.
-func ParseNFRCategory(s string) NFRCategory {
-	switch s {
-	case "performance":
-		return NFRPerformance
-	case "security":
-		return NFRSecurity
-	case "reliability":
-		return NFRReliability
-	case "scalability":
-		return NFRScalability
-	case "usability":
-		return NFRUsability
-	case "compliance":
-		return NFRCompliance
-	case "maintainability":
-		return NFRMaintainability
-	case "availability":
-		return NFRAvailability
-	case "observability":
-		return NFRObservability
-	default:
-		return NFRPerformance
+func enumStrings[T ~string](values []T) []string {
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = string(v)
 	}
+	return result
 }
 
-// ParseStatus converts a string to Status type.
-func ParseStatus(s string) (Status, bool) {
-	switch s {
-	case "draft":
-		return StatusDraft, true
-	case "in_review", "review":
-		return StatusInReview, true
-	case "approved":
-		return StatusApproved, true
-	case "deprecated":
-		return StatusDeprecated, true
-	default:
-		return "", false
-	}
+// ParseMoSCoW converts a string to a MoSCoW priority.
+func ParseMoSCoW(s string) (MoSCoW, error) {
+	return parseEnum("priority", s, moscowValues, map[string]MoSCoW{"won't": MoSCoWWont})
+}
+
+// ParseRiskImpact converts a string to a RiskImpact.
+func ParseRiskImpact(s string) (RiskImpact, error) {
+	return parseEnum("impact", s, riskImpactValues, nil)
+}
+
+// ParseRiskProbability converts a string to a RiskProbability.
+func ParseRiskProbability(s string) (RiskProbability, error) {
+	return parseEnum("probability", s, riskProbabilityValues, nil)
+}
+
+// ParseNFRCategory converts a string to an NFRCategory.
+func ParseNFRCategory(s string) (NFRCategory, error) {
+	return parseEnum("NFR category", s, nfrCategoryValues, nil)
+}
+
+// ParseStatus converts a string to a Status. "review" is accepted for
+// in_review.
+func ParseStatus(s string) (Status, error) {
+	return parseEnum("status", s, statusValues, map[string]Status{"review": StatusInReview})
 }
+
+// MoSCoWValues returns the priorities accepted by ParseMoSCoW.
+func MoSCoWValues() []string { return enumStrings(moscowValues) }
+
+// RiskImpactValues returns the impacts accepted by ParseRiskImpact.
+func RiskImpactValues() []string { return enumStrings(riskImpactValues) }
+
+// RiskProbabilityValues returns the probabilities accepted by
+// ParseRiskProbability.
+func RiskProbabilityValues() []string { return enumStrings(riskProbabilityValues) }
+
+// NFRCategoryValues returns the categories accepted by ParseNFRCategory.
+func NFRCategoryValues() []string { return enumStrings(nfrCategoryValues) }
+
+// StatusValues returns the statuses accepted by ParseStatus.
+func StatusValues() []string { return enumStrings(statusValues) }
@@ -1,6 +1,7 @@
 package prd
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -87,6 +88,37 @@ func TestAddOutOfScope(t *testing.T) {
 	}
 }
 
+func TestAddEvidence(t *testing.T) {
+	p := New("PRD-2026-001", "Test PRD", Person{Name: "Owner"})
+
+	if AddEvidence(p, EvidenceSurvey, "60% of users reset passwords monthly", "Q3 survey") {
+		t.Error("expected AddEvidence to fail without a problem")
+	}
+
+	SetProblemStatement(p, "Users forget passwords", "", 0.5)
+	if !AddEvidence(p, EvidenceSurvey, "60% of users reset passwords monthly", "Q3 survey") {
+		t.Fatal("expected AddEvidence to succeed")
+	}
+	if len(p.Problem.Evidence) != 1 || p.Problem.Evidence[0].Type != EvidenceSurvey {
+		t.Errorf("expected one survey evidence, got %v", p.Problem.Evidence)
+	}
+}
+
+func TestAddAlternative(t *testing.T) {
+	p := New("PRD-2026-001", "Test PRD", Person{Name: "Owner"})
+
+	id := AddAlternative(p, "Spreadsheets", AlternativeWorkaround)
+	if p.Market == nil || len(p.Market.Alternatives) != 1 {
+		t.Fatal("expected one alternative")
+	}
+	if p.Market.Alternatives[0].ID != id || id != "ALT-1" {
+		t.Errorf("expected ID ALT-1, got %s", id)
+	}
+	if p.Market.Alternatives[0].Type != AlternativeWorkaround {
+		t.Errorf("expected workaround, got %s", p.Market.Alternatives[0].Type)
+	}
+}
+
 func TestAddSolution(t *testing.T) {
 	p := New("PRD-2026-001", "Test PRD", Person{Name: "Owner"})
 
@@ -238,18 +270,25 @@ func TestParseMoSCoW(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected MoSCoW
+		ok       bool
 	}{
-		{"must", MoSCoWMust},
-		{"should", MoSCoWShould},
-		{"could", MoSCoWCould},
-		{"wont", MoSCoWWont},
-		{"invalid", MoSCoWShould}, // default
-		{"", MoSCoWShould},        // default
+		{"must", MoSCoWMust, true},
+		{"should", MoSCoWShould, true},
+		{"could", MoSCoWCould, true},
+		{"wont", MoSCoWWont, true},
+		{"won't", MoSCoWWont, true},
+		{" Must ", MoSCoWMust, true},
+		{"mst", "", false},
+		{"", "", false},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.input, func(t *testing.T) {
-			if got := ParseMoSCoW(tt.input); got != tt.expected {
+			got, err := ParseMoSCoW(tt.input)
+			if (err == nil) != tt.ok {
+				t.Fatalf("ParseMoSCoW(%s) error = %v, want ok = %v", tt.input, err, tt.ok)
+			}
+			if got != tt.expected {
 				t.Errorf("ParseMoSCoW(%s) = %s, want %s", tt.input, got, tt.expected)
 			}
 		})
@@ -260,46 +299,75 @@ func TestParseRiskImpact(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected RiskImpact
+		ok       bool
 	}{
-		{"low", RiskImpactLow},
-		{"medium", RiskImpactMedium},
-		{"high", RiskImpactHigh},
-		{"critical", RiskImpactCritical},
-		{"invalid", RiskImpactMedium}, // default
-		{"", RiskImpactMedium},        // default
+		{"low", RiskImpactLow, true},
+		{"medium", RiskImpactMedium, true},
+		{"high", RiskImpactHigh, true},
+		{"critical", RiskImpactCritical, true},
+		{"severe", "", false},
+		{"", "", false},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.input, func(t *testing.T) {
-			if got := ParseRiskImpact(tt.input); got != tt.expected {
+			got, err := ParseRiskImpact(tt.input)
+			if (err == nil) != tt.ok {
+				t.Fatalf("ParseRiskImpact(%s) error = %v, want ok = %v", tt.input, err, tt.ok)
+			}
+			if got != tt.expected {
 				t.Errorf("ParseRiskImpact(%s) = %s, want %s", tt.input, got, tt.expected)
 			}
 		})
 	}
 }
 
+func TestParseRiskProbability(t *testing.T) {
+	for _, v := range RiskProbabilityValues() {
+		if got, err := ParseRiskProbability(v); err != nil || string(got) != v {
+			t.Errorf("ParseRiskProbability(%s) = %s, %v", v, got, err)
+		}
+	}
+	if _, err := ParseRiskProbability("critical"); err == nil {
+		t.Error("expected an error for probability critical")
+	}
+}
+
 func TestParseNFRCategory(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected NFRCategory
+		ok       bool
 	}{
-		{"performance", NFRPerformance},
-		{"security", NFRSecurity},
-		{"reliability", NFRReliability},
-		{"scalability", NFRScalability},
-		{"usability", NFRUsability},
-		{"compliance", NFRCompliance},
-		{"invalid", NFRPerformance}, // default
-		{"", NFRPerformance},        // default
+		{"performance", NFRPerformance, true},
+		{"security", NFRSecurity, true},
+		{"reliability", NFRReliability, true},
+		{"scalability", NFRScalability, true},
+		{"usability", NFRUsability, true},
+		{"compliance", NFRCompliance, true},
+		{"multi_tenancy", NFRMultiTenancy, true},
+		{"multi-tenancy", NFRMultiTenancy, true},
+		{"compatibility", NFRCompatibility, true},
+		{"multitenancy", "", false},
+		{"", "", false},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.input, func(t *testing.T) {
-			if got := ParseNFRCategory(tt.input); got != tt.expected {
+			got, err := ParseNFRCategory(tt.input)
+			if (err == nil) != tt.ok {
+				t.Fatalf("ParseNFRCategory(%s) error = %v, want ok = %v", tt.input, err, tt.ok)
+			}
+			if got != tt.expected {
 				t.Errorf("ParseNFRCategory(%s) = %s, want %s", tt.input, got, tt.expected)
 			}
 		})
 	}
+
+	// All eleven categories are accepted.
+	if n := len(NFRCategoryValues()); n != 11 {
+		t.Errorf("expected 11 NFR categories, got %d", n)
+	}
 }
 
 func TestParseStatus(t *testing.T) {
@@ -310,6 +378,7 @@ func TestParseStatus(t *testing.T) {
 	}{
 		{"draft", StatusDraft, true},
 		{"in_review", StatusInReview, true},
+		{"in-review", StatusInReview, true},
 		{"review", StatusInReview, true},
 		{"approved", StatusApproved, true},
 		{"deprecated", StatusDeprecated, true},
@@ -319,17 +388,31 @@ func TestParseStatus(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.input, func(t *testing.T) {
-			got, ok := ParseStatus(tt.input)
-			if ok != tt.ok {
-				t.Errorf("ParseStatus(%s) ok = %v, want %v", tt.input, ok, tt.ok)
+			got, err := ParseStatus(tt.input)
+			if (err == nil) != tt.ok {
+				t.Errorf("ParseStatus(%s) error = %v, want ok = %v", tt.input, err, tt.ok)
 			}
-			if ok && got != tt.expected {
+			if tt.ok && got != tt.expected {
 				t.Errorf("ParseStatus(%s) = %s, want %s", tt.input, got, tt.expected)
 			}
 		})
 	}
 }
 
+func TestInvalidValueError(t *testing.T) {
+	_, err := ParseMoSCoW("mst")
+	var verr *InvalidValueError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected an InvalidValueError, got %v", err)
+	}
+	if verr.Kind != "priority" || verr.Value != "mst" {
+		t.Errorf("unexpected error fields: %+v", verr)
+	}
+	if want := `invalid priority "mst" (valid: must, should, could, wont)`; err.Error() != want {
+		t.Errorf("expected %q, got %q", want, err.Error())
+	}
+}
+
 func TestNextID(t *testing.T) {
 	p := New("PRD-2026-001", "Test PRD", Person{Name: "Owner"})
 
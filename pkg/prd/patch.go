@@ -0,0 +1,393 @@
+package prd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// PatchOperation is a single RFC 6902 JSON Patch operation.
+type PatchOperation struct {
+	Op    string      `json:"op" jsonschema:"Operation: add, remove, replace, move, copy or test"`
+	Path  string      `json:"path" jsonschema:"JSON Pointer to the target location, e.g. /personas/0/painPoints"`
+	From  string      `json:"from,omitempty" jsonschema:"JSON Pointer to the source location (move and copy)"`
+	Value interface{} `json:"value,omitempty" jsonschema:"Value to add, replace or test"`
+}
+
+// MarshalJSON encodes the operation, always writing the value of add,
+// replace and test operations, so a null value survives a round trip.
+// The omitempty tag only keeps value optional in inferred schemas, where
+// remove, move and copy operations have none.
+func (op PatchOperation) MarshalJSON() ([]byte, error) {
+	type operation PatchOperation
+	switch op.Op {
+	case "add", "replace", "test":
+		return json.Marshal(struct {
+			operation
+			Value interface{} `json:"value"`
+		}{operation(op), op.Value})
+	default:
+		return json.Marshal(operation(op))
+	}
+}
+
+// PatchValidationError is returned when a patch would introduce validation
+// errors. The PRD is left unchanged.
+type PatchValidationError struct {
+	Errors []ValidationError
+}
+
+func (e *PatchValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, ve := range e.Errors {
+		msgs[i] = ve.Field + ": " + ve.Message
+	}
+	return "patch introduces validation errors: " + strings.Join(msgs, "; ")
+}
+
+// ParsePatch decodes a patch document. A JSON array is read as RFC 6902
+// JSON Patch operations and a JSON object as an RFC 7396 merge patch.
+func ParsePatch(data []byte) (ops []PatchOperation, merge map[string]interface{}, err error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, nil, errors.New("empty patch")
+	}
+
+	switch trimmed[0] {
+	case '[':
+		if err := json.Unmarshal(trimmed, &ops); err != nil {
+			return nil, nil, fmt.Errorf("invalid JSON Patch: %w", err)
+		}
+		return ops, nil, nil
+	case '{':
+		if err := json.Unmarshal(trimmed, &merge); err != nil {
+			return nil, nil, fmt.Errorf("invalid JSON Merge Patch: %w", err)
+		}
+		return nil, merge, nil
+	default:
+		return nil, nil, errors.New("patch must be a JSON array (JSON Patch) or object (JSON Merge Patch)")
+	}
+}
+
+// ApplyJSONPatch applies RFC 6902 JSON Patch operations to a copy of the
+// PRD. The operations are applied all-or-nothing: if any fails, or the
+// result has validation errors the original did not, an error is returned
+// and p is not modified.
+func ApplyJSONPatch(p *PRD, ops []PatchOperation) (*PRD, error) {
+	doc, err := toDocument(p)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, op := range ops {
+		doc, err = applyOperation(doc, op)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+
+	return fromDocument(p, doc)
+}
+
+// ApplyMergePatch applies an RFC 7396 JSON Merge Patch to a copy of the
+// PRD. Null values remove fields. As with ApplyJSONPatch, a patch that
+// introduces validation errors is rejected and p is not modified.
+func ApplyMergePatch(p *PRD, patch map[string]interface{}) (*PRD, error) {
+	doc, err := toDocument(p)
+	if err != nil {
+		return nil, err
+	}
+	return fromDocument(p, mergePatch(doc, patch))
+}
+
+func toDocument(p *PRD) (interface{}, error) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal PRD: %w", err)
+	}
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode PRD: %w", err)
+	}
+	return doc, nil
+}
+
+// fromDocument decodes the patched document and rejects it if it has
+// validation errors the original PRD did not have.
+func fromDocument(original *PRD, doc interface{}) (*PRD, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal patched PRD: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var patched PRD
+	if err := dec.Decode(&patched); err != nil {
+		return nil, fmt.Errorf("patched document is not a valid PRD: %w", err)
+	}
+
+	existing := make(map[ValidationError]bool)
+	for _, ve := range Validate(original).Errors {
+		existing[ve] = true
+	}
+	var introduced []ValidationError
+	for _, ve := range Validate(&patched).Errors {
+		if !existing[ve] {
+			introduced = append(introduced, ve)
+		}
+	}
+	if len(introduced) > 0 {
+		return nil, &PatchValidationError{Errors: introduced}
+	}
+
+	return &patched, nil
+}
+
+func mergePatch(target, patch interface{}) interface{} {
+	pm, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	tm, ok := target.(map[string]interface{})
+	if !ok {
+		tm = make(map[string]interface{})
+	}
+	for k, v := range pm {
+		if v == nil {
+			delete(tm, k)
+		} else {
+			tm[k] = mergePatch(tm[k], v)
+		}
+	}
+	return tm
+}
+
+func applyOperation(doc interface{}, op PatchOperation) (interface{}, error) {
+	switch op.Op {
+	case "add":
+		return addValue(doc, op.Path, normalize(op.Value))
+	case "remove":
+		doc, _, err := removeValue(doc, op.Path)
+		return doc, err
+	case "replace":
+		doc, _, err := removeValue(doc, op.Path)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, op.Path, normalize(op.Value))
+	case "move":
+		if op.From == op.Path {
+			return doc, nil
+		}
+		if strings.HasPrefix(op.Path, op.From+"/") {
+			return nil, errors.New("cannot move a value into one of its children")
+		}
+		doc, value, err := removeValue(doc, op.From)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, op.Path, value)
+	case "copy":
+		value, err := getValue(doc, op.From)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, op.Path, normalize(value))
+	case "test":
+		value, err := getValue(doc, op.Path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(value, normalize(op.Value)) {
+			return nil, errors.New("test failed: value does not match")
+		}
+		return doc, nil
+	default:
+		return nil, fmt.Errorf("unknown operation %q", op.Op)
+	}
+}
+
+// parsePointer splits an RFC 6901 JSON Pointer into unescaped tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON Pointer %q: must start with /", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, t := range tokens {
+		t = strings.ReplaceAll(t, "~1", "/")
+		tokens[i] = strings.ReplaceAll(t, "~0", "~")
+	}
+	return tokens, nil
+}
+
+func getValue(doc interface{}, pointer string) (interface{}, error) {
+	tokens, err := parsePointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	current := doc
+	for _, t := range tokens {
+		current, err = child(current, t)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return current, nil
+}
+
+func child(node interface{}, token string) (interface{}, error) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		v, ok := n[token]
+		if !ok {
+			return nil, fmt.Errorf("path not found: %s", token)
+		}
+		return v, nil
+	case []interface{}:
+		i, err := arrayIndex(token, len(n)-1)
+		if err != nil {
+			return nil, err
+		}
+		return n[i], nil
+	default:
+		return nil, fmt.Errorf("cannot traverse into %s", token)
+	}
+}
+
+// addValue sets the value at pointer, inserting into arrays, and returns the
+// possibly replaced document root.
+func addValue(doc interface{}, pointer string, value interface{}) (interface{}, error) {
+	tokens, err := parsePointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return value, nil
+	}
+
+	parent, err := getValue(doc, pointer[:strings.LastIndex(pointer, "/")])
+	if err != nil {
+		return nil, err
+	}
+	last := tokens[len(tokens)-1]
+
+	switch p := parent.(type) {
+	case map[string]interface{}:
+		p[last] = value
+		return doc, nil
+	case []interface{}:
+		i := len(p)
+		if last != "-" {
+			if i, err = arrayIndex(last, len(p)); err != nil {
+				return nil, err
+			}
+		}
+		arr := append(p[:i:i], append([]interface{}{value}, p[i:]...)...)
+		return replaceAt(doc, tokens[:len(tokens)-1], arr)
+	default:
+		return nil, fmt.Errorf("cannot add to %s", pointer)
+	}
+}
+
+// removeValue deletes the value at pointer and returns the new document
+// root and the removed value.
+func removeValue(doc interface{}, pointer string) (interface{}, interface{}, error) {
+	tokens, err := parsePointer(pointer)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil, errors.New("cannot remove the document root")
+	}
+
+	parent, err := getValue(doc, pointer[:strings.LastIndex(pointer, "/")])
+	if err != nil {
+		return nil, nil, err
+	}
+	last := tokens[len(tokens)-1]
+
+	switch p := parent.(type) {
+	case map[string]interface{}:
+		v, ok := p[last]
+		if !ok {
+			return nil, nil, fmt.Errorf("path not found: %s", pointer)
+		}
+		delete(p, last)
+		return doc, v, nil
+	case []interface{}:
+		i, err := arrayIndex(last, len(p)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+		v := p[i]
+		arr := append(p[:i:i], p[i+1:]...)
+		doc, err = replaceAt(doc, tokens[:len(tokens)-1], arr)
+		return doc, v, err
+	default:
+		return nil, nil, fmt.Errorf("cannot remove %s", pointer)
+	}
+}
+
+// replaceAt stores value at the location given by tokens. Arrays change
+// length on insert and remove, so their parent must be updated.
+func replaceAt(doc interface{}, tokens []string, value interface{}) (interface{}, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	parent := doc
+	for _, t := range tokens[:len(tokens)-1] {
+		var err error
+		if parent, err = child(parent, t); err != nil {
+			return nil, err
+		}
+	}
+	last := tokens[len(tokens)-1]
+	switch p := parent.(type) {
+	case map[string]interface{}:
+		p[last] = value
+	case []interface{}:
+		i, err := arrayIndex(last, len(p)-1)
+		if err != nil {
+			return nil, err
+		}
+		p[i] = value
+	}
+	return doc, nil
+}
+
+func arrayIndex(token string, max int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	if i > max {
+		return 0, fmt.Errorf("array index %d out of range", i)
+	}
+	return i, nil
+}
+
+// normalize converts a value to the types produced by decoding JSON into
+// interface{}, so it can be compared with document values. The result is a
+// deep copy.
+func normalize(v interface{}) interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return v
+	}
+	return out
+}
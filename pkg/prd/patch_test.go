@@ -0,0 +1,147 @@
+package prd
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func newPatchTestPRD() *PRD {
+	p := New("PRD-2026-001", "Patch Test PRD", Person{Name: "Owner"})
+	AddPersona(p, "Developer Dan", "Backend Developer", []string{"Slow builds"})
+	AddRisk(p, "Provider outage", RiskProbabilityMedium, RiskImpactHigh, "")
+	return p
+}
+
+func TestParsePatch(t *testing.T) {
+	ops, merge, err := ParsePatch([]byte(`[{"op":"remove","path":"/risks/0"}]`))
+	if err != nil || len(ops) != 1 || merge != nil {
+		t.Errorf("expected one JSON Patch operation, got %v %v %v", ops, merge, err)
+	}
+
+	ops, merge, err = ParsePatch([]byte(` {"metadata":{"version":"1.1.0"}}`))
+	if err != nil || ops != nil || merge == nil {
+		t.Errorf("expected a merge patch, got %v %v %v", ops, merge, err)
+	}
+
+	if _, _, err := ParsePatch([]byte(`"text"`)); err == nil {
+		t.Error("expected error for a patch that is neither array nor object")
+	}
+}
+
+func TestPatchOperationNullValue(t *testing.T) {
+	data, err := json.Marshal([]PatchOperation{
+		{Op: "replace", Path: "/problem/userImpact", Value: nil},
+		{Op: "remove", Path: "/risks/0"},
+	})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	want := `[{"op":"replace","path":"/problem/userImpact","value":null},{"op":"remove","path":"/risks/0"}]`
+	if string(data) != want {
+		t.Errorf("expected %s, got %s", want, data)
+	}
+
+	ops, _, err := ParsePatch(data)
+	if err != nil || len(ops) != 2 || ops[0].Value != nil {
+		t.Errorf("expected the null value to round trip, got %+v %v", ops, err)
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	p := newPatchTestPRD()
+
+	patched, err := ApplyJSONPatch(p, []PatchOperation{
+		{Op: "test", Path: "/personas/0/name", Value: "Developer Dan"},
+		{Op: "add", Path: "/personas/0/painPoints/-", Value: "Complex configs"},
+		{Op: "replace", Path: "/risks/0/mitigation", Value: "Fallback provider"},
+	})
+	if err != nil {
+		t.Fatalf("ApplyJSONPatch failed: %v", err)
+	}
+
+	if got := patched.Personas[0].PainPoints; len(got) != 2 || got[1] != "Complex configs" {
+		t.Errorf("expected appended pain point, got %v", got)
+	}
+	if patched.Risks[0].Mitigation != "Fallback provider" {
+		t.Errorf("expected mitigation to be replaced, got %q", patched.Risks[0].Mitigation)
+	}
+	if len(p.Personas[0].PainPoints) != 1 || p.Risks[0].Mitigation != "" {
+		t.Error("expected original PRD to be unchanged")
+	}
+}
+
+func TestApplyJSONPatchAllOrNothing(t *testing.T) {
+	p := newPatchTestPRD()
+
+	_, err := ApplyJSONPatch(p, []PatchOperation{
+		{Op: "replace", Path: "/risks/0/mitigation", Value: "Fallback provider"},
+		{Op: "test", Path: "/personas/0/name", Value: "Someone Else"},
+	})
+	if err == nil {
+		t.Fatal("expected failed test operation to reject the patch")
+	}
+	if p.Risks[0].Mitigation != "" {
+		t.Error("expected original PRD to be unchanged")
+	}
+}
+
+func TestApplyJSONPatchErrors(t *testing.T) {
+	p := newPatchTestPRD()
+
+	tests := []struct {
+		name string
+		op   PatchOperation
+	}{
+		{"unknown op", PatchOperation{Op: "upsert", Path: "/metadata/title"}},
+		{"missing path", PatchOperation{Op: "remove", Path: "/personas/5"}},
+		{"bad pointer", PatchOperation{Op: "remove", Path: "personas"}},
+		{"unknown field", PatchOperation{Op: "add", Path: "/personas/0/favoriteColor", Value: "blue"}},
+		{"remove root", PatchOperation{Op: "remove", Path: ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ApplyJSONPatch(p, []PatchOperation{tt.op}); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestApplyJSONPatchRejectsValidationErrors(t *testing.T) {
+	p := newPatchTestPRD()
+
+	_, err := ApplyJSONPatch(p, []PatchOperation{
+		{Op: "replace", Path: "/metadata/title", Value: "Hi"},
+	})
+
+	var verr *PatchValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected PatchValidationError, got %v", err)
+	}
+	if len(verr.Errors) != 1 || verr.Errors[0].Field != "metadata.title" {
+		t.Errorf("expected metadata.title error, got %v", verr.Errors)
+	}
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	p := newPatchTestPRD()
+
+	patched, err := ApplyMergePatch(p, map[string]interface{}{
+		"metadata": map[string]interface{}{"version": "1.1.0"},
+		"risks":    nil,
+	})
+	if err != nil {
+		t.Fatalf("ApplyMergePatch failed: %v", err)
+	}
+
+	if patched.Metadata.Version != "1.1.0" {
+		t.Errorf("expected version 1.1.0, got %s", patched.Metadata.Version)
+	}
+	if patched.Metadata.Title != p.Metadata.Title {
+		t.Error("expected untouched fields to be kept")
+	}
+	if len(patched.Risks) != 0 {
+		t.Errorf("expected risks to be removed, got %d", len(patched.Risks))
+	}
+}
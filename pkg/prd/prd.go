@@ -1,6 +1,12 @@
 package prd
 
 import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
 	"github.com/agentplexus/structured-evaluation/evaluation"
 	structuredprd "github.com/grokify/structured-plan/requirements/prd"
 )
@@ -18,9 +24,55 @@ func Load(path string) (*PRD, error) {
 }
 
 // Save writes a PRD to a JSON file.
-// Wrapper around structured-prd Save function.
+// The document is written and synced to a temporary file in the same
+// directory and renamed into place, so readers never see a partially
+// written file. If path is a symlink, its target is replaced and the link
+// is kept.
 func Save(prd *PRD, path string) error {
-	return structuredprd.Save(prd, path)
+	data, err := json.MarshalIndent(prd, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	mode := os.FileMode(0644)
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+		if info, err := os.Stat(path); err == nil {
+			mode = info.Mode().Perm()
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	cleanup := func(err error) error {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		return cleanup(err)
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		return cleanup(err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return cleanup(err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	return nil
 }
 
 // New creates a new PRD with required fields initialized.
@@ -113,21 +165,21 @@ func LoadPersonaLibrary(path string) (*PersonaLibrary, error) {
 
 // ValidationResult contains validation errors and warnings.
 type ValidationResult struct {
-	Valid    bool
-	Errors   []ValidationError
-	Warnings []ValidationWarning
+	Valid    bool                `json:"valid"`
+	Errors   []ValidationError   `json:"errors"`
+	Warnings []ValidationWarning `json:"warnings"`
 }
 
 // ValidationError represents a validation failure.
 type ValidationError struct {
-	Field   string
-	Message string
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 // ValidationWarning represents a non-blocking issue.
 type ValidationWarning struct {
-	Field   string
-	Message string
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 // Validate checks the PRD for structural and content issues.
@@ -84,6 +84,82 @@ func TestSaveAndLoad(t *testing.T) {
 	}
 }
 
+func TestSaveOverwritesAtomically(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "PRD.json")
+
+	p := New("PRD-2026-001", "Original", Person{Name: "Owner"})
+	if err := Save(p, path); err != nil {
+		t.Fatalf("failed to save PRD: %v", err)
+	}
+	if err := os.Chmod(path, 0640); err != nil {
+		t.Fatalf("failed to chmod: %v", err)
+	}
+
+	p.Metadata.Title = "Updated"
+	if err := Save(p, path); err != nil {
+		t.Fatalf("failed to save PRD: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("failed to load PRD: %v", err)
+	}
+	if loaded.Metadata.Title != "Updated" {
+		t.Errorf("expected title Updated, got %s", loaded.Metadata.Title)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("failed to stat: %v", err)
+	}
+	if info.Mode().Perm() != 0640 {
+		t.Errorf("expected permissions to be preserved, got %v", info.Mode().Perm())
+	}
+
+	entries, err := os.ReadDir(tmpDir)
+	if err != nil {
+		t.Fatalf("failed to read dir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected only PRD.json in directory, found %d entries", len(entries))
+	}
+}
+
+func TestSaveThroughSymlink(t *testing.T) {
+	tmpDir := t.TempDir()
+	target := filepath.Join(tmpDir, "PRD.json")
+	link := filepath.Join(tmpDir, "link.json")
+
+	p := New("PRD-2026-001", "Original", Person{Name: "Owner"})
+	if err := Save(p, target); err != nil {
+		t.Fatalf("failed to save PRD: %v", err)
+	}
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	p.Metadata.Title = "Updated"
+	if err := Save(p, link); err != nil {
+		t.Fatalf("failed to save PRD: %v", err)
+	}
+
+	info, err := os.Lstat(link)
+	if err != nil {
+		t.Fatalf("failed to stat link: %v", err)
+	}
+	if info.Mode()&os.ModeSymlink == 0 {
+		t.Error("expected the symlink to be kept")
+	}
+	loaded, err := Load(target)
+	if err != nil {
+		t.Fatalf("failed to load PRD: %v", err)
+	}
+	if loaded.Metadata.Title != "Updated" {
+		t.Errorf("expected the link target to be updated, got %s", loaded.Metadata.Title)
+	}
+}
+
 func TestLoadNonExistent(t *testing.T) {
 	_, err := Load("/nonexistent/path/prd.json")
 	if err == nil {
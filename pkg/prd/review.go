@@ -0,0 +1,210 @@
+package prd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/agentplexus/structured-evaluation/evaluation"
+)
+
+// ReviewRecord records when the review in the reviews section was made,
+// the content it judged and the rubric categories it scored, which the
+// reviews section has no fields for. It is stored in the "review" custom
+// section.
+type ReviewRecord struct {
+	ReviewedAt time.Time `json:"reviewedAt"`
+	// ContentHash is the ContentHash of the PRD when it was reviewed.
+	ContentHash string   `json:"contentHash"`
+	Version     string   `json:"version,omitempty"`
+	Categories  []string `json:"categories"`
+}
+
+// RecordReview stores an evaluation's category scores, decision, blockers
+// and revision triggers in the PRD's reviews section, replacing any earlier
+// review, and records when it was made (see ReviewRecord).
+//
+// Critical and high findings are recorded as blockers. Every finding above
+// info severity is recorded as a revision trigger, with its severity mapped
+// to the PRD vocabulary (blocker, major, minor). Categories the report
+// hasn't scored yet (pending) are left out rather than recorded as 0.
+func RecordReview(p *PRD, report *evaluation.EvaluationReport) error {
+	if report == nil {
+		return errors.New("evaluation report is required")
+	}
+	if id := report.Metadata.DocumentID; id != "" && id != p.Metadata.ID {
+		return fmt.Errorf("evaluation is for %s, not %s", id, p.Metadata.ID)
+	}
+
+	decision, err := reviewDecision(report.Decision.Status)
+	if err != nil {
+		return err
+	}
+
+	hash, err := ContentHash(p)
+	if err != nil {
+		return err
+	}
+	record := ReviewRecord{
+		ReviewedAt:  time.Now().UTC(),
+		ContentHash: hash,
+		Version:     p.Metadata.Version,
+		Categories:  []string{},
+	}
+
+	scores := &QualityScores{OverallScore: report.WeightedScore}
+	for _, c := range report.Categories {
+		if c.Status == evaluation.CategoryPending {
+			continue
+		}
+		if field := qualityScore(scores, c.Category); field != nil {
+			*field = c.Score
+			record.Categories = append(record.Categories, c.Category)
+		}
+	}
+
+	owners := CategoryOwners()
+	reviews := &ReviewsDefinition{
+		ReviewBoardSummary: report.Summary,
+		QualityScores:      scores,
+		Decision:           decision,
+	}
+	for _, f := range report.Findings {
+		if f.Severity == evaluation.SeverityInfo {
+			continue
+		}
+		description := joinNonEmpty(f.Title, f.Description)
+		if f.IsBlocking() {
+			reviews.Blockers = append(reviews.Blockers, Blocker{
+				ID:          f.ID,
+				Category:    f.Category,
+				Description: description,
+			})
+		}
+
+		owner := f.Owner
+		if owner == "" {
+			owner = owners[f.Category]
+		}
+		if f.Recommendation != "" {
+			description = joinNonEmpty(description, f.Recommendation)
+		}
+		reviews.RevisionTriggers = append(reviews.RevisionTriggers, RevisionTrigger{
+			IssueID:          f.ID,
+			Category:         f.Category,
+			Severity:         triggerSeverity(f.Severity),
+			Description:      description,
+			RecommendedOwner: owner,
+		})
+	}
+
+	p.Reviews = reviews
+	SetCustomSection(p, SectionReview, "Review", record)
+	return nil
+}
+
+// GetReviewRecord returns the record of the PRD's review, or nil if the
+// review was recorded without one or there is no review.
+func GetReviewRecord(p *PRD) (*ReviewRecord, error) {
+	if p.Reviews == nil {
+		return nil, nil
+	}
+	var record ReviewRecord
+	ok, err := DecodeCustomSection(p, SectionReview, &record)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// ReviewIsCurrent reports whether the PRD has a recorded review of its
+// current content. A review recorded without a ReviewRecord can't be
+// checked and is not current.
+func ReviewIsCurrent(p *PRD) (bool, error) {
+	record, err := GetReviewRecord(p)
+	if err != nil || record == nil {
+		return false, err
+	}
+	hash, err := ContentHash(p)
+	if err != nil {
+		return false, err
+	}
+	return record.ContentHash == hash, nil
+}
+
+func reviewDecision(status evaluation.DecisionStatus) (ReviewDecision, error) {
+	switch status {
+	case evaluation.DecisionPass:
+		return ReviewApprove, nil
+	case evaluation.DecisionConditional:
+		return ReviewRevise, nil
+	case evaluation.DecisionHumanReview:
+		return ReviewHumanReview, nil
+	case evaluation.DecisionFail:
+		return ReviewReject, nil
+	case "":
+		return "", errors.New("evaluation has no decision; finalize the report before recording it")
+	default:
+		return "", fmt.Errorf("unknown evaluation decision: %s", status)
+	}
+}
+
+func triggerSeverity(s evaluation.Severity) string {
+	switch s {
+	case evaluation.SeverityCritical:
+		return "blocker"
+	case evaluation.SeverityHigh:
+		return "major"
+	default:
+		return "minor"
+	}
+}
+
+// qualityScore returns the QualityScores field for a rubric category, or
+// nil for categories outside the rubric.
+func qualityScore(s *QualityScores, category string) *float64 {
+	switch category {
+	case "problem_definition":
+		return &s.ProblemDefinition
+	case "user_understanding":
+		return &s.UserUnderstanding
+	case "market_awareness":
+		return &s.MarketAwareness
+	case "solution_fit":
+		return &s.SolutionFit
+	case "scope_discipline":
+		return &s.ScopeDiscipline
+	case "requirements_quality":
+		return &s.RequirementsQuality
+	case "ux_coverage":
+		return &s.UXCoverage
+	case "technical_feasibility":
+		return &s.TechnicalFeasibility
+	case "metrics_quality":
+		return &s.MetricsQuality
+	case "risk_management":
+		return &s.RiskManagement
+	default:
+		return nil
+	}
+}
+
+// QualityScore returns the recorded score for a rubric category and
+// whether the category is part of the rubric.
+func QualityScore(s *QualityScores, category string) (float64, bool) {
+	if field := qualityScore(s, category); field != nil {
+		return *field, true
+	}
+	return 0, false
+}
+
+func joinNonEmpty(a, b string) string {
+	switch {
+	case a == "":
+		return b
+	case b == "":
+		return a
+	default:
+		return a + ": " + b
+	}
+}
@@ -0,0 +1,135 @@
+package prd
+
+import (
+	"testing"
+
+	"github.com/agentplexus/structured-evaluation/evaluation"
+)
+
+func newReviewTestReport() *evaluation.EvaluationReport {
+	r := evaluation.NewEvaluationReport("prd", "PRD.json")
+	r.Metadata.DocumentID = "PRD-2026-001"
+	r.AddCategory(evaluation.NewCategoryScore("problem_definition", 0.5, 8, "Clear problem"))
+	r.AddCategory(evaluation.NewCategoryScore("metrics_quality", 0.5, 4, "No baselines"))
+	r.AddFinding(evaluation.Finding{
+		ID:             "F-1",
+		Category:       "metrics_quality",
+		Severity:       evaluation.SeverityHigh,
+		Title:          "Metrics lack baselines",
+		Recommendation: "Add current values",
+	})
+	r.AddFinding(evaluation.Finding{ID: "F-2", Category: "problem_definition", Severity: evaluation.SeverityLow, Title: "Impact is qualitative", Owner: "pm"})
+	r.AddFinding(evaluation.Finding{ID: "F-3", Category: "problem_definition", Severity: evaluation.SeverityInfo, Title: "Nice framing"})
+	r.Finalize("prdtool eval deterministic")
+	return r
+}
+
+func TestRecordReview(t *testing.T) {
+	p := New("PRD-2026-001", "Review Test PRD", Person{Name: "Owner"})
+
+	if err := RecordReview(p, newReviewTestReport()); err != nil {
+		t.Fatalf("RecordReview failed: %v", err)
+	}
+
+	r := p.Reviews
+	if r == nil || r.QualityScores == nil {
+		t.Fatal("expected reviews with quality scores")
+	}
+	if r.QualityScores.ProblemDefinition != 8 || r.QualityScores.MetricsQuality != 4 {
+		t.Errorf("unexpected category scores: %+v", r.QualityScores)
+	}
+	if r.QualityScores.OverallScore != 6 {
+		t.Errorf("expected overall score 6, got %f", r.QualityScores.OverallScore)
+	}
+	if r.Decision != ReviewReject {
+		t.Errorf("expected reject for a high severity finding, got %s", r.Decision)
+	}
+
+	if len(r.Blockers) != 1 || r.Blockers[0].ID != "F-1" {
+		t.Errorf("expected F-1 as the only blocker, got %+v", r.Blockers)
+	}
+	if len(r.RevisionTriggers) != 2 {
+		t.Fatalf("expected 2 revision triggers, got %+v", r.RevisionTriggers)
+	}
+	if got := r.RevisionTriggers[0]; got.Severity != "major" || got.Description != "Metrics lack baselines: Add current values" {
+		t.Errorf("unexpected trigger: %+v", got)
+	}
+	if got := r.RevisionTriggers[1]; got.Severity != "minor" || got.RecommendedOwner != "pm" {
+		t.Errorf("unexpected trigger: %+v", got)
+	}
+
+	record, err := GetReviewRecord(p)
+	if err != nil || record == nil {
+		t.Fatalf("expected a review record, got %v %v", record, err)
+	}
+	if record.ReviewedAt.IsZero() || len(record.Categories) != 2 || record.Categories[1] != "metrics_quality" {
+		t.Errorf("unexpected review record: %+v", record)
+	}
+	if current, err := ReviewIsCurrent(p); err != nil || !current {
+		t.Errorf("expected the review to be current, got %v %v", current, err)
+	}
+
+	// Workflow changes keep the review current; content changes don't.
+	p.Metadata.Status = StatusInReview
+	if current, _ := ReviewIsCurrent(p); !current {
+		t.Error("expected a status change to keep the review current")
+	}
+	p.Metadata.Title = "Renamed PRD"
+	if current, _ := ReviewIsCurrent(p); current {
+		t.Error("expected a title change to make the review stale")
+	}
+}
+
+func TestRecordReviewPendingCategories(t *testing.T) {
+	p := New("PRD-2026-001", "Review Test PRD", Person{Name: "Owner"})
+
+	report := newReviewTestReport()
+	report.Categories = append(report.Categories, evaluation.CategoryScore{Category: "solution_fit", Status: evaluation.CategoryPending})
+	if err := RecordReview(p, report); err != nil {
+		t.Fatalf("RecordReview failed: %v", err)
+	}
+
+	scores := p.Reviews.QualityScores
+	if scores.ProblemDefinition != 8 || scores.MetricsQuality != 4 {
+		t.Errorf("unexpected category scores: %+v", scores)
+	}
+	if scores.SolutionFit != 0 {
+		t.Errorf("expected the pending category to be left unscored, got %f", scores.SolutionFit)
+	}
+	record, err := GetReviewRecord(p)
+	if err != nil || record == nil {
+		t.Fatalf("expected a review record, got %v %v", record, err)
+	}
+	for _, c := range record.Categories {
+		if c == "solution_fit" {
+			t.Errorf("expected the pending category not to be recorded, got %v", record.Categories)
+		}
+	}
+}
+
+func TestRecordReviewErrors(t *testing.T) {
+	p := New("PRD-2026-002", "Review Test PRD", Person{Name: "Owner"})
+
+	if err := RecordReview(p, newReviewTestReport()); err == nil {
+		t.Error("expected error for a report about another PRD")
+	}
+
+	unfinished := evaluation.NewEvaluationReport("prd", "PRD.json")
+	if err := RecordReview(p, unfinished); err == nil {
+		t.Error("expected error for a report without a decision")
+	}
+	if p.Reviews != nil {
+		t.Error("expected reviews to be left unchanged")
+	}
+}
+
+func TestQualityScore(t *testing.T) {
+	s := &QualityScores{UXCoverage: 7.5}
+
+	if score, ok := QualityScore(s, "ux_coverage"); !ok || score != 7.5 {
+		t.Errorf("expected 7.5, got %f %v", score, ok)
+	}
+	if _, ok := QualityScore(s, "vibes"); ok {
+		t.Error("expected unknown category to be reported")
+	}
+}
@@ -0,0 +1,235 @@
+package prd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SignOffDecision is an approver's verdict on a PRD.
+type SignOffDecision string
+
+const (
+	SignOffApproved SignOffDecision = "approved"
+	SignOffRejected SignOffDecision = "rejected"
+)
+
+// SignOff records an approver's decision on one version of a PRD's
+// content. Sign-offs are stored in the "sign-offs" custom section, oldest
+// first; an approver's latest sign-off is the one that counts.
+type SignOff struct {
+	Approver string          `json:"approver"`
+	Decision SignOffDecision `json:"decision"`
+	Comment  string          `json:"comment,omitempty"`
+	// ContentHash is the ContentHash of the PRD when it was signed off.
+	ContentHash string    `json:"contentHash"`
+	Version     string    `json:"version,omitempty"`
+	SignedAt    time.Time `json:"signedAt"`
+}
+
+// ApprovalState is where an approver stands on the current content.
+type ApprovalState string
+
+const (
+	// ApprovalPending means the approver has not signed off.
+	ApprovalPending ApprovalState = "pending"
+	// ApprovalApproved means the approver approved the current content.
+	ApprovalApproved ApprovalState = "approved"
+	// ApprovalRejected means the approver rejected the current content.
+	ApprovalRejected ApprovalState = "rejected"
+	// ApprovalStale means the approver signed off on content that has
+	// since changed, and must sign off again.
+	ApprovalStale ApprovalState = "stale"
+)
+
+// ApproverStatus is an approver's state with their latest sign-off.
+type ApproverStatus struct {
+	Name    string        `json:"name"`
+	Role    string        `json:"role,omitempty"`
+	State   ApprovalState `json:"state"`
+	SignOff *SignOff      `json:"signOff,omitempty"`
+}
+
+// contentExcluded lists the metadata fields left out of ContentHash.
+var contentExcluded = []string{"status", "version", "createdAt", "updatedAt", "authors", "reviewers", "approvers"}
+
+// signOffExcluded lists the custom sections left out of ContentHash: they
+// annotate the PRD rather than being part of its content.
+var signOffExcluded = map[string]bool{
+	SectionSignOffs:     true,
+	SectionComments:     true,
+	SectionTestCoverage: true,
+	SectionReview:       true,
+}
+
+// ContentHash returns a hash of the PRD's material content. It leaves
+// out the workflow fields of the metadata (status, version, timestamps
+// and people), the recorded review and its record, and the sign-off,
+// comment and test coverage sections, so changing those doesn't invalidate
+// sign-offs or reviews.
+//
+// The approvers' names are content: who must approve is part of what is
+// approved, so an approver added after the others signed off makes their
+// sign-offs stale. Their roles and approval state are not.
+func ContentHash(p *PRD) (string, error) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode PRD: %w", err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return "", fmt.Errorf("failed to decode PRD: %w", err)
+	}
+
+	delete(doc, "reviews")
+	if metadata, ok := doc["metadata"].(map[string]interface{}); ok {
+		for _, field := range contentExcluded {
+			delete(metadata, field)
+		}
+		if len(p.Metadata.Approvers) > 0 {
+			names := make([]string, len(p.Metadata.Approvers))
+			for i, a := range p.Metadata.Approvers {
+				names[i] = a.Name
+			}
+			metadata["approvers"] = names
+		}
+	}
+	if sections, ok := doc["customSections"].([]interface{}); ok {
+		var kept []interface{}
+		for _, s := range sections {
+			if cs, ok := s.(map[string]interface{}); ok && signOffExcluded[fmt.Sprint(cs["id"])] {
+				continue
+			}
+			kept = append(kept, s)
+		}
+		if len(kept) == 0 {
+			delete(doc, "customSections")
+		} else {
+			doc["customSections"] = kept
+		}
+	}
+
+	// Maps are encoded with sorted keys, so equal content hashes equally.
+	canonical, err := json.Marshal(doc)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode PRD: %w", err)
+	}
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// GetSignOffs returns the sign-offs recorded in the PRD, oldest first.
+func GetSignOffs(p *PRD) ([]SignOff, error) {
+	var signOffs []SignOff
+	if _, err := DecodeCustomSection(p, SectionSignOffs, &signOffs); err != nil {
+		return nil, err
+	}
+	return signOffs, nil
+}
+
+// RecordSignOff records an approver's decision on the PRD's current
+// content. The approver must be listed in the metadata; the error for
+// anyone else names the listed approvers. The approver's Approved flag
+// mirrors the decision.
+func RecordSignOff(p *PRD, approver string, decision SignOffDecision, comment string) (SignOff, error) {
+	approver = strings.TrimSpace(approver)
+	if approver == "" {
+		return SignOff{}, fmt.Errorf("approver is required")
+	}
+	if decision != SignOffApproved && decision != SignOffRejected {
+		return SignOff{}, fmt.Errorf("invalid decision %q (valid: %s, %s)", decision, SignOffApproved, SignOffRejected)
+	}
+
+	a := findApprover(p, approver)
+	if a == nil {
+		return SignOff{}, notAnApprover(p, approver)
+	}
+
+	signOffs, err := GetSignOffs(p)
+	if err != nil {
+		return SignOff{}, err
+	}
+	hash, err := ContentHash(p)
+	if err != nil {
+		return SignOff{}, err
+	}
+
+	s := SignOff{
+		Approver:    approver,
+		Decision:    decision,
+		Comment:     strings.TrimSpace(comment),
+		ContentHash: hash,
+		Version:     p.Metadata.Version,
+		SignedAt:    time.Now().UTC(),
+	}
+
+	a.Approved = decision == SignOffApproved
+	a.ApprovedAt = nil
+	if a.Approved {
+		signedAt := s.SignedAt
+		a.ApprovedAt = &signedAt
+	}
+	a.Comments = s.Comment
+
+	SetCustomSection(p, SectionSignOffs, "Sign-offs", append(signOffs, s))
+	return s, nil
+}
+
+// notAnApprover reports that name isn't one of the PRD's approvers.
+func notAnApprover(p *PRD, name string) error {
+	if len(p.Metadata.Approvers) == 0 {
+		return fmt.Errorf("%s is not an approver: the PRD lists no approvers in metadata.approvers", name)
+	}
+	names := make([]string, len(p.Metadata.Approvers))
+	for i, a := range p.Metadata.Approvers {
+		names[i] = a.Name
+	}
+	return fmt.Errorf("%s is not an approver (approvers: %s)", name, strings.Join(names, ", "))
+}
+
+// ApprovalStatus returns the state of every approver listed in the
+// metadata, in order, judged against the PRD's current content.
+func ApprovalStatus(p *PRD) ([]ApproverStatus, error) {
+	signOffs, err := GetSignOffs(p)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := ContentHash(p)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]ApproverStatus, 0, len(p.Metadata.Approvers))
+	for _, a := range p.Metadata.Approvers {
+		status := ApproverStatus{Name: a.Name, Role: a.Role, State: ApprovalPending}
+		for i := len(signOffs) - 1; i >= 0; i-- {
+			if strings.EqualFold(signOffs[i].Approver, a.Name) {
+				status.SignOff = &signOffs[i]
+				break
+			}
+		}
+		switch {
+		case status.SignOff == nil:
+		case status.SignOff.ContentHash != hash:
+			status.State = ApprovalStale
+		case status.SignOff.Decision == SignOffApproved:
+			status.State = ApprovalApproved
+		default:
+			status.State = ApprovalRejected
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func findApprover(p *PRD, name string) *Approver {
+	for i := range p.Metadata.Approvers {
+		if strings.EqualFold(p.Metadata.Approvers[i].Name, name) {
+			return &p.Metadata.Approvers[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package prd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestContentHash(t *testing.T) {
+	p := New("PRD-2026-001", "Test PRD", Person{Name: "Owner"})
+	before, err := ContentHash(p)
+	if err != nil {
+		t.Fatalf("ContentHash failed: %v", err)
+	}
+
+	// Workflow changes and annotations don't change the content.
+	p.Metadata.Status = StatusInReview
+	p.Metadata.Version = "1.1.0"
+	if _, err := AddComment(p, "Alice", "Looks good", ""); err != nil {
+		t.Fatal(err)
+	}
+	if after, _ := ContentHash(p); after != before {
+		t.Error("expected workflow changes to keep the content hash")
+	}
+
+	// Who must approve is content; their roles and approvals are not.
+	p.Metadata.Approvers = []Approver{{Name: "Alice"}}
+	withApprovers, _ := ContentHash(p)
+	if withApprovers == before {
+		t.Error("expected adding an approver to change the content hash")
+	}
+	p.Metadata.Approvers[0].Role = "Eng Lead"
+	if _, err := RecordSignOff(p, "Alice", SignOffApproved, ""); err != nil {
+		t.Fatal(err)
+	}
+	if after, _ := ContentHash(p); after != withApprovers {
+		t.Error("expected approver roles and sign-offs to keep the content hash")
+	}
+
+	p.Metadata.Title = "Renamed PRD"
+	if after, _ := ContentHash(p); after == before {
+		t.Error("expected a title change to change the content hash")
+	}
+}
+
+func TestRecordSignOff(t *testing.T) {
+	p := New("PRD-2026-001", "Test PRD", Person{Name: "Owner"})
+	p.Metadata.Approvers = []Approver{{Name: "Alice", Role: "Eng Lead"}}
+
+	s, err := RecordSignOff(p, "alice", SignOffApproved, "Ship it")
+	if err != nil {
+		t.Fatalf("RecordSignOff failed: %v", err)
+	}
+	if s.ContentHash == "" || s.SignedAt.IsZero() || s.Version != p.Metadata.Version {
+		t.Errorf("unexpected sign-off: %+v", s)
+	}
+	a := p.Metadata.Approvers[0]
+	if !a.Approved || a.ApprovedAt == nil || a.Comments != "Ship it" {
+		t.Errorf("expected the approver to mirror the sign-off, got %+v", a)
+	}
+
+	// An unlisted approver is rejected, and the error names the listed ones.
+	if _, err := RecordSignOff(p, "Mallory", SignOffApproved, ""); err == nil || !strings.Contains(err.Error(), "approvers: Alice") {
+		t.Errorf("expected an error listing the approvers, got %v", err)
+	}
+	if len(p.Metadata.Approvers) != 1 {
+		t.Errorf("expected Mallory not to be added, got %+v", p.Metadata.Approvers)
+	}
+
+	p.Metadata.Approvers = append(p.Metadata.Approvers, Approver{Name: "Bob", Approved: true})
+	if _, err := RecordSignOff(p, "Bob", SignOffRejected, "No metrics"); err != nil {
+		t.Fatalf("RecordSignOff failed: %v", err)
+	}
+	if p.Metadata.Approvers[1].Approved {
+		t.Errorf("expected the rejection to clear Bob's approval, got %+v", p.Metadata.Approvers[1])
+	}
+
+	if _, err := RecordSignOff(p, " ", SignOffApproved, ""); err == nil {
+		t.Error("expected error for missing approver")
+	}
+	if _, err := RecordSignOff(p, "Alice", "maybe", ""); err == nil {
+		t.Error("expected error for invalid decision")
+	}
+
+	// Sign-offs survive a JSON round trip.
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var loaded PRD
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	signOffs, err := GetSignOffs(&loaded)
+	if err != nil {
+		t.Fatalf("GetSignOffs failed: %v", err)
+	}
+	if len(signOffs) != 2 || signOffs[1].Decision != SignOffRejected {
+		t.Errorf("unexpected sign-offs after round trip: %+v", signOffs)
+	}
+}
+
+func TestAddedApproverMakesSignOffsStale(t *testing.T) {
+	p := New("PRD-2026-001", "Test PRD", Person{Name: "Owner"})
+	p.Metadata.Approvers = []Approver{{Name: "Alice"}}
+	if _, err := RecordSignOff(p, "Alice", SignOffApproved, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// Someone who adds themselves as an approver and signs off doesn't
+	// complete the approval: Alice hasn't approved the new list.
+	p.Metadata.Approvers = append(p.Metadata.Approvers, Approver{Name: "Mallory"})
+	if _, err := RecordSignOff(p, "Mallory", SignOffApproved, ""); err != nil {
+		t.Fatal(err)
+	}
+	statuses, err := ApprovalStatus(p)
+	if err != nil {
+		t.Fatalf("ApprovalStatus failed: %v", err)
+	}
+	if statuses[0].State != ApprovalStale || statuses[1].State != ApprovalApproved {
+		t.Errorf("expected Alice's sign-off to be stale, got %+v", statuses)
+	}
+	if passed, _ := SignOffGate().Check(p); passed {
+		t.Error("expected the sign-off gate to wait for Alice")
+	}
+}
+
+func TestApprovalStatus(t *testing.T) {
+	p := New("PRD-2026-001", "Test PRD", Person{Name: "Owner"})
+	p.Metadata.Approvers = []Approver{{Name: "Alice"}, {Name: "Bob"}, {Name: "Carol"}}
+
+	RecordSignOff(p, "Alice", SignOffRejected, "Missing metrics")
+	RecordSignOff(p, "Alice", SignOffApproved, "")
+	RecordSignOff(p, "Bob", SignOffRejected, "")
+
+	states := func() []ApprovalState {
+		statuses, err := ApprovalStatus(p)
+		if err != nil {
+			t.Fatalf("ApprovalStatus failed: %v", err)
+		}
+		var got []ApprovalState
+		for _, s := range statuses {
+			got = append(got, s.State)
+		}
+		return got
+	}
+
+	got := states()
+	want := []ApprovalState{ApprovalApproved, ApprovalRejected, ApprovalPending}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+
+	// A material change makes every sign-off stale.
+	p.Metadata.Title = "Renamed PRD"
+	got = states()
+	want = []ApprovalState{ApprovalStale, ApprovalStale, ApprovalPending}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v after a change, got %v", want, got)
+		}
+	}
+
+	passed, message := SignOffGate().Check(p)
+	if passed || message != "waiting for Alice (stale), Bob (stale), Carol" {
+		t.Errorf("unexpected sign-off gate result: %v %q", passed, message)
+	}
+}
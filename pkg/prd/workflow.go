@@ -0,0 +1,176 @@
+package prd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// transitions lists the legal status changes. A PRD under review can be
+// sent back to draft, and an approved PRD can be reopened for review;
+// deprecated is final.
+var transitions = map[Status][]Status{
+	StatusDraft:    {StatusInReview},
+	StatusInReview: {StatusDraft, StatusApproved},
+	StatusApproved: {StatusInReview, StatusDeprecated},
+}
+
+// Transitions returns the statuses a PRD can move to from status.
+func Transitions(from Status) []Status {
+	return append([]Status(nil), transitions[from]...)
+}
+
+// Gate is a condition a PRD must meet to enter a status.
+type Gate struct {
+	// Name identifies the gate in results, e.g. "score".
+	Name string
+
+	// Check reports whether p passes, with a message explaining the
+	// result.
+	Check func(p *PRD) (passed bool, message string)
+}
+
+// GateResult is the outcome of checking one gate.
+type GateResult struct {
+	Gate    string `json:"gate"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message,omitempty"`
+}
+
+// Workflow is the status state machine with the gates checked when a PRD
+// enters each status. The zero value allows every legal transition
+// without gates.
+type Workflow struct {
+	// Gates are keyed by the status they guard.
+	Gates map[Status][]Gate
+}
+
+// TransitionError reports a status change that is not allowed, either
+// because the transition is illegal or because gates failed.
+type TransitionError struct {
+	From Status
+	To   Status
+
+	// Failed lists the gates that failed. It is empty when the
+	// transition itself is illegal.
+	Failed []GateResult
+}
+
+func (e *TransitionError) Error() string {
+	if len(e.Failed) > 0 {
+		reasons := make([]string, len(e.Failed))
+		for i, r := range e.Failed {
+			reasons[i] = r.Gate + ": " + r.Message
+		}
+		return fmt.Sprintf("cannot move from %s to %s: %s", e.From, e.To, strings.Join(reasons, "; "))
+	}
+	if e.From == e.To {
+		return fmt.Sprintf("status is already %s", e.To)
+	}
+	next := transitions[e.From]
+	if len(next) == 0 {
+		return fmt.Sprintf("cannot move from %s to %s: %s is final", e.From, e.To, e.From)
+	}
+	return fmt.Sprintf("cannot move from %s to %s (allowed: %s)", e.From, e.To, strings.Join(enumStrings(next), ", "))
+}
+
+// Check checks whether p can move to status to, without changing it. It
+// returns the results of every gate guarding to, and a *TransitionError
+// if the transition is illegal or a gate failed.
+func (w *Workflow) Check(p *PRD, to Status) ([]GateResult, error) {
+	return w.check(p.Metadata.Status, p, to)
+}
+
+// Transition moves p to status to if Check allows it.
+func (w *Workflow) Transition(p *PRD, to Status) ([]GateResult, error) {
+	results, err := w.Check(p, to)
+	if err != nil {
+		return results, err
+	}
+	UpdateStatus(p, to)
+	return results, nil
+}
+
+// CheckChange checks an edit, such as a patch, that may have changed the
+// status from before's to after's. The gates are checked against after.
+// It returns nil results and no error if the status is unchanged.
+func (w *Workflow) CheckChange(before, after *PRD) ([]GateResult, error) {
+	if before.Metadata.Status == after.Metadata.Status {
+		return nil, nil
+	}
+	return w.check(before.Metadata.Status, after, after.Metadata.Status)
+}
+
+func (w *Workflow) check(from Status, p *PRD, to Status) ([]GateResult, error) {
+	legal := false
+	for _, next := range transitions[from] {
+		if next == to {
+			legal = true
+			break
+		}
+	}
+	if !legal {
+		return nil, &TransitionError{From: from, To: to}
+	}
+
+	var results, failed []GateResult
+	for _, gate := range w.Gates[to] {
+		passed, message := gate.Check(p)
+		result := GateResult{Gate: gate.Name, Passed: passed, Message: message}
+		results = append(results, result)
+		if !passed {
+			failed = append(failed, result)
+		}
+	}
+	if len(failed) > 0 {
+		return results, &TransitionError{From: from, To: to, Failed: failed}
+	}
+	return results, nil
+}
+
+// ValidGate passes when the PRD has no validation errors.
+func ValidGate() Gate {
+	return Gate{
+		Name: "valid",
+		Check: func(p *PRD) (bool, string) {
+			result := Validate(p)
+			if len(result.Errors) == 0 {
+				return true, "no validation errors"
+			}
+			return false, fmt.Sprintf("%d validation errors, e.g. %s: %s",
+				len(result.Errors), result.Errors[0].Field, result.Errors[0].Message)
+		},
+	}
+}
+
+// SignOffGate passes when every approver listed in the metadata has
+// approved the PRD's current content (see RecordSignOff). A sign-off on
+// content that has since changed doesn't count. A PRD without approvers
+// passes.
+func SignOffGate() Gate {
+	return Gate{
+		Name: "sign-off",
+		Check: func(p *PRD) (bool, string) {
+			if len(p.Metadata.Approvers) == 0 {
+				return true, "no approvers required"
+			}
+			statuses, err := ApprovalStatus(p)
+			if err != nil {
+				return false, err.Error()
+			}
+			var pending []string
+			for _, s := range statuses {
+				switch s.State {
+				case ApprovalApproved:
+				case ApprovalPending:
+					pending = append(pending, s.Name)
+				default:
+					pending = append(pending, fmt.Sprintf("%s (%s)", s.Name, s.State))
+				}
+			}
+			if len(pending) > 0 {
+				return false, "waiting for " + strings.Join(pending, ", ")
+			}
+			return true, fmt.Sprintf("signed off by all %d approvers", len(p.Metadata.Approvers))
+		},
+	}
+}
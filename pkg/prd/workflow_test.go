@@ -0,0 +1,129 @@
+package prd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTransitions(t *testing.T) {
+	tests := []struct {
+		from, to Status
+		legal    bool
+	}{
+		{StatusDraft, StatusInReview, true},
+		{StatusDraft, StatusApproved, false},
+		{StatusDraft, StatusDeprecated, false},
+		{StatusInReview, StatusApproved, true},
+		{StatusInReview, StatusDraft, true},
+		{StatusApproved, StatusDeprecated, true},
+		{StatusApproved, StatusInReview, true},
+		{StatusApproved, StatusApproved, false},
+		{StatusDeprecated, StatusDraft, false},
+	}
+
+	var w Workflow
+	for _, tt := range tests {
+		p := New("PRD-2026-001", "Workflow Test", Person{Name: "Owner"})
+		p.Metadata.Status = tt.from
+
+		_, err := w.Transition(p, tt.to)
+		if tt.legal {
+			if err != nil || p.Metadata.Status != tt.to {
+				t.Errorf("%s -> %s: expected the change, got %v", tt.from, tt.to, err)
+			}
+			continue
+		}
+		var terr *TransitionError
+		if !errors.As(err, &terr) || len(terr.Failed) != 0 {
+			t.Errorf("%s -> %s: expected an illegal transition, got %v", tt.from, tt.to, err)
+		}
+		if p.Metadata.Status != tt.from {
+			t.Errorf("%s -> %s: status changed to %s", tt.from, tt.to, p.Metadata.Status)
+		}
+	}
+}
+
+func TestTransitionErrorMessages(t *testing.T) {
+	tests := []struct {
+		err  *TransitionError
+		want string
+	}{
+		{&TransitionError{From: StatusDraft, To: StatusApproved}, "cannot move from draft to approved (allowed: in_review)"},
+		{&TransitionError{From: StatusDeprecated, To: StatusDraft}, "cannot move from deprecated to draft: deprecated is final"},
+		{&TransitionError{From: StatusDraft, To: StatusDraft}, "status is already draft"},
+		{&TransitionError{From: StatusInReview, To: StatusApproved, Failed: []GateResult{
+			{Gate: "score", Message: "5.0 is below 8.0"},
+			{Gate: "sign-off", Message: "waiting for Bob"},
+		}}, "cannot move from in_review to approved: score: 5.0 is below 8.0; sign-off: waiting for Bob"},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("expected %q, got %q", tt.want, got)
+		}
+	}
+}
+
+func TestWorkflowGates(t *testing.T) {
+	p := New("PRD-2026-001", "Workflow Test", Person{Name: "Owner"})
+	p.Metadata.Status = StatusInReview
+	p.Metadata.Approvers = []Approver{{Name: "Alice"}, {Name: "Bob", Approved: true}}
+	if _, err := RecordSignOff(p, "Alice", SignOffApproved, ""); err != nil {
+		t.Fatalf("RecordSignOff failed: %v", err)
+	}
+
+	w := &Workflow{Gates: map[Status][]Gate{
+		StatusApproved: {ValidGate(), SignOffGate()},
+	}}
+
+	results, err := w.Transition(p, StatusApproved)
+	var terr *TransitionError
+	if !errors.As(err, &terr) {
+		t.Fatalf("expected a TransitionError, got %v", err)
+	}
+	if len(results) != 2 || !results[0].Passed || results[1].Passed {
+		t.Errorf("expected valid to pass and sign-off to fail, got %+v", results)
+	}
+	if len(terr.Failed) != 1 || terr.Failed[0].Message != "waiting for Bob" {
+		t.Errorf("unexpected failed gates: %+v", terr.Failed)
+	}
+	if p.Metadata.Status != StatusInReview {
+		t.Errorf("status changed to %s", p.Metadata.Status)
+	}
+
+	if _, err := RecordSignOff(p, "Bob", SignOffApproved, ""); err != nil {
+		t.Fatalf("RecordSignOff failed: %v", err)
+	}
+	if _, err := w.Transition(p, StatusApproved); err != nil {
+		t.Fatalf("expected the approval to pass, got %v", err)
+	}
+	if p.Metadata.Status != StatusApproved {
+		t.Errorf("expected approved, got %s", p.Metadata.Status)
+	}
+}
+
+func TestValidGate(t *testing.T) {
+	p := New("PRD-2026-001", "Tiny", Person{Name: "Owner"})
+	passed, message := ValidGate().Check(p)
+	if passed || !strings.Contains(message, "metadata.title") {
+		t.Errorf("expected the short title to fail, got %v %q", passed, message)
+	}
+}
+
+func TestCheckChange(t *testing.T) {
+	w := &Workflow{Gates: map[Status][]Gate{StatusApproved: {SignOffGate()}}}
+	before := New("PRD-2026-001", "Workflow Test", Person{Name: "Owner"})
+	before.Metadata.Status = StatusInReview
+
+	after := *before
+	after.Metadata.Title = "Renamed"
+	if results, err := w.CheckChange(before, &after); err != nil || results != nil {
+		t.Errorf("expected no check without a status change, got %v, %v", results, err)
+	}
+
+	after.Metadata.Status = StatusApproved
+	after.Metadata.Approvers = []Approver{{Name: "Bob"}}
+	if _, err := w.CheckChange(before, &after); err == nil || !strings.Contains(err.Error(), "waiting for Bob") {
+		t.Errorf("expected the sign-off gate to fail, got %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package scoring
+
+import (
+	"fmt"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+)
+
+var categoryNames = map[string]string{
+	"problem_definition":    "Problem Definition",
+	"user_understanding":    "User Understanding",
+	"market_awareness":      "Market Awareness",
+	"solution_fit":          "Solution Fit",
+	"scope_discipline":      "Scope Discipline",
+	"requirements_quality":  "Requirements Quality",
+	"ux_coverage":           "UX Coverage",
+	"technical_feasibility": "Technical Feasibility",
+	"metrics_quality":       "Metrics Quality",
+	"risk_management":       "Risk Management",
+}
+
+// CategoryName returns the display name for a scoring category ID,
+// or the ID itself if the category is unknown.
+func CategoryName(category string) string {
+	if name, ok := categoryNames[category]; ok {
+		return name
+	}
+	return category
+}
+
+// IsCategory reports whether id is one of the rubric's scoring categories.
+func IsCategory(id string) bool {
+	_, ok := categoryNames[id]
+	return ok
+}
+
+// ValidateWeights checks that every key is a rubric category and every
+// weight is between 0 and 1.
+func ValidateWeights(weights map[string]float64) error {
+	for category, weight := range weights {
+		if !IsCategory(category) {
+			return fmt.Errorf("unknown scoring category: %s", category)
+		}
+		if weight < 0 || weight > 1 {
+			return fmt.Errorf("weight for %s must be between 0 and 1, got %g", category, weight)
+		}
+	}
+	return nil
+}
+
+// Category describes a rubric category for judges and reviewers.
+type Category struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Weight      float64 `json:"weight"`
+	Description string  `json:"description,omitempty"`
+	Owner       string  `json:"owner,omitempty"`
+}
+
+// Categories returns the rubric categories in weight order, with their
+// default weights, descriptions and suggested owners.
+func Categories() []Category {
+	descriptions := prd.CategoryDescriptions()
+	owners := prd.CategoryOwners()
+
+	weights := DefaultWeights()
+	categories := make([]Category, 0, len(weights))
+	for _, w := range weights {
+		categories = append(categories, Category{
+			ID:          w.Category,
+			Name:        CategoryName(w.Category),
+			Weight:      w.Weight,
+			Description: descriptions[w.Category],
+			Owner:       owners[w.Category],
+		})
+	}
+	return categories
+}
@@ -0,0 +1,35 @@
+package scoring
+
+import (
+	"fmt"
+
+	"github.com/agentplexus/agent-team-prd/pkg/coverage"
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+)
+
+// coverageIssuePrefix prefixes the requirement ID in the issue ID of a
+// coverage revision trigger.
+const coverageIssuePrefix = "COV-"
+
+// addCoverageTriggers adds revision triggers for must-have requirements that
+// have no linked tests, based on the coverage recorded by `prdtool coverage --write`.
+// PRDs that have never been scanned are left untouched.
+func addCoverageTriggers(p *prd.PRD, result *ScoringResult) {
+	report, err := coverage.FromPRD(p)
+	if err != nil || report == nil {
+		return
+	}
+
+	for _, req := range report.UncoveredRequirements() {
+		if req.Priority != prd.MoSCoWMust {
+			continue
+		}
+		result.RevisionTriggers = append(result.RevisionTriggers, RevisionItem{
+			IssueID:          coverageIssuePrefix + req.ID,
+			Category:         "requirements_quality",
+			Severity:         "minor",
+			Description:      fmt.Sprintf("Must-have requirement %s has no linked tests", req.ID),
+			RecommendedOwner: "qa",
+		})
+	}
+}
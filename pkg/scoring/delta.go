@@ -0,0 +1,54 @@
+package scoring
+
+import (
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+)
+
+// Delta is the change in score between two versions of a PRD.
+type Delta struct {
+	Before         float64         `json:"before"`
+	After          float64         `json:"after"`
+	Change         float64         `json:"change"`
+	DecisionBefore string          `json:"decisionBefore,omitempty"`
+	DecisionAfter  string          `json:"decisionAfter"`
+	Categories     []CategoryDelta `json:"categories,omitempty"`
+}
+
+// CategoryDelta is the change in a single category's score.
+type CategoryDelta struct {
+	Category string  `json:"category"`
+	Before   float64 `json:"before"`
+	After    float64 `json:"after"`
+}
+
+// Compare scores both PRDs and returns the difference. Only categories whose
+// score changed are listed. A nil before scores zero, as for a new PRD.
+func Compare(before, after *prd.PRD) Delta {
+	beforeScores := make(map[string]float64)
+	var delta Delta
+	if before != nil {
+		result := Score(before)
+		delta.Before = result.WeightedScore
+		delta.DecisionBefore = result.Decision
+		for _, cat := range result.CategoryScores {
+			beforeScores[cat.Category] = cat.Score
+		}
+	}
+
+	result := Score(after)
+	delta.After = result.WeightedScore
+	delta.DecisionAfter = result.Decision
+	delta.Change = delta.After - delta.Before
+
+	for _, cat := range result.CategoryScores {
+		if prev := beforeScores[cat.Category]; prev != cat.Score {
+			delta.Categories = append(delta.Categories, CategoryDelta{
+				Category: cat.Category,
+				Before:   prev,
+				After:    cat.Score,
+			})
+		}
+	}
+
+	return delta
+}
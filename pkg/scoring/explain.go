@@ -0,0 +1,140 @@
+package scoring
+
+import (
+	"strings"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+)
+
+// Check is a check Score failed a category on: one of its revision
+// triggers, such as a category scoring below the revise threshold or a
+// must-have requirement without linked tests.
+type Check struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	Severity    string `json:"severity"`
+	Owner       string `json:"owner,omitempty"`
+
+	// Paths are JSON Pointers to the content the check is about: the
+	// requirement without tests, or the sections the category scores. They
+	// can be used directly with `prdtool patch`.
+	Paths []string `json:"paths,omitempty"`
+}
+
+// CategoryExplanation is Score's result for a category with the checks it
+// failed (see Explain).
+type CategoryExplanation struct {
+	Category      string  `json:"category"`
+	Name          string  `json:"name"`
+	Weight        float64 `json:"weight"`
+	Score         float64 `json:"score"`
+	MaxScore      float64 `json:"maxScore"`
+	Justification string  `json:"justification,omitempty"`
+
+	// Passed is set when the category scores at least the revise
+	// threshold.
+	Passed bool    `json:"passed"`
+	Checks []Check `json:"checks"`
+}
+
+// Explain scores the PRD and lists, for each category, its score, whether
+// it passed, and the checks it failed with where the content they are
+// about is.
+//
+// Everything comes from Score: the category scores and the revision
+// triggers it raised, including those for untested must-have requirements.
+// A category without failed checks passed all of them.
+func Explain(p *prd.PRD) []CategoryExplanation {
+	result := Score(p)
+	scored := make(map[string]CategoryScore, len(result.CategoryScores))
+	for _, cs := range result.CategoryScores {
+		scored[cs.Category] = cs
+	}
+
+	weights := make(map[string]float64)
+	for _, w := range DefaultWeights() {
+		weights[w.Category] = w.Weight
+	}
+
+	explanations := make([]CategoryExplanation, 0, len(categoryOrder))
+	index := make(map[string]int, len(categoryOrder))
+	for _, category := range categoryOrder {
+		cs, ok := scored[category]
+		e := CategoryExplanation{
+			Category:      category,
+			Name:          CategoryName(category),
+			Weight:        weights[category],
+			Score:         cs.Score,
+			MaxScore:      cs.MaxScore,
+			Justification: cs.Justification,
+			Passed:        ok && !cs.BelowThreshold,
+			Checks:        []Check{},
+		}
+		if cs.Weight > 0 {
+			e.Weight = cs.Weight
+		}
+		if e.MaxScore == 0 {
+			e.MaxScore = 10
+		}
+		index[category] = len(explanations)
+		explanations = append(explanations, e)
+	}
+
+	for _, t := range result.RevisionTriggers {
+		i, ok := index[t.Category]
+		if !ok {
+			continue
+		}
+		explanations[i].Checks = append(explanations[i].Checks, Check{
+			ID:          t.IssueID,
+			Description: t.Description,
+			Severity:    t.Severity,
+			Owner:       t.RecommendedOwner,
+			Paths:       triggerPaths(p, t),
+		})
+		explanations[i].Passed = false
+	}
+	return explanations
+}
+
+// categoryOrder is the order categories are explained in, by weight.
+var categoryOrder = []string{
+	"problem_definition",
+	"solution_fit",
+	"user_understanding",
+	"market_awareness",
+	"scope_discipline",
+	"requirements_quality",
+	"metrics_quality",
+	"ux_coverage",
+	"technical_feasibility",
+	"risk_management",
+}
+
+// categorySections are the PRD sections each category is scored on.
+var categorySections = map[string][]string{
+	"problem_definition":    {"/problem"},
+	"solution_fit":          {"/solution"},
+	"user_understanding":    {"/personas", "/userStories"},
+	"market_awareness":      {"/market"},
+	"scope_discipline":      {"/outOfScope", "/roadmap"},
+	"requirements_quality":  {"/requirements"},
+	"metrics_quality":       {"/objectives"},
+	"ux_coverage":           {"/uxRequirements"},
+	"technical_feasibility": {"/technicalArchitecture", "/assumptions"},
+	"risk_management":       {"/risks"},
+}
+
+// triggerPaths returns the JSON Pointers of the content a revision trigger
+// is about: the requirement for a coverage trigger, or otherwise the
+// sections its category is scored on.
+func triggerPaths(p *prd.PRD, t RevisionItem) []string {
+	if id, ok := strings.CutPrefix(t.IssueID, coverageIssuePrefix); ok {
+		for i, fr := range p.Requirements.Functional {
+			if fr.ID == id {
+				return []string{pointer("requirements", "functional", i)}
+			}
+		}
+	}
+	return categorySections[t.Category]
+}
@@ -0,0 +1,110 @@
+package scoring
+
+import (
+	"testing"
+
+	"github.com/agentplexus/agent-team-prd/pkg/coverage"
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+)
+
+func TestExplainCoversRubric(t *testing.T) {
+	p := prd.New("PRD-2026-001", "Test PRD", prd.Person{Name: "Owner"})
+
+	explanations := Explain(p)
+	if len(explanations) != len(categoryOrder) {
+		t.Fatalf("expected %d categories, got %d", len(categoryOrder), len(explanations))
+	}
+	for i, e := range explanations {
+		if e.Category != categoryOrder[i] || e.Name != CategoryName(e.Category) {
+			t.Errorf("unexpected category %s (%s) at %d", e.Category, e.Name, i)
+		}
+		if e.MaxScore != 10 {
+			t.Errorf("expected %s to be scored out of 10, got %f", e.Category, e.MaxScore)
+		}
+		if len(categorySections[e.Category]) == 0 {
+			t.Errorf("no sections for %s", e.Category)
+		}
+	}
+}
+
+// TestExplainMatchesScore checks that the explanation reports Score's own
+// results: its category scores, and its revision triggers as the failed
+// checks of their categories.
+func TestExplainMatchesScore(t *testing.T) {
+	for _, p := range []*prd.PRD{
+		prd.New("PRD-2026-001", "Test", prd.Person{Name: "Owner"}),
+		createWellDefinedPRD(),
+	} {
+		result := Score(p)
+		explanations := Explain(p)
+
+		for _, cs := range result.CategoryScores {
+			e := findExplanation(t, explanations, cs.Category)
+			if e.Score != cs.Score || e.Justification != cs.Justification {
+				t.Errorf("%s: expected score %.1f (%s), got %.1f (%s)", cs.Category, cs.Score, cs.Justification, e.Score, e.Justification)
+			}
+			if cs.BelowThreshold && e.Passed {
+				t.Errorf("%s: expected a category below threshold to fail", cs.Category)
+			}
+		}
+
+		checks := 0
+		for _, e := range explanations {
+			checks += len(e.Checks)
+			if len(e.Checks) > 0 && e.Passed {
+				t.Errorf("%s: expected a category with failed checks to fail", e.Category)
+			}
+		}
+		if checks != len(result.RevisionTriggers) {
+			t.Errorf("expected a check per revision trigger (%d), got %d", len(result.RevisionTriggers), checks)
+		}
+		for _, trigger := range result.RevisionTriggers {
+			check := findCheck(t, explanations, trigger.Category, trigger.IssueID)
+			if check.Description != trigger.Description || check.Severity != trigger.Severity {
+				t.Errorf("expected check %s to match its trigger, got %+v", trigger.IssueID, check)
+			}
+		}
+	}
+}
+
+func TestExplainCoverageChecks(t *testing.T) {
+	p := prd.New("PRD-2026-001", "Test PRD", prd.Person{Name: "Owner"})
+	prd.AddFunctionalRequirement(p, "Login", "Users can log in", prd.MoSCoWMust)
+	id := prd.AddFunctionalRequirement(p, "Export", "Users can export data", prd.MoSCoWMust)
+	coverage.Apply(p, coverage.Analyze(p, []coverage.Reference{{ID: p.Requirements.Functional[0].ID, File: "login_test.go", Line: 1, Kind: coverage.KindGo}}))
+
+	check := findCheck(t, Explain(p), "requirements_quality", coverageIssuePrefix+id)
+	if len(check.Paths) != 1 || check.Paths[0] != "/requirements/functional/1" {
+		t.Errorf("expected the untested requirement's path, got %v", check.Paths)
+	}
+	if e := findExplanation(t, Explain(p), "requirements_quality"); e.Passed {
+		t.Error("expected a category with failed checks to fail")
+	}
+}
+
+func findExplanation(t *testing.T, explanations []CategoryExplanation, category string) CategoryExplanation {
+	t.Helper()
+	for _, e := range explanations {
+		if e.Category == category {
+			return e
+		}
+	}
+	t.Fatalf("no explanation for %s", category)
+	return CategoryExplanation{}
+}
+
+func findCheck(t *testing.T, explanations []CategoryExplanation, category, id string) Check {
+	t.Helper()
+	for _, e := range explanations {
+		if e.Category != category {
+			continue
+		}
+		for _, c := range e.Checks {
+			if c.ID == id {
+				return c
+			}
+		}
+	}
+	t.Fatalf("check %s not found in %s", id, category)
+	return Check{}
+}
@@ -0,0 +1,212 @@
+package scoring
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/agentplexus/structured-evaluation/evaluation"
+)
+
+// Defaults for merging deterministic and LLM judge evaluations.
+const (
+	DefaultLLMWeight             = 0.5
+	DefaultDisagreementThreshold = 2.0
+)
+
+// MergeOptions controls how a deterministic evaluation and an LLM judge
+// evaluation are combined.
+type MergeOptions struct {
+	// LLMWeight is the share of each category score taken from the LLM
+	// judge, from 0 (deterministic only) to 1 (LLM only).
+	LLMWeight float64
+
+	// CategoryLLMWeights overrides LLMWeight for individual categories.
+	CategoryLLMWeights map[string]float64
+
+	// DisagreementThreshold is the score difference above which a category
+	// is flagged for review.
+	DisagreementThreshold float64
+
+	// RerunCommand is recorded in the merged report's next steps.
+	RerunCommand string
+}
+
+// DefaultMergeOptions weights both evaluations equally and flags categories
+// whose scores differ by more than two points.
+func DefaultMergeOptions() MergeOptions {
+	return MergeOptions{
+		LLMWeight:             DefaultLLMWeight,
+		DisagreementThreshold: DefaultDisagreementThreshold,
+	}
+}
+
+// Disagreement is a category where the deterministic and LLM scores differ
+// by more than the threshold.
+type Disagreement struct {
+	Category                   string  `json:"category"`
+	Deterministic              float64 `json:"deterministic"`
+	LLM                        float64 `json:"llm"`
+	Difference                 float64 `json:"difference"`
+	DeterministicJustification string  `json:"deterministic_justification,omitempty"`
+	LLMJustification           string  `json:"llm_justification,omitempty"`
+}
+
+// MergeResult is the combined evaluation and the categories to review.
+type MergeResult struct {
+	Report        *evaluation.EvaluationReport `json:"report"`
+	Disagreements []Disagreement               `json:"disagreements"`
+	LLMWeight     float64                      `json:"llm_weight"`
+	Threshold     float64                      `json:"threshold"`
+}
+
+// MergeEvaluations blends a deterministic evaluation with an LLM judge's
+// evaluation of the same PRD.
+//
+// Categories scored by both are blended using the LLM weight. Categories the
+// judge left pending, or that only one report has, are taken as they are.
+// Findings from both reports are kept, with duplicates (same category and
+// title) reduced to the most severe. The final decision comes from the
+// merged score and findings; if any category disagrees by more than the
+// threshold, a passing decision is downgraded to human review.
+func MergeEvaluations(det, llm *evaluation.EvaluationReport, opts MergeOptions) (*MergeResult, error) {
+	if det == nil || llm == nil {
+		return nil, errors.New("both a deterministic and an LLM evaluation are required")
+	}
+	if err := validateMergeOptions(opts); err != nil {
+		return nil, err
+	}
+
+	merged := &evaluation.EvaluationReport{
+		Schema:       det.Schema,
+		Metadata:     det.Metadata,
+		ReviewType:   det.ReviewType,
+		Judge:        llm.Judge,
+		RubricID:     llm.RubricID,
+		Reference:    det.Reference,
+		Categories:   []evaluation.CategoryScore{},
+		Findings:     mergeFindings(det.Findings, llm.Findings),
+		PassCriteria: det.PassCriteria,
+	}
+	merged.Metadata.GeneratedBy = "prdtool eval merge"
+	if merged.PassCriteria == (evaluation.PassCriteria{}) {
+		merged.PassCriteria = evaluation.DefaultPassCriteria()
+	}
+
+	llmCategories := make(map[string]evaluation.CategoryScore, len(llm.Categories))
+	for _, c := range llm.Categories {
+		if c.Status != evaluation.CategoryPending {
+			llmCategories[c.Category] = c
+		}
+	}
+
+	var disagreements []Disagreement
+	seen := make(map[string]bool, len(det.Categories))
+	for _, d := range det.Categories {
+		seen[d.Category] = true
+		l, ok := llmCategories[d.Category]
+		if !ok {
+			merged.AddCategory(d)
+			continue
+		}
+
+		merged.AddCategory(blendCategory(d, l, opts.llmWeight(d.Category)))
+		if diff := l.Score - d.Score; math.Abs(diff) > opts.DisagreementThreshold {
+			disagreements = append(disagreements, Disagreement{
+				Category:                   d.Category,
+				Deterministic:              d.Score,
+				LLM:                        l.Score,
+				Difference:                 diff,
+				DeterministicJustification: d.Justification,
+				LLMJustification:           l.Justification,
+			})
+		}
+	}
+	for _, l := range llm.Categories {
+		if !seen[l.Category] && l.Status != evaluation.CategoryPending {
+			merged.AddCategory(l)
+		}
+	}
+
+	// Largest disagreements first: this is the review agenda.
+	sort.SliceStable(disagreements, func(i, j int) bool {
+		return math.Abs(disagreements[i].Difference) > math.Abs(disagreements[j].Difference)
+	})
+
+	merged.Finalize(opts.RerunCommand)
+	if len(disagreements) > 0 && merged.Decision.Status != evaluation.DecisionFail && merged.Decision.Status != evaluation.DecisionHumanReview {
+		merged.Decision.Status = evaluation.DecisionHumanReview
+		merged.Decision.Passed = false
+		merged.Decision.Rationale = fmt.Sprintf("Deterministic and LLM scores disagree by more than %.1f in %d categories", opts.DisagreementThreshold, len(disagreements))
+		merged.GenerateSummary()
+	}
+
+	return &MergeResult{
+		Report:        merged,
+		Disagreements: disagreements,
+		LLMWeight:     opts.LLMWeight,
+		Threshold:     opts.DisagreementThreshold,
+	}, nil
+}
+
+func validateMergeOptions(opts MergeOptions) error {
+	if opts.LLMWeight < 0 || opts.LLMWeight > 1 {
+		return fmt.Errorf("LLM weight must be between 0 and 1, got %g", opts.LLMWeight)
+	}
+	for category, w := range opts.CategoryLLMWeights {
+		if !IsCategory(category) {
+			return fmt.Errorf("unknown scoring category: %s", category)
+		}
+		if w < 0 || w > 1 {
+			return fmt.Errorf("LLM weight for %s must be between 0 and 1, got %g", category, w)
+		}
+	}
+	if opts.DisagreementThreshold < 0 {
+		return fmt.Errorf("disagreement threshold must not be negative, got %g", opts.DisagreementThreshold)
+	}
+	return nil
+}
+
+func (o MergeOptions) llmWeight(category string) float64 {
+	if w, ok := o.CategoryLLMWeights[category]; ok {
+		return w
+	}
+	return o.LLMWeight
+}
+
+func blendCategory(det, llm evaluation.CategoryScore, llmWeight float64) evaluation.CategoryScore {
+	c := det
+	c.Score = (1-llmWeight)*det.Score + llmWeight*llm.Score
+	if c.Weight == 0 {
+		c.Weight = llm.Weight
+	}
+	if c.MaxScore == 0 {
+		c.MaxScore = llm.MaxScore
+	}
+	c.Justification = fmt.Sprintf("Deterministic (%.1f): %s LLM (%.1f): %s", det.Score, det.Justification, llm.Score, llm.Justification)
+	if llm.Evidence != "" {
+		c.Evidence = llm.Evidence
+	}
+	c.Findings = mergeFindings(det.Findings, llm.Findings)
+	return c
+}
+
+// mergeFindings keeps every finding from both lists, reducing duplicates
+// with the same category and title to the most severe.
+func mergeFindings(a, b []evaluation.Finding) []evaluation.Finding {
+	merged := []evaluation.Finding{}
+	index := make(map[string]int)
+	for _, f := range append(append([]evaluation.Finding{}, a...), b...) {
+		key := f.Category + ":" + f.Title
+		if i, ok := index[key]; ok {
+			if f.Severity.Weight() > merged[i].Severity.Weight() {
+				merged[i] = f
+			}
+			continue
+		}
+		index[key] = len(merged)
+		merged = append(merged, f)
+	}
+	return merged
+}
@@ -0,0 +1,128 @@
+package scoring
+
+import (
+	"testing"
+
+	"github.com/agentplexus/structured-evaluation/evaluation"
+)
+
+func newMergeTestReport(scores map[string]float64) *evaluation.EvaluationReport {
+	r := evaluation.NewEvaluationReport("prd", "PRD.json")
+	for _, category := range []string{"problem_definition", "solution_fit", "metrics_quality"} {
+		if score, ok := scores[category]; ok {
+			r.AddCategory(evaluation.NewCategoryScore(category, 1.0/3, score, category+" reviewed"))
+		}
+	}
+	return r
+}
+
+func TestMergeEvaluations(t *testing.T) {
+	det := newMergeTestReport(map[string]float64{"problem_definition": 8, "solution_fit": 8, "metrics_quality": 8})
+	llm := newMergeTestReport(map[string]float64{"problem_definition": 9, "solution_fit": 7, "metrics_quality": 8})
+
+	result, err := MergeEvaluations(det, llm, DefaultMergeOptions())
+	if err != nil {
+		t.Fatalf("MergeEvaluations failed: %v", err)
+	}
+
+	if len(result.Report.Categories) != 3 {
+		t.Fatalf("expected 3 categories, got %d", len(result.Report.Categories))
+	}
+	if got := result.Report.Categories[0].Score; got != 8.5 {
+		t.Errorf("expected blended problem_definition score 8.5, got %f", got)
+	}
+	if len(result.Disagreements) != 0 {
+		t.Errorf("expected no disagreements, got %v", result.Disagreements)
+	}
+	if result.Report.Decision.Status != evaluation.DecisionPass {
+		t.Errorf("expected pass, got %s", result.Report.Decision.Status)
+	}
+}
+
+func TestMergeEvaluationsDisagreement(t *testing.T) {
+	det := newMergeTestReport(map[string]float64{"problem_definition": 9, "solution_fit": 8, "metrics_quality": 9})
+	llm := newMergeTestReport(map[string]float64{"problem_definition": 9, "solution_fit": 4, "metrics_quality": 5.5})
+
+	result, err := MergeEvaluations(det, llm, DefaultMergeOptions())
+	if err != nil {
+		t.Fatalf("MergeEvaluations failed: %v", err)
+	}
+
+	if len(result.Disagreements) != 2 {
+		t.Fatalf("expected 2 disagreements, got %v", result.Disagreements)
+	}
+	first := result.Disagreements[0]
+	if first.Category != "solution_fit" || first.Difference != -4 {
+		t.Errorf("expected largest disagreement first, got %+v", first)
+	}
+	if result.Report.Decision.Status != evaluation.DecisionHumanReview {
+		t.Errorf("expected human_review, got %s", result.Report.Decision.Status)
+	}
+}
+
+func TestMergeEvaluationsWeights(t *testing.T) {
+	det := newMergeTestReport(map[string]float64{"problem_definition": 6, "solution_fit": 6})
+	llm := newMergeTestReport(map[string]float64{"problem_definition": 10, "solution_fit": 10})
+
+	opts := DefaultMergeOptions()
+	opts.LLMWeight = 0.25
+	opts.CategoryLLMWeights = map[string]float64{"solution_fit": 1}
+	opts.DisagreementThreshold = 5
+
+	result, err := MergeEvaluations(det, llm, opts)
+	if err != nil {
+		t.Fatalf("MergeEvaluations failed: %v", err)
+	}
+
+	if got := result.Report.Categories[0].Score; got != 7 {
+		t.Errorf("expected problem_definition 7, got %f", got)
+	}
+	if got := result.Report.Categories[1].Score; got != 10 {
+		t.Errorf("expected solution_fit 10, got %f", got)
+	}
+}
+
+func TestMergeEvaluationsPendingAndFindings(t *testing.T) {
+	det := newMergeTestReport(map[string]float64{"problem_definition": 8})
+	det.AddFinding(evaluation.Finding{ID: "F-1", Category: "problem_definition", Severity: evaluation.SeverityLow, Title: "Vague impact"})
+
+	llm := newMergeTestReport(nil)
+	llm.Categories = append(llm.Categories, evaluation.CategoryScore{Category: "problem_definition", Status: evaluation.CategoryPending})
+	llm.AddFinding(evaluation.Finding{ID: "J-1", Category: "problem_definition", Severity: evaluation.SeverityHigh, Title: "Vague impact"})
+
+	result, err := MergeEvaluations(det, llm, DefaultMergeOptions())
+	if err != nil {
+		t.Fatalf("MergeEvaluations failed: %v", err)
+	}
+
+	if got := result.Report.Categories[0].Score; got != 8 {
+		t.Errorf("expected pending LLM category to be ignored, got %f", got)
+	}
+	if len(result.Report.Findings) != 1 || result.Report.Findings[0].Severity != evaluation.SeverityHigh {
+		t.Errorf("expected one high severity finding, got %v", result.Report.Findings)
+	}
+	if result.Report.Decision.Status != evaluation.DecisionFail {
+		t.Errorf("expected fail for a high severity finding, got %s", result.Report.Decision.Status)
+	}
+}
+
+func TestMergeEvaluationsInvalidOptions(t *testing.T) {
+	det := newMergeTestReport(nil)
+	llm := newMergeTestReport(nil)
+
+	opts := DefaultMergeOptions()
+	opts.LLMWeight = 1.5
+	if _, err := MergeEvaluations(det, llm, opts); err == nil {
+		t.Error("expected error for LLM weight above 1")
+	}
+
+	opts = DefaultMergeOptions()
+	opts.CategoryLLMWeights = map[string]float64{"vibes": 0.5}
+	if _, err := MergeEvaluations(det, llm, opts); err == nil {
+		t.Error("expected error for unknown category")
+	}
+
+	if _, err := MergeEvaluations(det, nil, DefaultMergeOptions()); err == nil {
+		t.Error("expected error for missing LLM evaluation")
+	}
+}
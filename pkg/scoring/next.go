@@ -0,0 +1,530 @@
+package scoring
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+)
+
+// NextAction is a concrete change that would raise a PRD's score.
+type NextAction struct {
+	Category string `json:"category"`
+	Check    string `json:"check"`
+	Target   string `json:"target,omitempty"`
+	Path     string `json:"path,omitempty"`
+	Action   string `json:"action"`
+
+	// Gain is the projected increase in the weighted score (0-10) from
+	// making this change alone.
+	Gain float64 `json:"gain"`
+
+	// Command is the prdtool command that makes the change, and Tool the
+	// equivalent MCP tool call. Values in angle brackets are placeholders.
+	Command string   `json:"command"`
+	Tool    ToolCall `json:"tool"`
+}
+
+// ToolCall is an MCP tool name and its arguments.
+type ToolCall struct {
+	Name      string                 `json:"name"`
+	Arguments map[string]interface{} `json:"arguments"`
+}
+
+// NextActions returns the changes that would most improve the PRD's score,
+// highest projected gain first.
+//
+// Each gap the rubric checks find is turned into a fix, which is simulated
+// on a copy of the PRD with placeholder content. The checks only propose
+// fixes: the gain is the change in the weighted score from Score.
+// Fixes that cannot be applied, or that would not raise the score, are left
+// out.
+//
+// path is the PRD file the commands and tool calls refer to; if empty, they
+// use the default.
+func NextActions(p *prd.PRD, path string) []NextAction {
+	before := Score(p).WeightedScore
+
+	var actions []NextAction
+	index := make(map[string]int)
+	for _, r := range rubricRules {
+		generate := fixes[r.id]
+		if generate == nil {
+			continue
+		}
+		_, gaps := r.eval(p)
+		for _, g := range gaps {
+			f := generate(p, g)
+			if f == nil {
+				continue
+			}
+			after, err := f.simulate(p)
+			if err != nil {
+				continue
+			}
+			gain := Score(after).WeightedScore - before
+			if gain <= 0 {
+				continue
+			}
+
+			action := f.nextAction(path)
+			action.Category = r.category
+			action.Check = r.id
+			action.Target = g.target
+			action.Path = g.path
+			action.Gain = gain
+
+			// Some fixes satisfy several checks; keep one entry per change.
+			if i, ok := index[action.Command]; ok {
+				if gain > actions[i].Gain {
+					actions[i] = action
+				}
+				continue
+			}
+			index[action.Command] = len(actions)
+			actions = append(actions, action)
+		}
+	}
+
+	sort.SliceStable(actions, func(i, j int) bool {
+		return actions[i].Gain > actions[j].Gain
+	})
+	return actions
+}
+
+// fix is a change that addresses a gap. It is either a JSON Patch, or a
+// prdtool command with an equivalent MCP tool and operation.
+type fix struct {
+	action string
+
+	ops []prd.PatchOperation
+
+	command []string
+	tool    string
+	args    map[string]interface{}
+	apply   func(p *prd.PRD)
+}
+
+func patchFix(action string, ops ...prd.PatchOperation) *fix {
+	return &fix{action: action, ops: ops}
+}
+
+func (f *fix) simulate(p *prd.PRD) (*prd.PRD, error) {
+	if f.ops != nil {
+		return prd.ApplyJSONPatch(p, f.ops)
+	}
+	clone, err := prd.ApplyJSONPatch(p, nil)
+	if err != nil {
+		return nil, err
+	}
+	f.apply(clone)
+	return clone, nil
+}
+
+func (f *fix) nextAction(path string) NextAction {
+	command := f.command
+	tool := ToolCall{Name: f.tool, Arguments: make(map[string]interface{})}
+	for k, v := range f.args {
+		tool.Arguments[k] = v
+	}
+
+	if f.ops != nil {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		_ = enc.Encode(f.ops)
+		command = []string{"patch", "--patch", strings.TrimSpace(buf.String())}
+		tool.Name = "prd_patch"
+		tool.Arguments["operations"] = f.ops
+	}
+	if path != "" {
+		command = append(command, path)
+		tool.Arguments["path"] = path
+	}
+
+	words := []string{"prdtool"}
+	for _, arg := range command {
+		words = append(words, shellQuote(arg))
+	}
+	return NextAction{
+		Action:  f.action,
+		Command: strings.Join(words, " "),
+		Tool:    tool,
+	}
+}
+
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9_./=:-]+$`)
+
+func shellQuote(s string) string {
+	if shellSafe.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func addOp(path string, value interface{}) prd.PatchOperation {
+	return prd.PatchOperation{Op: "add", Path: path, Value: value}
+}
+
+// appendOp adds item to the list at path, creating the list if it is empty.
+func appendOp(path string, n int, item interface{}) prd.PatchOperation {
+	if n == 0 {
+		return addOp(path, []interface{}{item})
+	}
+	return addOp(path+"/-", item)
+}
+
+// describe names the entity a gap belongs to, for action text.
+func describe(g gap, fallback string) string {
+	if g.target != "" {
+		return g.target
+	}
+	return fallback
+}
+
+// fixes generates a fix for a gap found by the rubric rule with the same ID.
+// A nil fix means the gap cannot be fixed directly, usually because another
+// action (such as adding the problem definition) has to come first.
+var fixes = map[string]func(p *prd.PRD, g gap) *fix{
+	// Problem Definition
+	"problem.statement": func(p *prd.PRD, g gap) *fix {
+		if p.Problem != nil {
+			return patchFix("Write the problem statement", addOp(g.path, "<problem statement>"))
+		}
+		return &fix{
+			action:  "Define the problem",
+			command: []string{"add", "problem", "--statement", "<problem statement>", "--impact", "<user impact>"},
+			tool:    "prd_add_problem",
+			args:    map[string]interface{}{"statement": "<problem statement>", "impact": "<user impact>"},
+			apply: func(p *prd.PRD) {
+				prd.SetProblemStatement(p, "<problem statement>", "<user impact>", 0.5)
+			},
+		}
+	},
+	"problem.impact": func(p *prd.PRD, g gap) *fix {
+		if p.Problem == nil {
+			return nil
+		}
+		return patchFix("Describe the user impact of "+describe(g, "the problem"), addOp(g.path, "<user impact>"))
+	},
+	"problem.evidence": func(p *prd.PRD, g gap) *fix {
+		if p.Problem == nil {
+			return nil
+		}
+		evidence := prd.Evidence{Type: prd.EvidenceAnalytics, Summary: "<evidence summary>"}
+		return patchFix("Add evidence to "+describe(g, "the problem"), appendOp(g.path, len(p.Problem.Evidence), evidence))
+	},
+	"problem.confidence": func(p *prd.PRD, g gap) *fix {
+		if p.Problem == nil {
+			return nil
+		}
+		return patchFix("Validate "+describe(g, "the problem")+" and raise its confidence to at least 0.6", addOp(g.path, 0.6))
+	},
+	"problem.root_causes": func(p *prd.PRD, g gap) *fix {
+		if p.Problem == nil {
+			return nil
+		}
+		return patchFix("Identify root causes of "+describe(g, "the problem"), addOp(g.path, []string{"<root cause>"}))
+	},
+
+	// Solution Fit
+	"solution.options": func(p *prd.PRD, g gap) *fix {
+		return &fix{
+			action:  "Add a solution option to compare",
+			command: []string{"add", "solution", "--name", "<solution name>", "--description", "<description>", "--tradeoff", "<tradeoff>"},
+			tool:    "prd_add_solution",
+			args:    map[string]interface{}{"name": "<solution name>", "description": "<description>", "tradeoffs": []string{"<tradeoff>"}},
+			apply: func(p *prd.PRD) {
+				prd.AddSolution(p, "<solution name>", "<description>", []string{"<tradeoff>"})
+			},
+		}
+	},
+	"solution.selected": func(p *prd.PRD, g gap) *fix {
+		if p.Solution == nil || len(p.Solution.SolutionOptions) == 0 {
+			return nil
+		}
+		id := p.Solution.SolutionOptions[0].ID
+		return &fix{
+			action: "Select a solution, such as " + id,
+			command: []string{"patch", "--patch", fmt.Sprintf(
+				`[{"op":"add","path":"/solution/selectedSolutionId","value":%q},{"op":"add","path":"/solution/solutionRationale","value":"<rationale>"}]`, id)},
+			tool: "prd_select_solution",
+			args: map[string]interface{}{"id": id, "rationale": "<rationale>"},
+			apply: func(p *prd.PRD) {
+				prd.SelectSolution(p, id, "<rationale>")
+			},
+		}
+	},
+	"solution.rationale": func(p *prd.PRD, g gap) *fix {
+		if p.Solution == nil || p.Solution.SelectedSolutionID == "" {
+			return nil
+		}
+		return patchFix("Explain why "+p.Solution.SelectedSolutionID+" was selected", addOp(g.path, "<rationale>"))
+	},
+	"solution.tradeoffs": func(p *prd.PRD, g gap) *fix {
+		if g.target == "" {
+			return nil
+		}
+		return patchFix("List the tradeoffs of "+g.target, addOp(g.path, []string{"<tradeoff>"}))
+	},
+
+	// User Understanding
+	"personas.defined": func(p *prd.PRD, g gap) *fix {
+		return &fix{
+			action:  "Add a persona",
+			command: []string{"add", "persona", "--name", "<name>", "--role", "<role>", "--pain-point", "<pain point>"},
+			tool:    "prd_add_persona",
+			args:    map[string]interface{}{"name": "<name>", "role": "<role>", "pain_points": []string{"<pain point>"}},
+			apply: func(p *prd.PRD) {
+				prd.AddPersona(p, "<name>", "<role>", []string{"<pain point>"})
+			},
+		}
+	},
+	"personas.pain_points": func(p *prd.PRD, g gap) *fix {
+		if g.target == "" {
+			return nil
+		}
+		return patchFix("Add pain points to "+g.target, addOp(g.path, []string{"<pain point>"}))
+	},
+	"personas.primary": func(p *prd.PRD, g gap) *fix {
+		if g.target == "" {
+			return nil
+		}
+		return patchFix("Mark the primary persona, such as "+g.target, addOp(g.path, true))
+	},
+	"stories.defined": func(p *prd.PRD, g gap) *fix {
+		story := prd.UserStory{
+			ID:    prd.NextID(p, "US"),
+			Title: "<title>",
+			Story: "As a <persona>, I want <capability> so that <benefit>",
+		}
+		if len(p.Personas) > 0 {
+			story.PersonaID = p.Personas[0].ID
+		}
+		return patchFix("Write a user story", appendOp(g.path, len(p.UserStories), story))
+	},
+	"stories.acceptance": func(p *prd.PRD, g gap) *fix {
+		if g.target == "" {
+			return nil
+		}
+		criterion := prd.AcceptanceCriterion{ID: g.target + "-AC-1", Description: "<acceptance criterion>"}
+		return patchFix("Add acceptance criteria to "+g.target, addOp(g.path, []prd.AcceptanceCriterion{criterion}))
+	},
+
+	// Market Awareness
+	"market.alternatives": func(p *prd.PRD, g gap) *fix {
+		return alternativeFix(p, "Add a competitor or alternative", prd.AlternativeCompetitor, "<competitor>")
+	},
+	"market.competitors": func(p *prd.PRD, g gap) *fix {
+		return alternativeFix(p, "Add a competitor", prd.AlternativeCompetitor, "<competitor>")
+	},
+	"market.status_quo": func(p *prd.PRD, g gap) *fix {
+		return alternativeFix(p, "Add the current workaround as an alternative", prd.AlternativeWorkaround, "<workaround>")
+	},
+
+	// Scope Discipline
+	"scope.non_goals": func(p *prd.PRD, g gap) *fix {
+		return &fix{
+			action:  "Add a non-goal",
+			command: []string{"add", "nongoal", "--statement", "<non-goal>"},
+			tool:    "prd_add_nongoal",
+			args:    map[string]interface{}{"statement": "<non-goal>"},
+			apply: func(p *prd.PRD) {
+				prd.AddOutOfScope(p, "<non-goal>")
+			},
+		}
+	},
+	"scope.roadmap": func(p *prd.PRD, g gap) *fix {
+		phase := prd.Phase{ID: prd.NextID(p, "PHASE"), Name: "<phase name>"}
+		return patchFix("Add a roadmap phase", appendOp(g.path, len(p.Roadmap.Phases), phase))
+	},
+	"scope.phased_requirements": func(p *prd.PRD, g gap) *fix {
+		if g.target == "" || len(p.Roadmap.Phases) == 0 {
+			return nil
+		}
+		phase := p.Roadmap.Phases[0].ID
+		return patchFix("Assign "+g.target+" to a phase, such as "+phase, addOp(g.path, phase))
+	},
+
+	// Requirements Quality
+	"requirements.functional": func(p *prd.PRD, g gap) *fix {
+		return requirementFix()
+	},
+	"requirements.acceptance": func(p *prd.PRD, g gap) *fix {
+		if g.target == "" {
+			return nil
+		}
+		criterion := prd.AcceptanceCriterion{ID: g.target + "-AC-1", Description: "<acceptance criterion>"}
+		return patchFix("Add acceptance criteria to "+g.target, addOp(g.path, []prd.AcceptanceCriterion{criterion}))
+	},
+	"requirements.prioritized": func(p *prd.PRD, g gap) *fix {
+		if len(p.Requirements.Functional) == 0 {
+			return requirementFix()
+		}
+		fr := p.Requirements.Functional[0]
+		return patchFix("Mark the most important requirement, such as "+fr.ID+", as must-have",
+			addOp("/requirements/functional/0/priority", prd.MoSCoWMust))
+	},
+	"requirements.nonfunctional": func(p *prd.PRD, g gap) *fix {
+		return &fix{
+			action:  "Add a non-functional requirement",
+			command: []string{"add", "nfr", "--category", "performance", "--requirement", "<requirement>", "--target", "<target>"},
+			tool:    "prd_add_nfr",
+			args:    map[string]interface{}{"category": "performance", "requirement": "<requirement>", "target": "<target>"},
+			apply: func(p *prd.PRD) {
+				prd.AddNonFunctionalRequirement(p, prd.NFRPerformance, "", "<requirement>", "<target>", prd.MoSCoWShould)
+			},
+		}
+	},
+	"requirements.nfr_targets": func(p *prd.PRD, g gap) *fix {
+		if g.target == "" {
+			return nil
+		}
+		return patchFix("Add a target to "+g.target, addOp(g.path, "<target>"))
+	},
+
+	// Metrics Quality
+	"metrics.defined": func(p *prd.PRD, g gap) *fix {
+		return &fix{
+			action:  "Add a success metric",
+			command: []string{"add", "metric", "--name", "<metric>", "--target", "<target>"},
+			tool:    "prd_add_metric",
+			args:    map[string]interface{}{"name": "<metric>", "target": "<target>"},
+			apply: func(p *prd.PRD) {
+				prd.AddSuccessMetric(p, "<metric>", "", "<target>")
+			},
+		}
+	},
+	"metrics.targets": func(p *prd.PRD, g gap) *fix {
+		if g.target == "" {
+			return nil
+		}
+		return patchFix("Add a target to "+g.target, addOp(g.path, "<target>"))
+	},
+	"metrics.baselines": func(p *prd.PRD, g gap) *fix {
+		if g.target == "" {
+			return nil
+		}
+		return patchFix("Add a baseline to "+g.target, addOp(g.path, "<baseline>"))
+	},
+	"metrics.objectives": func(p *prd.PRD, g gap) *fix {
+		if g.target == "" {
+			return nil
+		}
+		kr := prd.KeyResult{ID: prd.NextID(p, "KR"), Title: "<key result>", Baseline: "<baseline>", Target: "<target>"}
+		return patchFix("Add a key result to "+g.target, addOp(g.path, []prd.KeyResult{kr}))
+	},
+
+	// UX Coverage
+	"ux.flows": func(p *prd.PRD, g gap) *fix {
+		flow := prd.InteractionFlow{ID: "FLOW-1", Title: "<flow>", Steps: []string{"<step>"}}
+		if p.UXRequirements == nil {
+			return patchFix("Describe an interaction flow", addOp("/uxRequirements", map[string]interface{}{
+				"interactionFlows": []prd.InteractionFlow{flow},
+			}))
+		}
+		flow.ID = fmt.Sprintf("FLOW-%d", len(p.UXRequirements.InteractionFlows)+1)
+		return patchFix("Describe an interaction flow", appendOp(g.path, len(p.UXRequirements.InteractionFlows), flow))
+	},
+	"ux.flow_steps": func(p *prd.PRD, g gap) *fix {
+		if g.target == "" {
+			return nil
+		}
+		return patchFix("Add steps to "+g.target, addOp(g.path, []string{"<step>"}))
+	},
+	"ux.principles": func(p *prd.PRD, g gap) *fix {
+		if p.UXRequirements == nil {
+			return patchFix("State the design principles", addOp("/uxRequirements", map[string]interface{}{
+				"designPrinciples": []string{"<principle>"},
+			}))
+		}
+		return patchFix("State the design principles", addOp(g.path, []string{"<principle>"}))
+	},
+
+	// Technical Feasibility
+	"tech.overview": func(p *prd.PRD, g gap) *fix {
+		if p.TechArchitecture == nil {
+			return patchFix("Describe the technical architecture", addOp("/technicalArchitecture", map[string]interface{}{
+				"overview": "<architecture overview>",
+			}))
+		}
+		return patchFix("Describe the technical architecture", addOp(g.path, "<architecture overview>"))
+	},
+	"tech.integrations": func(p *prd.PRD, g gap) *fix {
+		integration := prd.Integration{Name: "<system>", Description: "<how it integrates>"}
+		if p.TechArchitecture == nil {
+			return patchFix("List the integration points", addOp("/technicalArchitecture", map[string]interface{}{
+				"integrationPoints": []prd.Integration{integration},
+			}))
+		}
+		return patchFix("List the integration points", addOp(g.path, []prd.Integration{integration}))
+	},
+	"tech.constraints": func(p *prd.PRD, g gap) *fix {
+		constraint := prd.Constraint{ID: "CON-1", Description: "<constraint>"}
+		if p.Assumptions == nil {
+			return patchFix("Record the constraints", addOp(g.path, map[string]interface{}{
+				"constraints": []prd.Constraint{constraint},
+			}))
+		}
+		return patchFix("Record the constraints", addOp(g.path+"/constraints", []prd.Constraint{constraint}))
+	},
+
+	// Risk Management
+	"risks.defined": func(p *prd.PRD, g gap) *fix {
+		return &fix{
+			action:  "Add a risk with a mitigation",
+			command: []string{"add", "risk", "--description", "<risk>", "--probability", "medium", "--impact", "medium", "--mitigation", "<mitigation>"},
+			tool:    "prd_add_risk",
+			args:    map[string]interface{}{"description": "<risk>", "probability": "medium", "impact": "medium", "mitigation": "<mitigation>"},
+			apply: func(p *prd.PRD) {
+				prd.AddRisk(p, "<risk>", prd.RiskProbabilityMedium, prd.RiskImpactMedium, "<mitigation>")
+			},
+		}
+	},
+	"risks.mitigation": func(p *prd.PRD, g gap) *fix {
+		if g.target == "" {
+			return nil
+		}
+		return patchFix("Add a mitigation to "+g.target, addOp(g.path, "<mitigation>"))
+	},
+	"risks.owner": func(p *prd.PRD, g gap) *fix {
+		if g.target == "" {
+			return nil
+		}
+		return patchFix("Assign an owner to "+g.target, addOp(g.path, "<owner>"))
+	},
+	"risks.assessed": func(p *prd.PRD, g gap) *fix {
+		if g.target == "" {
+			return nil
+		}
+		return patchFix("Assess the probability and impact of "+g.target,
+			addOp(g.path+"/probability", prd.RiskProbabilityMedium),
+			addOp(g.path+"/impact", prd.RiskImpactMedium))
+	},
+}
+
+func alternativeFix(p *prd.PRD, action string, t prd.AlternativeType, name string) *fix {
+	alt := prd.Alternative{ID: prd.NextID(p, "ALT"), Name: name, Type: t}
+	if p.Market == nil {
+		return patchFix(action, addOp("/market", map[string]interface{}{
+			"alternatives": []prd.Alternative{alt},
+		}))
+	}
+	return patchFix(action, appendOp("/market/alternatives", len(p.Market.Alternatives), alt))
+}
+
+func requirementFix() *fix {
+	return &fix{
+		action:  "Add a must-have functional requirement",
+		command: []string{"add", "req", "--description", "<requirement>", "--priority", "must"},
+		tool:    "prd_add_requirement",
+		args:    map[string]interface{}{"description": "<requirement>", "priority": "must"},
+		apply: func(p *prd.PRD) {
+			prd.AddFunctionalRequirement(p, "", "<requirement>", prd.MoSCoWMust)
+		},
+	}
+}
@@ -0,0 +1,98 @@
+package scoring
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+)
+
+func TestNextActions(t *testing.T) {
+	p := prd.New("PRD-2026-001", "Test PRD", prd.Person{Name: "Owner"})
+	prd.AddSuccessMetric(p, "Login success rate", "", "99%")
+	prd.AddRisk(p, "Provider outage", prd.RiskProbabilityMedium, prd.RiskImpactHigh, "")
+
+	actions := NextActions(p, "")
+	if len(actions) == 0 {
+		t.Fatal("expected next actions for a sparse PRD")
+	}
+	for i := 1; i < len(actions); i++ {
+		if actions[i].Gain > actions[i-1].Gain {
+			t.Fatalf("actions not ranked by gain: %v before %v", actions[i-1], actions[i])
+		}
+	}
+	if actions[0].Check != "problem.statement" || actions[0].Tool.Name != "prd_add_problem" {
+		t.Errorf("expected defining the problem first, got %+v", actions[0])
+	}
+
+	baseline := findAction(t, actions, "metrics.baselines")
+	if baseline.Action != "Add a baseline to KR-1" {
+		t.Errorf("unexpected action %q", baseline.Action)
+	}
+	if baseline.Tool.Name != "prd_patch" || !strings.HasPrefix(baseline.Command, "prdtool patch --patch '") {
+		t.Errorf("expected a patch, got %s %s", baseline.Tool.Name, baseline.Command)
+	}
+	if baseline.Gain <= 0 {
+		t.Errorf("expected a positive gain, got %f", baseline.Gain)
+	}
+
+	mitigation := findAction(t, actions, "risks.mitigation")
+	if mitigation.Path != "/risks/0/mitigation" || mitigation.Target != "RISK-1" {
+		t.Errorf("unexpected mitigation action %+v", mitigation)
+	}
+}
+
+func TestNextActionsPath(t *testing.T) {
+	p := prd.New("PRD-2026-001", "Test PRD", prd.Person{Name: "Owner"})
+
+	risk := findAction(t, NextActions(p, "docs/PRD.json"), "risks.defined")
+	if !strings.HasPrefix(risk.Command, "prdtool add risk --description '<risk>'") || !strings.HasSuffix(risk.Command, " docs/PRD.json") {
+		t.Errorf("unexpected command %s", risk.Command)
+	}
+	if risk.Tool.Arguments["path"] != "docs/PRD.json" {
+		t.Errorf("expected path argument, got %v", risk.Tool.Arguments)
+	}
+}
+
+func TestNextActionsApply(t *testing.T) {
+	p := prd.New("PRD-2026-001", "Test PRD", prd.Person{Name: "Owner"})
+	prd.AddRisk(p, "Provider outage", prd.RiskProbabilityMedium, prd.RiskImpactHigh, "")
+
+	mitigation := findAction(t, NextActions(p, ""), "risks.mitigation")
+	ops, _ := mitigation.Tool.Arguments["operations"].([]prd.PatchOperation)
+	patched, err := prd.ApplyJSONPatch(p, ops)
+	if err != nil {
+		t.Fatalf("failed to apply suggested patch: %v", err)
+	}
+	if patched.Risks[0].Mitigation == "" {
+		t.Error("expected the suggested patch to set the risk mitigation")
+	}
+	if gain := Score(patched).WeightedScore - Score(p).WeightedScore; gain != mitigation.Gain {
+		t.Errorf("expected the gain %f to be the change in Score, got %f", gain, mitigation.Gain)
+	}
+	for _, a := range NextActions(patched, "") {
+		if a.Check == "risks.mitigation" {
+			t.Errorf("expected no mitigation action once fixed, got %+v", a)
+		}
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	if got := shellQuote("PRD.json"); got != "PRD.json" {
+		t.Errorf("expected no quoting, got %s", got)
+	}
+	if got := shellQuote("it's"); got != `'it'\''s'` {
+		t.Errorf("unexpected quoting %s", got)
+	}
+}
+
+func findAction(t *testing.T, actions []NextAction, check string) NextAction {
+	t.Helper()
+	for _, a := range actions {
+		if a.Check == check {
+			return a
+		}
+	}
+	t.Fatalf("no action for check %s in %v", check, actions)
+	return NextAction{}
+}
@@ -0,0 +1,65 @@
+package scoring
+
+import (
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+)
+
+// FromReview returns the review recorded in the PRD (see prd.RecordReview)
+// as a scoring result, or nil if no review has been recorded. Categories
+// the review didn't score are left out rather than scored 0; for a review
+// recorded without a prd.ReviewRecord, those are the categories scored 0.
+func FromReview(p *prd.PRD) *ScoringResult {
+	r := p.Reviews
+	if r == nil || r.QualityScores == nil || r.Decision == "" {
+		return nil
+	}
+
+	result := &ScoringResult{
+		WeightedScore:    r.QualityScores.OverallScore,
+		Decision:         string(r.Decision),
+		Blockers:         []string{},
+		RevisionTriggers: append([]RevisionItem{}, r.RevisionTriggers...),
+		Summary:          r.ReviewBoardSummary,
+	}
+	scored := reviewedCategories(p)
+	for _, w := range DefaultWeights() {
+		score, _ := prd.QualityScore(r.QualityScores, w.Category)
+		if !scored(w.Category, score) {
+			continue
+		}
+		result.CategoryScores = append(result.CategoryScores, CategoryScore{
+			Category:       w.Category,
+			Weight:         w.Weight,
+			Score:          score,
+			MaxScore:       10,
+			BelowThreshold: score < ThresholdRevise,
+		})
+	}
+	for _, b := range r.Blockers {
+		result.Blockers = append(result.Blockers, b.Description)
+	}
+	return result
+}
+
+// reviewedCategories returns a function reporting whether the recorded
+// review scored a category.
+func reviewedCategories(p *prd.PRD) func(category string, score float64) bool {
+	record, err := prd.GetReviewRecord(p)
+	if err != nil || record == nil {
+		return func(_ string, score float64) bool { return score != 0 }
+	}
+	categories := make(map[string]bool, len(record.Categories))
+	for _, c := range record.Categories {
+		categories[c] = true
+	}
+	return func(category string, _ float64) bool { return categories[category] }
+}
+
+// ReviewOrScore returns the recorded review if there is one, and otherwise
+// scores the PRD.
+func ReviewOrScore(p *prd.PRD) *ScoringResult {
+	if result := FromReview(p); result != nil {
+		return result
+	}
+	return Score(p)
+}
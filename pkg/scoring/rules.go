@@ -0,0 +1,314 @@
+package scoring
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+)
+
+// rule is a rubric check used to find gaps that NextActions can propose
+// fixes for. eval returns the fraction of the rule's points met and the
+// gaps that cost the rest. The rules restate the rubric's evidence rules
+// rather than run the scorer's own, so they only locate content: whether a
+// fix matters is measured with Score.
+type rule struct {
+	id          string
+	category    string
+	description string
+	points      float64
+	eval        func(p *prd.PRD) (float64, []gap)
+}
+
+// gap is content that failed a rule: where it is, and the ID of the entity
+// it belongs to, if any.
+type gap struct {
+	path   string
+	target string
+}
+
+// present scores a rule that is either met or not.
+func present(ok bool, path, target string) (float64, []gap) {
+	if ok {
+		return 1, nil
+	}
+	return 0, []gap{{path: path, target: target}}
+}
+
+// each scores a rule that every item of a list must meet. An empty list
+// earns nothing, with the list itself as the gap.
+func each(n int, listPath string, ok func(i int) (bool, string, string)) (float64, []gap) {
+	if n == 0 {
+		return 0, []gap{{path: listPath}}
+	}
+	var gaps []gap
+	for i := 0; i < n; i++ {
+		if met, path, target := ok(i); !met {
+			gaps = append(gaps, gap{path: path, target: target})
+		}
+	}
+	return float64(n-len(gaps)) / float64(n), gaps
+}
+
+func pointer(parts ...interface{}) string {
+	var sb strings.Builder
+	for _, part := range parts {
+		sb.WriteString("/")
+		s := fmt.Sprint(part)
+		s = strings.ReplaceAll(s, "~", "~0")
+		sb.WriteString(strings.ReplaceAll(s, "/", "~1"))
+	}
+	return sb.String()
+}
+
+func problemID(p *prd.PRD) string {
+	if p.Problem != nil {
+		return p.Problem.ID
+	}
+	return ""
+}
+
+// keyResults returns every key result with its JSON Pointer.
+func keyResults(p *prd.PRD) ([]prd.KeyResult, []string) {
+	var krs []prd.KeyResult
+	var paths []string
+	for i, okr := range p.Objectives.OKRs {
+		for j, kr := range okr.KeyResults {
+			krs = append(krs, kr)
+			paths = append(paths, pointer("objectives", "okrs", i, "keyResults", j))
+		}
+	}
+	return krs, paths
+}
+
+var rubricRules = []rule{
+	// Problem Definition
+	{"problem.statement", "problem_definition", "Problem statement is defined", 3, func(p *prd.PRD) (float64, []gap) {
+		return present(p.Problem != nil && p.Problem.Statement != "", "/problem/statement", problemID(p))
+	}},
+	{"problem.impact", "problem_definition", "User impact is described", 2, func(p *prd.PRD) (float64, []gap) {
+		return present(p.Problem != nil && p.Problem.UserImpact != "", "/problem/userImpact", problemID(p))
+	}},
+	{"problem.evidence", "problem_definition", "Problem is backed by evidence other than assumptions", 3, func(p *prd.PRD) (float64, []gap) {
+		ok := false
+		if p.Problem != nil {
+			for _, e := range p.Problem.Evidence {
+				if e.Type != prd.EvidenceAssumption {
+					ok = true
+				}
+			}
+		}
+		return present(ok, "/problem/evidence", problemID(p))
+	}},
+	{"problem.confidence", "problem_definition", "Confidence in the problem is at least 0.6", 1, func(p *prd.PRD) (float64, []gap) {
+		return present(p.Problem != nil && p.Problem.Confidence >= 0.6, "/problem/confidence", problemID(p))
+	}},
+	{"problem.root_causes", "problem_definition", "Root causes are identified", 1, func(p *prd.PRD) (float64, []gap) {
+		return present(p.Problem != nil && len(p.Problem.RootCauses) > 0, "/problem/rootCauses", problemID(p))
+	}},
+
+	// Solution Fit
+	{"solution.options", "solution_fit", "At least two solution options are compared", 4, func(p *prd.PRD) (float64, []gap) {
+		n := 0
+		if p.Solution != nil {
+			n = len(p.Solution.SolutionOptions)
+		}
+		switch {
+		case n >= 2:
+			return 1, nil
+		case n == 1:
+			return 0.5, []gap{{path: "/solution/solutionOptions"}}
+		default:
+			return 0, []gap{{path: "/solution/solutionOptions"}}
+		}
+	}},
+	{"solution.selected", "solution_fit", "A solution is selected", 3, func(p *prd.PRD) (float64, []gap) {
+		return present(p.Solution != nil && p.Solution.SelectedSolutionID != "", "/solution/selectedSolutionId", "")
+	}},
+	{"solution.rationale", "solution_fit", "The selection has a rationale", 1, func(p *prd.PRD) (float64, []gap) {
+		return present(p.Solution != nil && p.Solution.SolutionRationale != "", "/solution/solutionRationale", "")
+	}},
+	{"solution.tradeoffs", "solution_fit", "Every solution option lists tradeoffs", 2, func(p *prd.PRD) (float64, []gap) {
+		var options []prd.SolutionOption
+		if p.Solution != nil {
+			options = p.Solution.SolutionOptions
+		}
+		return each(len(options), "/solution/solutionOptions", func(i int) (bool, string, string) {
+			return len(options[i].Tradeoffs) > 0, pointer("solution", "solutionOptions", i, "tradeoffs"), options[i].ID
+		})
+	}},
+
+	// User Understanding
+	{"personas.defined", "user_understanding", "At least one persona is defined", 3, func(p *prd.PRD) (float64, []gap) {
+		return present(len(p.Personas) > 0, "/personas", "")
+	}},
+	{"personas.pain_points", "user_understanding", "Every persona has pain points", 2, func(p *prd.PRD) (float64, []gap) {
+		return each(len(p.Personas), "/personas", func(i int) (bool, string, string) {
+			return len(p.Personas[i].PainPoints) > 0, pointer("personas", i, "painPoints"), p.Personas[i].ID
+		})
+	}},
+	{"personas.primary", "user_understanding", "A primary persona is marked", 1, func(p *prd.PRD) (float64, []gap) {
+		for _, persona := range p.Personas {
+			if persona.IsPrimary {
+				return 1, nil
+			}
+		}
+		if len(p.Personas) > 0 {
+			return 0, []gap{{path: "/personas/0/isPrimary", target: p.Personas[0].ID}}
+		}
+		return 0, []gap{{path: "/personas"}}
+	}},
+	{"stories.defined", "user_understanding", "User stories are written", 2, func(p *prd.PRD) (float64, []gap) {
+		return present(len(p.UserStories) > 0, "/userStories", "")
+	}},
+	{"stories.acceptance", "user_understanding", "Every user story has acceptance criteria", 2, func(p *prd.PRD) (float64, []gap) {
+		return each(len(p.UserStories), "/userStories", func(i int) (bool, string, string) {
+			return len(p.UserStories[i].AcceptanceCriteria) > 0, pointer("userStories", i, "acceptanceCriteria"), p.UserStories[i].ID
+		})
+	}},
+
+	// Market Awareness
+	{"market.alternatives", "market_awareness", "Alternatives are identified", 4, func(p *prd.PRD) (float64, []gap) {
+		return present(p.Market != nil && len(p.Market.Alternatives) > 0, "/market/alternatives", "")
+	}},
+	{"market.competitors", "market_awareness", "Competitors are analyzed", 3, func(p *prd.PRD) (float64, []gap) {
+		return present(hasAlternative(p, prd.AlternativeCompetitor), "/market/alternatives", "")
+	}},
+	{"market.status_quo", "market_awareness", "Workarounds or doing nothing are considered", 3, func(p *prd.PRD) (float64, []gap) {
+		ok := hasAlternative(p, prd.AlternativeWorkaround) || hasAlternative(p, prd.AlternativeDoNothing)
+		return present(ok, "/market/alternatives", "")
+	}},
+
+	// Scope Discipline
+	{"scope.non_goals", "scope_discipline", "Non-goals are listed", 4, func(p *prd.PRD) (float64, []gap) {
+		return present(len(p.OutOfScope) > 0, "/outOfScope", "")
+	}},
+	{"scope.roadmap", "scope_discipline", "The roadmap has phases", 3, func(p *prd.PRD) (float64, []gap) {
+		return present(len(p.Roadmap.Phases) > 0, "/roadmap/phases", "")
+	}},
+	{"scope.phased_requirements", "scope_discipline", "Every must-have requirement is assigned to a phase", 3, func(p *prd.PRD) (float64, []gap) {
+		var must []int
+		for i, fr := range p.Requirements.Functional {
+			if fr.Priority == prd.MoSCoWMust {
+				must = append(must, i)
+			}
+		}
+		return each(len(must), "/requirements/functional", func(k int) (bool, string, string) {
+			fr := p.Requirements.Functional[must[k]]
+			return fr.PhaseID != "", pointer("requirements", "functional", must[k], "phaseId"), fr.ID
+		})
+	}},
+
+	// Requirements Quality
+	{"requirements.functional", "requirements_quality", "Functional requirements are defined", 2, func(p *prd.PRD) (float64, []gap) {
+		return present(len(p.Requirements.Functional) > 0, "/requirements/functional", "")
+	}},
+	{"requirements.acceptance", "requirements_quality", "Every functional requirement has acceptance criteria", 3, func(p *prd.PRD) (float64, []gap) {
+		frs := p.Requirements.Functional
+		return each(len(frs), "/requirements/functional", func(i int) (bool, string, string) {
+			return len(frs[i].AcceptanceCriteria) > 0, pointer("requirements", "functional", i, "acceptanceCriteria"), frs[i].ID
+		})
+	}},
+	{"requirements.prioritized", "requirements_quality", "At least one requirement is a must-have", 1, func(p *prd.PRD) (float64, []gap) {
+		for _, fr := range p.Requirements.Functional {
+			if fr.Priority == prd.MoSCoWMust {
+				return 1, nil
+			}
+		}
+		return 0, []gap{{path: "/requirements/functional"}}
+	}},
+	{"requirements.nonfunctional", "requirements_quality", "Non-functional requirements are defined", 2, func(p *prd.PRD) (float64, []gap) {
+		return present(len(p.Requirements.NonFunctional) > 0, "/requirements/nonFunctional", "")
+	}},
+	{"requirements.nfr_targets", "requirements_quality", "Every non-functional requirement has a target", 2, func(p *prd.PRD) (float64, []gap) {
+		nfrs := p.Requirements.NonFunctional
+		return each(len(nfrs), "/requirements/nonFunctional", func(i int) (bool, string, string) {
+			return nfrs[i].Target != "", pointer("requirements", "nonFunctional", i, "target"), nfrs[i].ID
+		})
+	}},
+
+	// Metrics Quality
+	{"metrics.defined", "metrics_quality", "Success metrics (key results) are defined", 3, func(p *prd.PRD) (float64, []gap) {
+		krs, _ := keyResults(p)
+		return present(len(krs) > 0, "/objectives/okrs", "")
+	}},
+	{"metrics.targets", "metrics_quality", "Every key result has a target", 3, func(p *prd.PRD) (float64, []gap) {
+		krs, paths := keyResults(p)
+		return each(len(krs), "/objectives/okrs", func(i int) (bool, string, string) {
+			return krs[i].Target != "", paths[i] + "/target", krs[i].ID
+		})
+	}},
+	{"metrics.baselines", "metrics_quality", "Every key result has a baseline", 3, func(p *prd.PRD) (float64, []gap) {
+		krs, paths := keyResults(p)
+		return each(len(krs), "/objectives/okrs", func(i int) (bool, string, string) {
+			return krs[i].Baseline != "", paths[i] + "/baseline", krs[i].ID
+		})
+	}},
+	{"metrics.objectives", "metrics_quality", "Every objective has key results", 1, func(p *prd.PRD) (float64, []gap) {
+		okrs := p.Objectives.OKRs
+		return each(len(okrs), "/objectives/okrs", func(i int) (bool, string, string) {
+			return len(okrs[i].KeyResults) > 0, pointer("objectives", "okrs", i, "keyResults"), okrs[i].Objective.ID
+		})
+	}},
+
+	// UX Coverage
+	{"ux.flows", "ux_coverage", "Interaction flows are described", 5, func(p *prd.PRD) (float64, []gap) {
+		return present(p.UXRequirements != nil && len(p.UXRequirements.InteractionFlows) > 0, "/uxRequirements/interactionFlows", "")
+	}},
+	{"ux.flow_steps", "ux_coverage", "Every interaction flow has steps", 2, func(p *prd.PRD) (float64, []gap) {
+		var flows []prd.InteractionFlow
+		if p.UXRequirements != nil {
+			flows = p.UXRequirements.InteractionFlows
+		}
+		return each(len(flows), "/uxRequirements/interactionFlows", func(i int) (bool, string, string) {
+			return len(flows[i].Steps) > 0, pointer("uxRequirements", "interactionFlows", i, "steps"), flows[i].ID
+		})
+	}},
+	{"ux.principles", "ux_coverage", "Design principles are stated", 3, func(p *prd.PRD) (float64, []gap) {
+		return present(p.UXRequirements != nil && len(p.UXRequirements.DesignPrinciples) > 0, "/uxRequirements/designPrinciples", "")
+	}},
+
+	// Technical Feasibility
+	{"tech.overview", "technical_feasibility", "A technical architecture overview is given", 5, func(p *prd.PRD) (float64, []gap) {
+		return present(p.TechArchitecture != nil && p.TechArchitecture.Overview != "", "/technicalArchitecture/overview", "")
+	}},
+	{"tech.integrations", "technical_feasibility", "Integration points are listed", 2, func(p *prd.PRD) (float64, []gap) {
+		return present(p.TechArchitecture != nil && len(p.TechArchitecture.IntegrationPoints) > 0, "/technicalArchitecture/integrationPoints", "")
+	}},
+	{"tech.constraints", "technical_feasibility", "Assumptions or constraints are recorded", 3, func(p *prd.PRD) (float64, []gap) {
+		ok := p.Assumptions != nil && (len(p.Assumptions.Assumptions) > 0 || len(p.Assumptions.Constraints) > 0)
+		return present(ok, "/assumptions", "")
+	}},
+
+	// Risk Management
+	{"risks.defined", "risk_management", "Risks are identified", 3, func(p *prd.PRD) (float64, []gap) {
+		return present(len(p.Risks) > 0, "/risks", "")
+	}},
+	{"risks.mitigation", "risk_management", "Every risk has a mitigation", 3, func(p *prd.PRD) (float64, []gap) {
+		return each(len(p.Risks), "/risks", func(i int) (bool, string, string) {
+			return p.Risks[i].Mitigation != "", pointer("risks", i, "mitigation"), p.Risks[i].ID
+		})
+	}},
+	{"risks.owner", "risk_management", "Every risk has an owner", 2, func(p *prd.PRD) (float64, []gap) {
+		return each(len(p.Risks), "/risks", func(i int) (bool, string, string) {
+			return p.Risks[i].Owner != "", pointer("risks", i, "owner"), p.Risks[i].ID
+		})
+	}},
+	{"risks.assessed", "risk_management", "Every risk has a probability and impact", 2, func(p *prd.PRD) (float64, []gap) {
+		return each(len(p.Risks), "/risks", func(i int) (bool, string, string) {
+			return p.Risks[i].Probability != "" && p.Risks[i].Impact != "", pointer("risks", i), p.Risks[i].ID
+		})
+	}},
+}
+
+func hasAlternative(p *prd.PRD, t prd.AlternativeType) bool {
+	if p.Market == nil {
+		return false
+	}
+	for _, alt := range p.Market.Alternatives {
+		if alt.Type == t {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package scoring
+
+import (
+	"testing"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+)
+
+func TestRulesCoverRubric(t *testing.T) {
+	points := make(map[string]float64)
+	for _, r := range rubricRules {
+		if !IsCategory(r.category) {
+			t.Errorf("rule %s has unknown category %s", r.id, r.category)
+		}
+		points[r.category] += r.points
+	}
+	for _, category := range categoryOrder {
+		if points[category] != 10 {
+			t.Errorf("expected rules for %s to be worth 10 points, got %f", category, points[category])
+		}
+	}
+}
+
+func TestRuleGaps(t *testing.T) {
+	p := prd.New("PRD-2026-001", "Test PRD", prd.Person{Name: "Owner"})
+	prd.AddRisk(p, "Provider outage", prd.RiskProbabilityMedium, prd.RiskImpactHigh, "Fallback provider")
+	prd.AddRisk(p, "Scope creep", prd.RiskProbabilityHigh, prd.RiskImpactMedium, "")
+
+	fraction, gaps := findRule(t, "risks.mitigation").eval(p)
+	if fraction != 0.5 {
+		t.Errorf("expected half the risks to be mitigated, got %f", fraction)
+	}
+	if len(gaps) != 1 || gaps[0].path != "/risks/1/mitigation" || gaps[0].target != p.Risks[1].ID {
+		t.Errorf("expected the unmitigated risk as the gap, got %+v", gaps)
+	}
+
+	if fraction, gaps := findRule(t, "risks.defined").eval(p); fraction != 1 || len(gaps) != 0 {
+		t.Errorf("expected risks.defined to be met, got %f %+v", fraction, gaps)
+	}
+}
+
+func TestRuleGapsEmptyList(t *testing.T) {
+	p := prd.New("PRD-2026-001", "Test PRD", prd.Person{Name: "Owner"})
+
+	fraction, gaps := findRule(t, "risks.owner").eval(p)
+	if fraction != 0 {
+		t.Errorf("expected nothing met without risks, got %f", fraction)
+	}
+	if len(gaps) != 1 || gaps[0].path != "/risks" {
+		t.Errorf("expected the risks list as the gap, got %+v", gaps)
+	}
+}
+
+func TestPointerEscaping(t *testing.T) {
+	if got := pointer("a/b", "c~d", 0); got != "/a~1b/c~0d/0" {
+		t.Errorf("unexpected pointer %s", got)
+	}
+}
+
+func findRule(t *testing.T, id string) rule {
+	t.Helper()
+	for _, r := range rubricRules {
+		if r.id == id {
+			return r
+		}
+	}
+	t.Fatalf("rule %s not found", id)
+	return rule{}
+}
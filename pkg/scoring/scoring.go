@@ -34,7 +34,10 @@ const (
 )
 
 // Score evaluates a PRD and returns scoring results.
-// Delegates to structured-prd Score function.
+// Delegates to structured-prd Score function and adds revision triggers
+// for untested must-have requirements when coverage has been recorded.
 func Score(p *prd.PRD) *ScoringResult {
-	return prd.Score(p)
+	result := prd.Score(p)
+	addCoverageTriggers(p, result)
+	return result
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/structured-evaluation/evaluation"
 )
 
 func TestDefaultWeights(t *testing.T) {
@@ -309,3 +310,127 @@ func createWellDefinedPRD() *prd.PRD {
 
 	return p
 }
+
+func TestCategoryName(t *testing.T) {
+	for _, w := range DefaultWeights() {
+		if !IsCategory(w.Category) {
+			t.Errorf("category %s has no display name", w.Category)
+		}
+	}
+
+	if got := CategoryName("ux_coverage"); got != "UX Coverage" {
+		t.Errorf("expected UX Coverage, got %s", got)
+	}
+	if got := CategoryName("unknown"); got != "unknown" {
+		t.Errorf("expected unknown category to be returned as-is, got %s", got)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	p := prd.New("PRD-2026-001", "Test PRD", prd.Person{Name: "Owner"})
+
+	same := Compare(p, p)
+	if same.Change != 0 || len(same.Categories) != 0 {
+		t.Errorf("expected no change for identical PRDs, got %+v", same)
+	}
+
+	created := Compare(nil, p)
+	if created.Before != 0 || created.DecisionBefore != "" {
+		t.Errorf("expected zero score before a new PRD, got %+v", created)
+	}
+	if created.Change != created.After {
+		t.Errorf("expected change %f to equal after score %f", created.Change, created.After)
+	}
+}
+
+func TestValidateWeights(t *testing.T) {
+	if err := ValidateWeights(map[string]float64{"problem_definition": 0.3, "risk_management": 0}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateWeights(map[string]float64{"vibes": 0.1}); err == nil {
+		t.Error("expected error for unknown category")
+	}
+	if err := ValidateWeights(map[string]float64{"solution_fit": 1.5}); err == nil {
+		t.Error("expected error for weight above 1")
+	}
+}
+
+func TestCategories(t *testing.T) {
+	categories := Categories()
+
+	if len(categories) != len(DefaultWeights()) {
+		t.Fatalf("expected %d categories, got %d", len(DefaultWeights()), len(categories))
+	}
+	for _, c := range categories {
+		if !IsCategory(c.ID) {
+			t.Errorf("unexpected category %s", c.ID)
+		}
+		if c.Name == c.ID {
+			t.Errorf("expected display name for %s", c.ID)
+		}
+	}
+}
+
+func TestFromReview(t *testing.T) {
+	p := prd.New("PRD-2026-001", "Test PRD", prd.Person{Name: "Owner"})
+
+	if FromReview(p) != nil {
+		t.Error("expected nil without a recorded review")
+	}
+
+	p.Reviews = &prd.ReviewsDefinition{
+		QualityScores: &prd.QualityScores{ProblemDefinition: 9, OverallScore: 8.2},
+		Decision:      prd.ReviewApprove,
+		Blockers:      []prd.Blocker{{ID: "B-1", Category: "risk_management", Description: "No rollback plan"}},
+	}
+
+	result := FromReview(p)
+	if result == nil {
+		t.Fatal("expected recorded review")
+	}
+	if result.WeightedScore != 8.2 || result.Decision != "approve" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if len(result.Blockers) != 1 || result.Blockers[0] != "No rollback plan" {
+		t.Errorf("unexpected blockers: %v", result.Blockers)
+	}
+	if ReviewOrScore(p) == nil || ReviewOrScore(p).WeightedScore != 8.2 {
+		t.Error("expected ReviewOrScore to prefer the recorded review")
+	}
+
+	// Without a review record, categories scored 0 are treated as unscored.
+	if len(result.CategoryScores) != 1 || result.CategoryScores[0].Category != "problem_definition" {
+		t.Errorf("expected only the scored category, got %+v", result.CategoryScores)
+	}
+}
+
+func TestFromReviewSkipsUnscoredCategories(t *testing.T) {
+	p := prd.New("PRD-2026-001", "Test PRD", prd.Person{Name: "Owner"})
+
+	report := evaluation.NewEvaluationReport("prd", "PRD.json")
+	report.Metadata.DocumentID = p.Metadata.ID
+	report.AddCategory(evaluation.NewCategoryScore("problem_definition", 0.5, 8, "Clear problem"))
+	report.AddCategory(evaluation.NewCategoryScore("metrics_quality", 0.5, 0, "No metrics"))
+	report.Finalize("test")
+	if err := prd.RecordReview(p, report); err != nil {
+		t.Fatalf("RecordReview failed: %v", err)
+	}
+
+	result := FromReview(p)
+	if result == nil {
+		t.Fatal("expected recorded review")
+	}
+	got := map[string]CategoryScore{}
+	for _, c := range result.CategoryScores {
+		got[c.Category] = c
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected the 2 reviewed categories, got %+v", result.CategoryScores)
+	}
+	if c := got["metrics_quality"]; c.Score != 0 || !c.BelowThreshold {
+		t.Errorf("expected a reviewed 0 to count, got %+v", c)
+	}
+	if _, ok := got["risk_management"]; ok {
+		t.Error("expected unreviewed categories to be left out")
+	}
+}
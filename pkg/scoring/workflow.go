@@ -0,0 +1,65 @@
+package scoring
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+)
+
+// DefaultWorkflow returns the status workflow used by prdtool: a PRD must
+// be valid to enter review, and approval needs a score of at least
+// ThresholdApprove, no open blockers and sign-off from every approver.
+func DefaultWorkflow() *prd.Workflow {
+	return ApprovalWorkflow(ThresholdApprove)
+}
+
+// ApprovalWorkflow returns DefaultWorkflow with a different minimum score
+// for approval. prdtool always uses DefaultWorkflow; this is for programs
+// that embed the service with a policy of their own.
+func ApprovalWorkflow(minScore float64) *prd.Workflow {
+	return &prd.Workflow{Gates: map[prd.Status][]prd.Gate{
+		prd.StatusInReview: {prd.ValidGate()},
+		prd.StatusApproved: {ScoreGate(minScore), BlockersGate(), prd.SignOffGate()},
+	}}
+}
+
+// ScoreGate passes when the PRD's weighted score is at least minScore.
+// The PRD is always scored: a recorded review is written by whoever
+// records it, so its score can't decide approval.
+func ScoreGate(minScore float64) prd.Gate {
+	return prd.Gate{
+		Name: "score",
+		Check: func(p *prd.PRD) (bool, string) {
+			score := Score(p).WeightedScore
+			if score < minScore {
+				return false, fmt.Sprintf("%.1f is below %.1f", score, minScore)
+			}
+			return true, fmt.Sprintf("%.1f meets %.1f", score, minScore)
+		},
+	}
+}
+
+// BlockersGate passes when the PRD has no open blockers, neither found by
+// scoring nor listed in the recorded review. A review's blockers stay open
+// until a new review is recorded, even if the PRD has changed since.
+func BlockersGate() prd.Gate {
+	return prd.Gate{
+		Name: "blockers",
+		Check: func(p *prd.PRD) (bool, string) {
+			blockers := Score(p).Blockers
+			if review := FromReview(p); review != nil {
+				for _, b := range review.Blockers {
+					if !slices.Contains(blockers, b) {
+						blockers = append(blockers, b)
+					}
+				}
+			}
+			if len(blockers) == 0 {
+				return true, "no open blockers"
+			}
+			return false, fmt.Sprintf("%d open: %s", len(blockers), strings.Join(blockers, "; "))
+		},
+	}
+}
@@ -0,0 +1,58 @@
+package scoring
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+)
+
+func TestApprovalGates(t *testing.T) {
+	p := prd.New("PRD-2026-001", "Workflow Test", prd.Person{Name: "Owner"})
+	p.Reviews = &prd.ReviewsDefinition{
+		QualityScores: &prd.QualityScores{OverallScore: 9.5},
+		Decision:      prd.ReviewApprove,
+		Blockers:      []prd.Blocker{{ID: "F-1", Category: "metrics_quality", Description: "Metrics lack baselines"}},
+	}
+	scored := Score(p)
+
+	passed, message := ScoreGate(8).Check(p)
+	if want := fmt.Sprintf("%.1f is below 8.0", scored.WeightedScore); passed || message != want {
+		t.Errorf("expected the score gate to use the scored %q despite the review, got %v %q", want, passed, message)
+	}
+	if passed, _ := ScoreGate(0).Check(p); !passed {
+		t.Error("expected the score gate to pass with a minimum of 0")
+	}
+
+	passed, message = BlockersGate().Check(p)
+	if passed || !strings.Contains(message, "Metrics lack baselines") {
+		t.Errorf("expected the blockers gate to fail on the review's blocker, got %v %q", passed, message)
+	}
+	for _, b := range scored.Blockers {
+		if !strings.Contains(message, b) {
+			t.Errorf("expected the scored blocker %q in %q", b, message)
+		}
+	}
+
+	p.Reviews.Blockers = nil
+	passed, message = BlockersGate().Check(p)
+	if passed != (len(scored.Blockers) == 0) || strings.Contains(message, "Metrics lack baselines") {
+		t.Errorf("expected only the scored blockers without the review's, got %v %q", passed, message)
+	}
+}
+
+func TestDefaultWorkflow(t *testing.T) {
+	w := DefaultWorkflow()
+	if gates := w.Gates[prd.StatusInReview]; len(gates) != 1 || gates[0].Name != "valid" {
+		t.Errorf("unexpected review gates: %+v", gates)
+	}
+
+	var names []string
+	for _, g := range w.Gates[prd.StatusApproved] {
+		names = append(names, g.Name)
+	}
+	if got := strings.Join(names, ","); got != "score,blockers,sign-off" {
+		t.Errorf("unexpected approval gates: %s", got)
+	}
+}
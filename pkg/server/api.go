@@ -0,0 +1,488 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/agent-team-prd/pkg/scoring"
+	"github.com/agentplexus/agent-team-prd/pkg/service"
+	"github.com/agentplexus/agent-team-prd/pkg/views"
+	"github.com/agentplexus/agent-team-prd/pkg/workspace"
+	"github.com/agentplexus/structured-evaluation/evaluation"
+)
+
+// View types served by /api/prds/{id}/views/{view}.
+const (
+	ViewPM       = "pm"
+	ViewExec     = "exec"
+	ViewDiagrams = "diagrams"
+	ViewSixPager = "sixpager"
+)
+
+// Document is a PRD and its file path relative to the workspace root.
+type Document struct {
+	Path string   `json:"path" jsonschema:"Path to the PRD file, relative to the workspace root"`
+	PRD  *prd.PRD `json:"prd" jsonschema:"The PRD document"`
+}
+
+// ViewResponse is a rendered view of a PRD.
+type ViewResponse struct {
+	View     string `json:"view" jsonschema:"View type: pm, exec, diagrams or sixpager"`
+	Markdown string `json:"markdown" jsonschema:"Rendered view"`
+}
+
+// ScoreResponse is the scoring result, with the checks behind each
+// category score when explain is set.
+type ScoreResponse struct {
+	*scoring.ScoringResult
+	Explanation []scoring.CategoryExplanation `json:"explanation,omitempty" jsonschema:"Score, pass state and failed checks per category, when explain is set"`
+}
+
+// NextActionsResponse lists the suggested changes, highest gain first.
+type NextActionsResponse struct {
+	Score   float64              `json:"score" jsonschema:"Current weighted score"`
+	Actions []scoring.NextAction `json:"actions" jsonschema:"Suggested changes"`
+}
+
+// CreateRequest creates a PRD.
+type CreateRequest struct {
+	Path  string `json:"path,omitempty" jsonschema:"File to create, relative to the workspace root (default: PRD.json)"`
+	ID    string `json:"id,omitempty" jsonschema:"PRD ID (default: generated from the date)"`
+	Title string `json:"title" jsonschema:"PRD title"`
+	Owner string `json:"owner" jsonschema:"Owner name"`
+}
+
+// PatchRequest edits a PRD with exactly one of a JSON Patch or a JSON
+// Merge Patch.
+type PatchRequest struct {
+	Operations []prd.PatchOperation   `json:"operations,omitempty" jsonschema:"RFC 6902 JSON Patch operations, applied all-or-nothing"`
+	Merge      map[string]interface{} `json:"merge,omitempty" jsonschema:"RFC 7396 JSON Merge Patch; null values remove fields"`
+}
+
+// StatusRequest updates the PRD status.
+type StatusRequest struct {
+	Status string `json:"status" jsonschema:"New status: draft, in_review, approved or deprecated"`
+}
+
+// SelectSolutionRequest selects a solution option.
+type SelectSolutionRequest struct {
+	ID        string `json:"id" jsonschema:"Solution ID, e.g. SOL-1"`
+	Rationale string `json:"rationale,omitempty" jsonschema:"Why this solution was selected"`
+}
+
+// CommentRequest adds a comment.
+type CommentRequest struct {
+	Author string `json:"author" jsonschema:"Comment author"`
+	Body   string `json:"body" jsonschema:"Comment text"`
+	Target string `json:"target,omitempty" jsonschema:"ID of the entry the comment is about, e.g. FR-3"`
+}
+
+// ResolveRequest resolves or reopens a comment.
+type ResolveRequest struct {
+	Resolved bool `json:"resolved" jsonschema:"Whether the comment is resolved"`
+}
+
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request) {
+	ws, err := s.discover()
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	filter := workspace.Filter{
+		Status: r.URL.Query().Get("status"),
+		Tag:    r.URL.Query().Get("tag"),
+	}
+	pf := workspace.Summarize(s.Root, ws.Select(filter), workspace.PortfolioOptions{Score: true, Validate: true})
+	pf.AddFailures(ws.Failures)
+	writeJSON(w, http.StatusOK, pf)
+}
+
+func (s *Server) handleKinds(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, prd.EntityKinds())
+}
+
+func (s *Server) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var req CreateRequest
+	if err := decode(r, &req); err != nil {
+		fail(w, err)
+		return
+	}
+	if strings.TrimSpace(req.Title) == "" || strings.TrimSpace(req.Owner) == "" {
+		fail(w, badRequest(errors.New("title and owner are required")))
+		return
+	}
+	if req.Path == "" {
+		req.Path = service.DefaultPath
+	}
+	if filepath.Ext(req.Path) != ".json" {
+		fail(w, badRequest(fmt.Errorf("path %q must be a .json file", req.Path)))
+		return
+	}
+	if req.ID == "" {
+		req.ID = prd.GenerateID()
+	}
+
+	path, err := workspace.Confine(s.Root, req.Path)
+	if err != nil {
+		fail(w, badRequest(err))
+		return
+	}
+	if _, _, err := s.load(req.ID); err == nil {
+		fail(w, fmt.Errorf("PRD %s: %w", req.ID, errConflict))
+		return
+	} else if !errors.Is(err, errPRDNotFound) {
+		fail(w, err)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		fail(w, err)
+		return
+	}
+
+	res, err := s.Service.Init(service.InitRequest{Path: path, ID: req.ID, Title: req.Title, Owner: req.Owner})
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	if err := setETag(w, res.PRD); err != nil {
+		fail(w, err)
+		return
+	}
+	rel, err := filepath.Rel(s.Root, path)
+	if err != nil {
+		rel = req.Path
+	}
+	w.Header().Set("Location", "/api/prds/"+req.ID)
+	writeJSON(w, http.StatusCreated, Document{Path: rel, PRD: res.PRD})
+}
+
+func (s *Server) handleGet(w http.ResponseWriter, r *http.Request) {
+	doc, _, err := s.load(r.PathValue("id"))
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	tag, err := etag(doc.PRD)
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	w.Header().Set("ETag", tag)
+	if match := r.Header.Get("If-None-Match"); match != "" && etagMatches(match, tag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	writeJSON(w, http.StatusOK, Document{Path: doc.Path, PRD: doc.PRD})
+}
+
+func (s *Server) handlePatch(w http.ResponseWriter, r *http.Request) {
+	var req PatchRequest
+	if err := decode(r, &req); err != nil {
+		fail(w, err)
+		return
+	}
+
+	doc, _, err := s.change(w, r, func(svc *service.Service, path string) (*service.Result, error) {
+		return svc.Patch(service.PatchRequest{Path: path, Operations: req.Operations, Merge: req.Merge})
+	})
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, Document{Path: doc.Path, PRD: doc.PRD})
+}
+
+func (s *Server) handleScore(w http.ResponseWriter, r *http.Request) {
+	doc, _, err := s.load(r.PathValue("id"))
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	resp := ScoreResponse{ScoringResult: scoring.Score(doc.PRD)}
+	if r.URL.Query().Get("explain") == "true" {
+		resp.Explanation = scoring.Explain(doc.PRD)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleNextActions(w http.ResponseWriter, r *http.Request) {
+	limit := 10
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			fail(w, badRequest(fmt.Errorf("invalid limit %q", v)))
+			return
+		}
+		limit = n
+	}
+	doc, _, err := s.load(r.PathValue("id"))
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	resp := NextActionsResponse{
+		Score:   scoring.Score(doc.PRD).WeightedScore,
+		Actions: scoring.NextActions(doc.PRD, doc.Path),
+	}
+	if len(resp.Actions) > limit {
+		resp.Actions = resp.Actions[:limit]
+	}
+	if resp.Actions == nil {
+		resp.Actions = []scoring.NextAction{}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleValidation(w http.ResponseWriter, r *http.Request) {
+	doc, _, err := s.load(r.PathValue("id"))
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, prd.Validate(doc.PRD))
+}
+
+func (s *Server) handleView(w http.ResponseWriter, r *http.Request) {
+	doc, _, err := s.load(r.PathValue("id"))
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	view := r.PathValue("view")
+	markdown, err := renderView(doc.PRD, view)
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, ViewResponse{View: view, Markdown: markdown})
+}
+
+// renderView renders a view as markdown, as 'prdtool view' does.
+func renderView(p *prd.PRD, view string) (string, error) {
+	switch view {
+	case ViewPM:
+		return views.RenderPMViewMarkdown(p)
+	case ViewExec:
+		view, err := views.GenerateExecView(p, scoring.ReviewOrScore(p))
+		if err != nil {
+			return "", err
+		}
+		return views.RenderExecMarkdown(view), nil
+	case ViewDiagrams:
+		return views.RenderDiagramsViewMarkdown(p), nil
+	case ViewSixPager:
+		return prd.RenderSixPagerMarkdown(prd.GenerateSixPagerView(p)), nil
+	default:
+		return "", badRequest(fmt.Errorf("unknown view %q: use %s, %s, %s or %s", view, ViewPM, ViewExec, ViewDiagrams, ViewSixPager))
+	}
+}
+
+func (s *Server) handleUpdateStatus(w http.ResponseWriter, r *http.Request) {
+	var req StatusRequest
+	if err := decode(r, &req); err != nil {
+		fail(w, err)
+		return
+	}
+
+	doc, _, err := s.change(w, r, func(svc *service.Service, path string) (*service.Result, error) {
+		return svc.UpdateStatus(service.UpdateStatusRequest{Path: path, Status: req.Status})
+	})
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, Document{Path: doc.Path, PRD: doc.PRD})
+}
+
+func (s *Server) handleSelectSolution(w http.ResponseWriter, r *http.Request) {
+	var req SelectSolutionRequest
+	if err := decode(r, &req); err != nil {
+		fail(w, err)
+		return
+	}
+
+	doc, _, err := s.change(w, r, func(svc *service.Service, path string) (*service.Result, error) {
+		return svc.SelectSolution(service.SelectSolutionRequest{Path: path, ID: req.ID, Rationale: req.Rationale})
+	})
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, Document{Path: doc.Path, PRD: doc.PRD})
+}
+
+func (s *Server) handleRecordReview(w http.ResponseWriter, r *http.Request) {
+	var report evaluation.EvaluationReport
+	if err := decode(r, &report); err != nil {
+		fail(w, err)
+		return
+	}
+
+	doc, _, err := s.change(w, r, func(svc *service.Service, path string) (*service.Result, error) {
+		return svc.RecordReview(service.RecordReviewRequest{Path: path, Report: &report})
+	})
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, Document{Path: doc.Path, PRD: doc.PRD})
+}
+
+func (s *Server) handleComments(w http.ResponseWriter, r *http.Request) {
+	doc, _, err := s.load(r.PathValue("id"))
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	comments, err := prd.GetComments(doc.PRD)
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	if comments == nil {
+		comments = []prd.Comment{}
+	}
+	writeJSON(w, http.StatusOK, comments)
+}
+
+func (s *Server) handleAddComment(w http.ResponseWriter, r *http.Request) {
+	var req CommentRequest
+	if err := decode(r, &req); err != nil {
+		fail(w, err)
+		return
+	}
+
+	var comment prd.Comment
+	_, err := s.update(w, r, func(p *prd.PRD) error {
+		var err error
+		comment, err = prd.AddComment(p, req.Author, req.Body, req.Target)
+		if err != nil && !errors.Is(err, prd.ErrEntityNotFound) {
+			return badRequest(err)
+		}
+		return err
+	})
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, comment)
+}
+
+func (s *Server) handleResolveComment(w http.ResponseWriter, r *http.Request) {
+	var req ResolveRequest
+	if err := decode(r, &req); err != nil {
+		fail(w, err)
+		return
+	}
+
+	var comment prd.Comment
+	_, err := s.update(w, r, func(p *prd.PRD) error {
+		var err error
+		comment, err = prd.ResolveComment(p, r.PathValue("comment"), req.Resolved)
+		return err
+	})
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, comment)
+}
+
+func (s *Server) handleEntities(w http.ResponseWriter, r *http.Request) {
+	kind, err := lookupKind(r)
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	doc, _, err := s.load(r.PathValue("id"))
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, kind.List(doc.PRD))
+}
+
+func (s *Server) handleCreateEntity(w http.ResponseWriter, r *http.Request) {
+	kind, err := lookupKind(r)
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	var values map[string]string
+	if err := decode(r, &values); err != nil {
+		fail(w, err)
+		return
+	}
+
+	doc, res, err := s.change(w, r, func(svc *service.Service, path string) (*service.Result, error) {
+		return svc.CreateEntity(service.CreateEntityRequest{Path: path, Kind: kind.Name, Values: values})
+	})
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	entity, err := kind.Get(doc.PRD, res.ID)
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, entity)
+}
+
+func (s *Server) handleUpdateEntity(w http.ResponseWriter, r *http.Request) {
+	kind, err := lookupKind(r)
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	var values map[string]string
+	if err := decode(r, &values); err != nil {
+		fail(w, err)
+		return
+	}
+
+	doc, res, err := s.change(w, r, func(svc *service.Service, path string) (*service.Result, error) {
+		return svc.UpdateEntity(service.UpdateEntityRequest{Path: path, Kind: kind.Name, ID: r.PathValue("entity"), Values: values})
+	})
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	entity, err := kind.Get(doc.PRD, res.ID)
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, entity)
+}
+
+func (s *Server) handleDeleteEntity(w http.ResponseWriter, r *http.Request) {
+	kind, err := lookupKind(r)
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	_, _, err = s.change(w, r, func(svc *service.Service, path string) (*service.Result, error) {
+		return svc.RemoveEntity(service.RemoveEntityRequest{Path: path, Kind: kind.Name, ID: r.PathValue("entity")})
+	})
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func lookupKind(r *http.Request) (*prd.EntityKind, error) {
+	name := r.PathValue("kind")
+	kind, ok := prd.LookupEntityKind(name)
+	if !ok {
+		return nil, fmt.Errorf("entity kind %q: %w", name, prd.ErrEntityNotFound)
+	}
+	return kind, nil
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/agent-team-prd/pkg/workspace"
+)
+
+// docIndex caches where each PRD ID was last found, so a request loads one
+// file instead of walking the workspace. A file whose modification time has
+// changed is checked to still hold its ID; the workspace is walked again
+// when an ID is unknown or its file has moved, gone or been renumbered.
+type docIndex struct {
+	mu      sync.Mutex
+	entries map[string]indexEntry
+}
+
+type indexEntry struct {
+	path    string // relative to the workspace root
+	modTime time.Time
+}
+
+func newDocIndex() *docIndex {
+	return &docIndex{entries: make(map[string]indexEntry)}
+}
+
+// rebuild replaces the index with the documents of a discovered workspace.
+func (x *docIndex) rebuild(ws *workspace.Workspace) {
+	entries := make(map[string]indexEntry, len(ws.Documents))
+	for _, d := range ws.Documents {
+		entry := indexEntry{path: d.Path}
+		if info, err := os.Stat(filepath.Join(ws.Root, d.Path)); err == nil {
+			entry.modTime = info.ModTime()
+		}
+		entries[d.PRD.Metadata.ID] = entry
+	}
+
+	x.mu.Lock()
+	x.entries = entries
+	x.mu.Unlock()
+}
+
+// lookup loads the PRD with the given ID from its indexed file. It returns
+// false if the ID isn't indexed or its file no longer holds it, and an
+// error if the file is there but can't be loaded.
+func (x *docIndex) lookup(root, id string) (*workspace.Document, bool, error) {
+	x.mu.Lock()
+	entry, ok := x.entries[id]
+	x.mu.Unlock()
+	if !ok {
+		return nil, false, nil
+	}
+
+	path := filepath.Join(root, entry.path)
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", entry.path, err)
+	}
+	p, err := prd.Load(path)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load %s: %w", entry.path, err)
+	}
+
+	if !info.ModTime().Equal(entry.modTime) {
+		if p.Metadata.ID != id {
+			return nil, false, nil
+		}
+		entry.modTime = info.ModTime()
+		x.mu.Lock()
+		x.entries[id] = entry
+		x.mu.Unlock()
+	}
+	return &workspace.Document{Path: entry.path, PRD: p}, true, nil
+}
+
+// discover walks the workspace and rebuilds the index from it.
+func (s *Server) discover() (*workspace.Workspace, error) {
+	ws, err := workspace.Discover(s.Root)
+	if err != nil {
+		return nil, err
+	}
+	s.index.rebuild(ws)
+	return ws, nil
+}
+
+// notFound reports that no PRD has the ID, naming the files that failed to
+// load, since the PRD may be one of them.
+func notFound(id string, failures []workspace.Failure) error {
+	if len(failures) == 0 {
+		return fmt.Errorf("%s: %w", id, errPRDNotFound)
+	}
+	msgs := make([]string, len(failures))
+	for i, f := range failures {
+		msgs[i] = f.Path + ": " + f.Error
+	}
+	return fmt.Errorf("%s: %w (failed to load %s)", id, errPRDNotFound, strings.Join(msgs, "; "))
+}
@@ -0,0 +1,338 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// APIVersion is the version reported in the OpenAPI document.
+const APIVersion = "1.0.0"
+
+// pathParam matches the {name} wildcards in a route path.
+var pathParam = regexp.MustCompile(`\{(\w+)\}`)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// pathParamDescriptions describes the path wildcards used by the routes.
+var pathParamDescriptions = map[string]string{
+	"id":      "PRD ID, e.g. PRD-2026-001",
+	"view":    "View type: pm, exec, diagrams or sixpager",
+	"comment": "Comment ID, e.g. CMT-1",
+	"kind":    "Entity kind, as listed by /api/kinds",
+	"entity":  "Entity ID, e.g. FR-3",
+}
+
+// OpenAPI returns the OpenAPI 3.1 description of the API. Operations come
+// from the route table and schemas are generated from the request and
+// response types, so the document always matches the server.
+func (s *Server) OpenAPI() (map[string]interface{}, error) {
+	schemas := &schemaSet{
+		schemas: map[string]interface{}{
+			"Error": map[string]interface{}{
+				"type":     "object",
+				"required": []string{"error"},
+				"properties": map[string]interface{}{
+					"error": map[string]interface{}{"type": "string"},
+				},
+			},
+		},
+		types: map[string]reflect.Type{},
+	}
+
+	paths := map[string]interface{}{}
+	for _, rt := range s.api() {
+		op, err := operation(rt, schemas)
+		if err != nil {
+			return nil, err
+		}
+		item, ok := paths[rt.path].(map[string]interface{})
+		if !ok {
+			item = map[string]interface{}{}
+			paths[rt.path] = item
+		}
+		item[strings.ToLower(rt.method)] = op
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.1.0",
+		"info": map[string]interface{}{
+			"title":       "prdtool API",
+			"version":     APIVersion,
+			"description": "Load, validate, score, render and edit the PRDs in a workspace.",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas.schemas,
+			"headers": map[string]interface{}{
+				"ETag": map[string]interface{}{
+					"description": "Version of the PRD; send it in If-Match to update only that version",
+					"schema":      map[string]interface{}{"type": "string"},
+				},
+			},
+		},
+	}, nil
+}
+
+// operation describes one route, adding the schemas it refers to.
+func operation(rt route, schemas *schemaSet) (map[string]interface{}, error) {
+	var params []interface{}
+	for _, m := range pathParam.FindAllStringSubmatch(rt.path, -1) {
+		params = append(params, map[string]interface{}{
+			"name":        m[1],
+			"in":          "path",
+			"required":    true,
+			"description": pathParamDescriptions[m[1]],
+			"schema":      map[string]interface{}{"type": "string"},
+		})
+	}
+	for _, q := range rt.query {
+		params = append(params, map[string]interface{}{
+			"name":        q.name,
+			"in":          "query",
+			"description": q.description,
+			"schema":      map[string]interface{}{"type": q.typ},
+		})
+	}
+	if rt.write {
+		params = append(params, map[string]interface{}{
+			"name":        "If-Match",
+			"in":          "header",
+			"description": "Only apply the change if the PRD's ETag matches",
+			"schema":      map[string]interface{}{"type": "string"},
+		})
+	}
+
+	success := map[string]interface{}{"description": http.StatusText(rt.status)}
+	if rt.response != nil {
+		ref, err := schemas.ref(reflect.TypeOf(rt.response))
+		if err != nil {
+			return nil, err
+		}
+		success["content"] = map[string]interface{}{
+			"application/json": map[string]interface{}{"schema": ref},
+		}
+	}
+	if rt.write || rt.path == "/api/prds/{id}" {
+		success["headers"] = map[string]interface{}{
+			"ETag": map[string]interface{}{"$ref": "#/components/headers/ETag"},
+		}
+	}
+
+	errorResponse := map[string]interface{}{
+		"description": "Error",
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{
+				"schema": map[string]interface{}{"$ref": "#/components/schemas/Error"},
+			},
+		},
+	}
+
+	op := map[string]interface{}{
+		"summary":     rt.summary,
+		"operationId": operationID(rt),
+		"responses": map[string]interface{}{
+			strconv.Itoa(rt.status): success,
+			"default":               errorResponse,
+		},
+	}
+	if len(params) > 0 {
+		op["parameters"] = params
+	}
+	if rt.request != nil {
+		ref, err := schemas.ref(reflect.TypeOf(rt.request))
+		if err != nil {
+			return nil, err
+		}
+		op["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": ref},
+			},
+		}
+	}
+	return op, nil
+}
+
+// schemaSet collects the component schemas of an OpenAPI document.
+type schemaSet struct {
+	schemas map[string]interface{}
+	// types records the Go type behind each component name.
+	types map[string]reflect.Type
+}
+
+// ref returns the JSON Schema for t, following encoding/json. Named struct
+// types are added to the set and referenced with $ref, which also handles
+// recursive types.
+func (s *schemaSet) ref(t reflect.Type) (interface{}, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}, nil
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}, nil
+	case reflect.Interface:
+		return map[string]interface{}{}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			if t == rawMessageType {
+				return map[string]interface{}{}, nil
+			}
+			return map[string]interface{}{"type": "string", "contentEncoding": "base64"}, nil
+		}
+		items, err := s.ref(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "array", "items": items}, nil
+	case reflect.Map:
+		values, err := s.ref(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "object", "additionalProperties": values}, nil
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.object(t)
+		}
+		name := s.name(t)
+		ref := map[string]interface{}{"$ref": "#/components/schemas/" + name}
+		if _, ok := s.schemas[name]; ok {
+			return ref, nil
+		}
+		// Reserve the name before recursing so that cycles end in a $ref.
+		s.schemas[name] = nil
+		schema, err := s.object(t)
+		if err != nil {
+			return nil, err
+		}
+		s.schemas[name] = schema
+		return ref, nil
+	default:
+		return nil, fmt.Errorf("type %s cannot be described in JSON Schema", t)
+	}
+}
+
+// object describes the JSON object encoding of a struct type. Fields of
+// embedded structs are promoted, and fields without omitempty are
+// required.
+func (s *schemaSet) object(t reflect.Type) (map[string]interface{}, error) {
+	properties := map[string]interface{}{}
+	var required []string
+	var addFields func(t reflect.Type) error
+	addFields = func(t reflect.Type) error {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag := f.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name, opts, _ := strings.Cut(tag, ",")
+			ft := f.Type
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+				if err := addFields(ft); err != nil {
+					return err
+				}
+				continue
+			}
+			if !f.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+
+			schema, err := s.ref(f.Type)
+			if err != nil {
+				return fmt.Errorf("%s.%s: %w", t.Name(), f.Name, err)
+			}
+			if desc := f.Tag.Get("jsonschema"); desc != "" {
+				schema = describe(schema, desc)
+			}
+			properties[name] = schema
+			if !strings.Contains(opts, "omitempty") && !strings.Contains(opts, "omitzero") {
+				required = append(required, name)
+			}
+		}
+		return nil
+	}
+	if err := addFields(t); err != nil {
+		return nil, err
+	}
+
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema, nil
+}
+
+// describe adds a description to a schema. A $ref cannot have siblings in
+// every tool, so referenced schemas are wrapped in allOf.
+func describe(schema interface{}, desc string) interface{} {
+	m := schema.(map[string]interface{})
+	if _, ok := m["$ref"]; ok {
+		return map[string]interface{}{"allOf": []interface{}{m}, "description": desc}
+	}
+	m["description"] = desc
+	return m
+}
+
+// name returns the component name for a named type: its Go name, or the
+// package-qualified name if another type already uses it.
+func (s *schemaSet) name(t reflect.Type) string {
+	name := t.Name()
+	if owner, ok := s.types[name]; ok && owner != t {
+		name = path.Base(t.PkgPath()) + "." + name
+	}
+	s.types[name] = t
+	return name
+}
+
+// operationID derives a stable operation ID such as "getPrdsIdScore" from
+// the method and path.
+func operationID(rt route) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(rt.method))
+	for _, part := range strings.FieldsFunc(rt.path, func(r rune) bool {
+		return r == '/' || r == '{' || r == '}' || r == '.' || r == '-'
+	}) {
+		if part == "api" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+func (s *Server) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	doc, err := s.OpenAPI()
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, doc)
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestOpenAPI(t *testing.T) {
+	ts, s, _ := newTestServer(t)
+
+	resp, body := do(t, ts, "GET", "/api/openapi.json", "")
+	expectStatus(t, resp, body, http.StatusOK)
+
+	var doc struct {
+		OpenAPI string `json:"openapi"`
+		Paths   map[string]map[string]struct {
+			OperationID string                     `json:"operationId"`
+			Parameters  []map[string]interface{}   `json:"parameters"`
+			RequestBody map[string]interface{}     `json:"requestBody"`
+			Responses   map[string]json.RawMessage `json:"responses"`
+		} `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Required   []string               `json:"required"`
+				Properties map[string]interface{} `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.OpenAPI != "3.1.0" {
+		t.Errorf("expected OpenAPI 3.1.0, got %s", doc.OpenAPI)
+	}
+
+	// Every route is documented, with its parameters and success status.
+	ids := map[string]bool{}
+	for _, rt := range s.api() {
+		op, ok := doc.Paths[rt.path][strings.ToLower(rt.method)]
+		if !ok {
+			t.Errorf("%s %s is not documented", rt.method, rt.path)
+			continue
+		}
+		if ids[op.OperationID] {
+			t.Errorf("duplicate operation ID %s", op.OperationID)
+		}
+		ids[op.OperationID] = true
+		if _, ok := op.Responses[strconv.Itoa(rt.status)]; !ok {
+			t.Errorf("%s %s: expected a %d response", rt.method, rt.path, rt.status)
+		}
+		if (rt.request != nil) != (op.RequestBody != nil) {
+			t.Errorf("%s %s: request body mismatch", rt.method, rt.path)
+		}
+	}
+
+	op := doc.Paths["/api/prds/{id}/entities/{kind}/{entity}"]["patch"]
+	var names []string
+	for _, p := range op.Parameters {
+		names = append(names, p["name"].(string))
+	}
+	if strings.Join(names, ",") != "id,kind,entity,If-Match" {
+		t.Errorf("unexpected parameters: %v", names)
+	}
+
+	// Schemas are generated from the Go types.
+	create, ok := doc.Components.Schemas["CreateRequest"]
+	if !ok {
+		t.Fatal("expected a CreateRequest schema")
+	}
+	if strings.Join(create.Required, ",") != "title,owner" {
+		t.Errorf("expected title and owner to be required, got %v", create.Required)
+	}
+	for _, name := range []string{"Document", "Comment", "Entity", "ScoreResponse", "Error"} {
+		if _, ok := doc.Components.Schemas[name]; !ok {
+			t.Errorf("expected a %s schema", name)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/agent-team-prd/pkg/workspace"
+	"github.com/agentplexus/structured-evaluation/evaluation"
+)
+
+// route is an API endpoint. The same table registers the handlers and
+// generates the OpenAPI document, so the two cannot drift apart.
+type route struct {
+	method  string
+	path    string
+	summary string
+	handler http.HandlerFunc
+
+	// write marks endpoints that change a PRD. They are wrapped by
+	// Server.write and accept If-Match.
+	write bool
+
+	// query lists the query parameters.
+	query []param
+
+	// request and response are values of the request and response body
+	// types, or nil when there is no body.
+	request  interface{}
+	response interface{}
+
+	// status is the status code of a successful response.
+	status int
+}
+
+// param is a query parameter.
+type param struct {
+	name        string
+	typ         string
+	description string
+}
+
+func (s *Server) api() []route {
+	return []route{
+		{
+			method: "GET", path: "/api/openapi.json", summary: "OpenAPI description of this API",
+			handler: s.handleOpenAPI, response: map[string]interface{}{}, status: http.StatusOK,
+		},
+		{
+			method: "GET", path: "/api/kinds", summary: "List the entity kinds and their fields",
+			handler: s.handleKinds, response: []prd.EntityKind{}, status: http.StatusOK,
+		},
+		{
+			method: "GET", path: "/api/prds", summary: "Summarize the PRDs in the workspace",
+			handler: s.handleList, response: workspace.Portfolio{}, status: http.StatusOK,
+			query: []param{
+				{"status", "string", "Only PRDs with this status"},
+				{"tag", "string", "Only PRDs with this tag"},
+			},
+		},
+		{
+			method: "POST", path: "/api/prds", summary: "Create a PRD",
+			handler: s.handleCreate, write: true,
+			request: CreateRequest{}, response: Document{}, status: http.StatusCreated,
+		},
+		{
+			method: "GET", path: "/api/prds/{id}", summary: "Load a PRD",
+			handler: s.handleGet, response: Document{}, status: http.StatusOK,
+		},
+		{
+			method: "PATCH", path: "/api/prds/{id}", summary: "Apply a JSON Patch or JSON Merge Patch",
+			handler: s.handlePatch, write: true,
+			request: PatchRequest{}, response: Document{}, status: http.StatusOK,
+		},
+		{
+			method: "GET", path: "/api/prds/{id}/validation", summary: "Validate a PRD",
+			handler: s.handleValidation, response: prd.ValidationResult{}, status: http.StatusOK,
+		},
+		{
+			method: "GET", path: "/api/prds/{id}/score", summary: "Score a PRD",
+			handler: s.handleScore, response: ScoreResponse{}, status: http.StatusOK,
+			query: []param{
+				{"explain", "boolean", "Include the passed and failed checks behind each category score"},
+			},
+		},
+		{
+			method: "GET", path: "/api/prds/{id}/next", summary: "Suggest the changes that would most improve the score",
+			handler: s.handleNextActions, response: NextActionsResponse{}, status: http.StatusOK,
+			query: []param{
+				{"limit", "integer", "Maximum number of actions (default: 10)"},
+			},
+		},
+		{
+			method: "GET", path: "/api/prds/{id}/views/{view}", summary: "Render the pm, exec, diagrams or sixpager view as markdown",
+			handler: s.handleView, response: ViewResponse{}, status: http.StatusOK,
+		},
+		{
+			method: "PUT", path: "/api/prds/{id}/status", summary: "Update the PRD status, subject to the workflow gates",
+			handler: s.handleUpdateStatus, write: true,
+			request: StatusRequest{}, response: Document{}, status: http.StatusOK,
+		},
+		{
+			method: "PUT", path: "/api/prds/{id}/solution", summary: "Select the solution option",
+			handler: s.handleSelectSolution, write: true,
+			request: SelectSolutionRequest{}, response: Document{}, status: http.StatusOK,
+		},
+		{
+			method: "POST", path: "/api/prds/{id}/reviews", summary: "Record an evaluation report in the reviews section",
+			handler: s.handleRecordReview, write: true,
+			request: evaluation.EvaluationReport{}, response: Document{}, status: http.StatusOK,
+		},
+		{
+			method: "GET", path: "/api/prds/{id}/comments", summary: "List comments",
+			handler: s.handleComments, response: []prd.Comment{}, status: http.StatusOK,
+		},
+		{
+			method: "POST", path: "/api/prds/{id}/comments", summary: "Add a comment",
+			handler: s.handleAddComment, write: true,
+			request: CommentRequest{}, response: prd.Comment{}, status: http.StatusCreated,
+		},
+		{
+			method: "PATCH", path: "/api/prds/{id}/comments/{comment}", summary: "Resolve or reopen a comment",
+			handler: s.handleResolveComment, write: true,
+			request: ResolveRequest{}, response: prd.Comment{}, status: http.StatusOK,
+		},
+		{
+			method: "GET", path: "/api/prds/{id}/entities/{kind}", summary: "List the entities of a kind",
+			handler: s.handleEntities, response: []prd.Entity{}, status: http.StatusOK,
+		},
+		{
+			method: "POST", path: "/api/prds/{id}/entities/{kind}", summary: "Add an entity from field values",
+			handler: s.handleCreateEntity, write: true,
+			request: map[string]string{}, response: prd.Entity{}, status: http.StatusCreated,
+		},
+		{
+			method: "PATCH", path: "/api/prds/{id}/entities/{kind}/{entity}", summary: "Update the given fields of an entity",
+			handler: s.handleUpdateEntity, write: true,
+			request: map[string]string{}, response: prd.Entity{}, status: http.StatusOK,
+		},
+		{
+			method: "DELETE", path: "/api/prds/{id}/entities/{kind}/{entity}", summary: "Remove an entity",
+			handler: s.handleDeleteEntity, write: true, status: http.StatusNoContent,
+		},
+	}
+}
@@ -0,0 +1,336 @@
+// Package server serves a browser UI and a JSON REST API for the PRDs in a
+// workspace.
+//
+// The API is a thin layer over pkg/service, pkg/scoring and pkg/views: it
+// lists the workspace portfolio, returns PRDs with their scores,
+// validation results and rendered views, and makes changes (creating
+// PRDs, patches, status changes, reviews and entity edits) with the same
+// service operations as prdtool and prdtool-mcp. Changes honor If-Match against the ETag of the PRD, and
+// GET /api/openapi.json describes every endpoint. The UI is a static page
+// embedded in the binary that calls the API, so no external services are
+// needed.
+package server
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/agent-team-prd/pkg/service"
+	"github.com/agentplexus/agent-team-prd/pkg/workspace"
+)
+
+//go:embed ui
+var uiFiles embed.FS
+
+// maxBodyBytes limits the size of request bodies.
+const maxBodyBytes = 1 << 20
+
+var (
+	// errPRDNotFound is returned when no PRD in the workspace has the
+	// requested ID.
+	errPRDNotFound = errors.New("PRD not found")
+
+	// errConflict is returned when a new PRD would replace an existing
+	// file or reuse an existing ID.
+	errConflict = errors.New("already exists")
+
+	// errPreconditionFailed is returned when a request's If-Match header
+	// does not match the PRD's current ETag.
+	errPreconditionFailed = errors.New("PRD has changed since it was read")
+)
+
+// Server serves the UI and API for a workspace directory.
+type Server struct {
+	// Root is the workspace directory. PRDs are discovered under it and
+	// indexed by ID; the index is refreshed when a file changes or an ID
+	// is unknown, so files added or edited on disk show up without a
+	// restart.
+	Root string
+
+	// Addr is the address the server listens on. Requests that change a
+	// PRD must name it, localhost or a loopback IP in their Host header,
+	// so a site that rebinds its DNS name to the server can't make them.
+	// A server listening on all interfaces (e.g. ":8090" or "0.0.0.0:8090")
+	// accepts any Host, since clients reach it by names it can't know.
+	Addr string
+
+	// ReadOnly rejects comments and edits.
+	ReadOnly bool
+
+	// Service makes the changes and serializes them per file. Its
+	// Workflow checks status changes, including those made by patches.
+	Service *service.Service
+
+	mux   *http.ServeMux
+	index *docIndex
+}
+
+// New returns a server for the workspace at root.
+func New(root string) *Server {
+	s := &Server{Root: root, Service: service.New(), mux: http.NewServeMux(), index: newDocIndex()}
+	s.routes()
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) routes() {
+	ui, err := fs.Sub(uiFiles, "ui")
+	if err != nil {
+		panic(err)
+	}
+	s.mux.Handle("GET /", http.FileServer(http.FS(ui)))
+
+	for _, rt := range s.api() {
+		h := rt.handler
+		if rt.write {
+			h = s.write(h)
+		}
+		s.mux.HandleFunc(rt.method+" "+rt.path, h)
+	}
+}
+
+// write wraps a handler that changes a PRD. It rejects the request in
+// read-only mode or when its Host isn't the server's (see Addr), and
+// requires a JSON body for requests that have one, so that a page on
+// another site cannot submit a form to the local server.
+func (s *Server) write(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.ReadOnly {
+			writeError(w, http.StatusForbidden, errors.New("server is read-only"))
+			return
+		}
+		if !s.allowedHost(r.Host) {
+			writeError(w, http.StatusForbidden, fmt.Errorf("host %q is not allowed", r.Host))
+			return
+		}
+		if r.Method != http.MethodDelete {
+			if mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mt != "application/json" {
+				writeError(w, http.StatusUnsupportedMediaType, errors.New("request body must be application/json"))
+				return
+			}
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
+		h(w, r)
+	}
+}
+
+// allowedHost reports whether a Host header names the server: its listen
+// address, localhost or a loopback IP.
+func (s *Server) allowedHost(host string) bool {
+	if host == "" {
+		return false
+	}
+	if s.Addr != "" && strings.EqualFold(host, s.Addr) {
+		return true
+	}
+	name := host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		name = h
+	}
+	name = strings.Trim(name, "[]")
+	if strings.EqualFold(name, "localhost") {
+		return true
+	}
+	if ip := net.ParseIP(name); ip != nil && ip.IsLoopback() {
+		return true
+	}
+	if s.Addr != "" {
+		if h, _, err := net.SplitHostPort(s.Addr); err == nil {
+			if ip := net.ParseIP(h); h == "" || (ip != nil && ip.IsUnspecified()) || strings.EqualFold(name, h) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// load finds a PRD by ID and returns it with its file path. A PRD whose
+// file can't be loaded is reported as such, not as missing.
+func (s *Server) load(id string) (*workspace.Document, string, error) {
+	doc, ok, err := s.index.lookup(s.Root, id)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", id, err)
+	}
+	if !ok {
+		ws, err := s.discover()
+		if err != nil {
+			return nil, "", err
+		}
+		if doc = ws.FindByID(id); doc == nil {
+			return nil, "", notFound(id, ws.Failures)
+		}
+	}
+	return doc, filepath.Join(s.Root, doc.Path), nil
+}
+
+// change runs a service operation on the PRD named by the request's {id}
+// and returns the changed document. If the request has an If-Match
+// header, the PRD is only changed when its current ETag matches, so
+// clients cannot overwrite changes they have not seen. The new ETag is set
+// on the response.
+func (s *Server) change(w http.ResponseWriter, r *http.Request, op func(svc *service.Service, path string) (*service.Result, error)) (*workspace.Document, *service.Result, error) {
+	doc, path, err := s.load(r.PathValue("id"))
+	if err != nil {
+		return nil, nil, err
+	}
+	svc := s.Service
+	if check := ifMatch(r); check != nil {
+		svc = svc.If(check)
+	}
+	res, err := op(svc, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &workspace.Document{Path: doc.Path, PRD: res.PRD}, res, setETag(w, res.PRD)
+}
+
+// update changes the PRD named by the request's {id} with fn, for changes
+// that have no service operation. It holds the service's lock on the file
+// and honors If-Match like change.
+func (s *Server) update(w http.ResponseWriter, r *http.Request, fn func(p *prd.PRD) error) (*workspace.Document, error) {
+	doc, path, err := s.load(r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+	defer s.Service.Lock(path)()
+
+	p, err := prd.Load(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load PRD: %w", err)
+	}
+	if check := ifMatch(r); check != nil {
+		if err := check(p); err != nil {
+			return nil, err
+		}
+	}
+	if err := fn(p); err != nil {
+		return nil, err
+	}
+	if _, err := service.Save(path, p, false); err != nil {
+		return nil, err
+	}
+	return &workspace.Document{Path: doc.Path, PRD: p}, setETag(w, p)
+}
+
+// ifMatch returns a check that the PRD's ETag matches the request's
+// If-Match header, or nil if it has none.
+func ifMatch(r *http.Request) func(p *prd.PRD) error {
+	match := r.Header.Get("If-Match")
+	if match == "" {
+		return nil
+	}
+	return func(p *prd.PRD) error {
+		tag, err := etag(p)
+		if err != nil {
+			return err
+		}
+		if !etagMatches(match, tag) {
+			return errPreconditionFailed
+		}
+		return nil
+	}
+}
+
+// etag returns the entity tag of a PRD, a hash of its JSON encoding.
+func etag(p *prd.PRD) (string, error) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`, nil
+}
+
+func setETag(w http.ResponseWriter, p *prd.PRD) error {
+	tag, err := etag(p)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("ETag", tag)
+	return nil
+}
+
+// etagMatches reports whether an If-Match or If-None-Match header value
+// lists tag. Weak tags compare equal to their strong form.
+func etagMatches(header, tag string) bool {
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimPrefix(strings.TrimSpace(t), "W/")
+		if t == "*" || t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// decode reads a JSON request body into v.
+func decode(r *http.Request, v interface{}) error {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return badRequest(fmt.Errorf("invalid request body: %w", err))
+	}
+	return nil
+}
+
+// requestError is an error caused by the request, reported as 400.
+type requestError struct {
+	err error
+}
+
+func (e *requestError) Error() string { return e.err.Error() }
+func (e *requestError) Unwrap() error { return e.err }
+
+func badRequest(err error) error {
+	return &requestError{err}
+}
+
+// errorResponse is the body of every error response.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+// fail reports err with a status derived from its type.
+func fail(w http.ResponseWriter, err error) {
+	var reqErr *requestError
+	var svcErr *service.RequestError
+	var transErr *prd.TransitionError
+	switch {
+	case errors.Is(err, errPRDNotFound), errors.Is(err, prd.ErrEntityNotFound):
+		writeError(w, http.StatusNotFound, err)
+	case errors.Is(err, errConflict), errors.Is(err, service.ErrPRDExists), errors.As(err, &transErr):
+		writeError(w, http.StatusConflict, err)
+	case errors.Is(err, errPreconditionFailed):
+		writeError(w, http.StatusPreconditionFailed, err)
+	case errors.As(err, &reqErr), errors.As(err, &svcErr):
+		writeError(w, http.StatusBadRequest, err)
+	default:
+		writeError(w, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,496 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/agent-team-prd/pkg/workspace"
+	"github.com/agentplexus/structured-evaluation/evaluation"
+)
+
+const testID = "PRD-2026-001"
+
+// newTestServer creates a workspace with one PRD in docs/PRD.json.
+func newTestServer(t *testing.T) (*httptest.Server, *Server, string) {
+	t.Helper()
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "docs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	p := prd.New(testID, "Test PRD", prd.Person{Name: "Owner"})
+	path := filepath.Join(root, "docs", "PRD.json")
+	if err := prd.Save(p, path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	s := New(root)
+	ts := httptest.NewServer(s)
+	t.Cleanup(ts.Close)
+	return ts, s, path
+}
+
+func do(t *testing.T, ts *httptest.Server, method, path, body string) (*http.Response, []byte) {
+	t.Helper()
+	var r io.Reader
+	if body != "" {
+		r = strings.NewReader(body)
+	}
+	req, err := http.NewRequest(method, ts.URL+path, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return send(t, ts, req)
+}
+
+func send(t *testing.T, ts *httptest.Server, req *http.Request) (*http.Response, []byte) {
+	t.Helper()
+	resp, err := ts.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, data
+}
+
+func expectStatus(t *testing.T, resp *http.Response, body []byte, want int) {
+	t.Helper()
+	if resp.StatusCode != want {
+		t.Fatalf("%s %s: expected status %d, got %d: %s", resp.Request.Method, resp.Request.URL.Path, want, resp.StatusCode, body)
+	}
+}
+
+func TestListAndGet(t *testing.T) {
+	ts, _, _ := newTestServer(t)
+
+	resp, body := do(t, ts, "GET", "/api/prds", "")
+	expectStatus(t, resp, body, http.StatusOK)
+	var pf workspace.Portfolio
+	if err := json.Unmarshal(body, &pf); err != nil {
+		t.Fatal(err)
+	}
+	if len(pf.Entries) != 1 || pf.Entries[0].ID != testID {
+		t.Fatalf("unexpected portfolio: %+v", pf.Entries)
+	}
+	if pf.Entries[0].Score == nil || pf.Entries[0].Valid == nil {
+		t.Error("expected portfolio entries to be scored and validated")
+	}
+
+	resp, body = do(t, ts, "GET", "/api/prds/"+testID, "")
+	expectStatus(t, resp, body, http.StatusOK)
+	var doc Document
+	if err := json.Unmarshal(body, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Path != filepath.Join("docs", "PRD.json") || doc.PRD.Metadata.Title != "Test PRD" {
+		t.Errorf("unexpected document: %s %s", doc.Path, doc.PRD.Metadata.Title)
+	}
+
+	for _, path := range []string{"/score", "/validation", "/comments"} {
+		resp, body = do(t, ts, "GET", "/api/prds/"+testID+path, "")
+		expectStatus(t, resp, body, http.StatusOK)
+	}
+
+	resp, body = do(t, ts, "GET", "/api/prds/PRD-9999-999", "")
+	expectStatus(t, resp, body, http.StatusNotFound)
+}
+
+func TestViews(t *testing.T) {
+	ts, _, _ := newTestServer(t)
+
+	for _, view := range []string{ViewPM, ViewExec, ViewDiagrams, ViewSixPager} {
+		resp, body := do(t, ts, "GET", "/api/prds/"+testID+"/views/"+view, "")
+		expectStatus(t, resp, body, http.StatusOK)
+		var v ViewResponse
+		if err := json.Unmarshal(body, &v); err != nil {
+			t.Fatal(err)
+		}
+		if v.View != view {
+			t.Errorf("expected view %s, got %s", view, v.View)
+		}
+	}
+
+	resp, body := do(t, ts, "GET", "/api/prds/"+testID+"/views/poster", "")
+	expectStatus(t, resp, body, http.StatusBadRequest)
+}
+
+func TestEntities(t *testing.T) {
+	ts, _, path := newTestServer(t)
+	base := "/api/prds/" + testID + "/entities/risks"
+
+	resp, body := do(t, ts, "POST", base, `{"description": "Provider outage", "impact": "high"}`)
+	expectStatus(t, resp, body, http.StatusCreated)
+	var e prd.Entity
+	if err := json.Unmarshal(body, &e); err != nil {
+		t.Fatal(err)
+	}
+	if e.ID != "RISK-1" || e.Values["impact"] != "high" {
+		t.Errorf("unexpected entity: %+v", e)
+	}
+
+	// The change is saved to the file.
+	p, err := prd.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Risks) != 1 || p.Risks[0].Description != "Provider outage" {
+		t.Fatalf("expected risk to be saved, got %+v", p.Risks)
+	}
+
+	resp, body = do(t, ts, "PATCH", base+"/RISK-1", `{"mitigation": "Fallback provider"}`)
+	expectStatus(t, resp, body, http.StatusOK)
+	p, _ = prd.Load(path)
+	if p.Risks[0].Mitigation != "Fallback provider" || p.Risks[0].Impact != prd.RiskImpactHigh {
+		t.Errorf("expected mitigation to be updated and impact kept, got %+v", p.Risks[0])
+	}
+
+	resp, body = do(t, ts, "PATCH", base+"/RISK-1", `{"impact": "huge"}`)
+	expectStatus(t, resp, body, http.StatusBadRequest)
+	resp, body = do(t, ts, "POST", base, `{"impact": "high"}`)
+	expectStatus(t, resp, body, http.StatusBadRequest)
+	resp, body = do(t, ts, "POST", base, `not json`)
+	expectStatus(t, resp, body, http.StatusBadRequest)
+
+	resp, body = do(t, ts, "GET", base, "")
+	expectStatus(t, resp, body, http.StatusOK)
+	var list []prd.Entity
+	if err := json.Unmarshal(body, &list); err != nil || len(list) != 1 {
+		t.Fatalf("expected one risk, got %s", body)
+	}
+
+	resp, body = do(t, ts, "DELETE", base+"/RISK-1", "")
+	expectStatus(t, resp, body, http.StatusNoContent)
+	resp, body = do(t, ts, "DELETE", base+"/RISK-1", "")
+	expectStatus(t, resp, body, http.StatusNotFound)
+
+	resp, body = do(t, ts, "GET", "/api/prds/"+testID+"/entities/widgets", "")
+	expectStatus(t, resp, body, http.StatusNotFound)
+}
+
+func TestComments(t *testing.T) {
+	ts, _, path := newTestServer(t)
+	base := "/api/prds/" + testID + "/comments"
+
+	// A form post from another site cannot set a JSON content type.
+	req, _ := http.NewRequest("POST", ts.URL+base, strings.NewReader("author=x&body=y"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := ts.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnsupportedMediaType {
+		t.Errorf("expected 415 for a form post, got %d", resp.StatusCode)
+	}
+
+	resp, body := do(t, ts, "POST", base, `{"author": "Alice", "body": "Is this in scope?", "target": "FR-1"}`)
+	expectStatus(t, resp, body, http.StatusCreated)
+	var c prd.Comment
+	if err := json.Unmarshal(body, &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.ID != "CMT-1" || c.Author != "Alice" {
+		t.Errorf("unexpected comment: %+v", c)
+	}
+
+	resp, body = do(t, ts, "POST", base, `{"author": "Alice"}`)
+	expectStatus(t, resp, body, http.StatusBadRequest)
+
+	resp, body = do(t, ts, "PATCH", base+"/CMT-1", `{"resolved": true}`)
+	expectStatus(t, resp, body, http.StatusOK)
+	resp, body = do(t, ts, "PATCH", base+"/CMT-7", `{"resolved": true}`)
+	expectStatus(t, resp, body, http.StatusNotFound)
+
+	p, err := prd.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	comments, err := prd.GetComments(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) != 1 || !comments[0].Resolved {
+		t.Errorf("expected resolved comment to be saved, got %+v", comments)
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	ts, s, _ := newTestServer(t)
+	s.ReadOnly = true
+
+	resp, body := do(t, ts, "POST", "/api/prds/"+testID+"/comments", `{"author": "Alice", "body": "Hi"}`)
+	expectStatus(t, resp, body, http.StatusForbidden)
+	resp, body = do(t, ts, "GET", "/api/prds/"+testID+"/comments", "")
+	expectStatus(t, resp, body, http.StatusOK)
+}
+
+func TestHostCheck(t *testing.T) {
+	ts, s, _ := newTestServer(t)
+
+	comment := func(host string) (*http.Response, []byte) {
+		req, err := http.NewRequest("POST", ts.URL+"/api/prds/"+testID+"/comments", strings.NewReader(`{"author": "Alice", "body": "Hi"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Host = host
+		return send(t, ts, req)
+	}
+
+	// A page that rebinds its DNS name to the server keeps its own Host.
+	resp, body := comment("evil.example:8080")
+	expectStatus(t, resp, body, http.StatusForbidden)
+
+	for _, host := range []string{"localhost:8080", "127.0.0.1", "[::1]:8080"} {
+		resp, body = comment(host)
+		expectStatus(t, resp, body, http.StatusCreated)
+	}
+
+	s.Addr = "prds.internal:8080"
+	resp, body = comment("prds.internal:8080")
+	expectStatus(t, resp, body, http.StatusCreated)
+
+	// Listening on all interfaces, any name may reach the server.
+	s.Addr = "0.0.0.0:8080"
+	resp, body = comment("evil.example:8080")
+	expectStatus(t, resp, body, http.StatusCreated)
+}
+
+func TestIndex(t *testing.T) {
+	ts, s, path := newTestServer(t)
+
+	resp, body := do(t, ts, "GET", "/api/prds/"+testID, "")
+	expectStatus(t, resp, body, http.StatusOK)
+
+	// A moved file is found again by walking the workspace.
+	moved := filepath.Join(s.Root, "specs", "moved.json")
+	if err := os.MkdirAll(filepath.Dir(moved), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(path, moved); err != nil {
+		t.Fatal(err)
+	}
+	resp, body = do(t, ts, "GET", "/api/prds/"+testID, "")
+	expectStatus(t, resp, body, http.StatusOK)
+	var doc Document
+	if err := json.Unmarshal(body, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Path != filepath.Join("specs", "moved.json") {
+		t.Errorf("expected the moved file, got %s", doc.Path)
+	}
+
+	// A file that breaks is reported, not treated as missing.
+	if err := os.WriteFile(moved, []byte(`{"metadata": {`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	resp, body = do(t, ts, "GET", "/api/prds/"+testID, "")
+	expectStatus(t, resp, body, http.StatusInternalServerError)
+	if !strings.Contains(string(body), "moved.json") {
+		t.Errorf("expected the broken file in the error, got %s", body)
+	}
+
+	// An unknown ID names the files that failed to load.
+	resp, body = do(t, ts, "GET", "/api/prds/PRD-9999-999", "")
+	expectStatus(t, resp, body, http.StatusNotFound)
+	if !strings.Contains(string(body), "failed to load specs") {
+		t.Errorf("expected the failed files in the error, got %s", body)
+	}
+}
+
+func TestUI(t *testing.T) {
+	ts, _, _ := newTestServer(t)
+
+	for _, path := range []string{"/", "/app.js", "/style.css"} {
+		resp, body := do(t, ts, "GET", path, "")
+		expectStatus(t, resp, body, http.StatusOK)
+	}
+	resp, body := do(t, ts, "GET", "/", "")
+	if !strings.Contains(string(body), `<script src="app.js">`) {
+		t.Errorf("expected index page, got %s", body)
+	}
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("expected text/html, got %s", ct)
+	}
+}
+
+func TestCreate(t *testing.T) {
+	ts, s, _ := newTestServer(t)
+
+	resp, body := do(t, ts, "POST", "/api/prds", `{"path": "specs/new.json", "id": "PRD-2026-002", "title": "New PRD", "owner": "Bob"}`)
+	expectStatus(t, resp, body, http.StatusCreated)
+	if resp.Header.Get("ETag") == "" || resp.Header.Get("Location") != "/api/prds/PRD-2026-002" {
+		t.Errorf("expected ETag and Location headers, got %v", resp.Header)
+	}
+	var doc Document
+	if err := json.Unmarshal(body, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Path != filepath.Join("specs", "new.json") || doc.PRD.Metadata.ID != "PRD-2026-002" {
+		t.Errorf("unexpected document: %s %s", doc.Path, doc.PRD.Metadata.ID)
+	}
+	if _, err := prd.Load(filepath.Join(s.Root, "specs", "new.json")); err != nil {
+		t.Errorf("expected PRD to be saved: %v", err)
+	}
+
+	resp, body = do(t, ts, "GET", "/api/prds/PRD-2026-002", "")
+	expectStatus(t, resp, body, http.StatusOK)
+
+	tests := []struct {
+		body string
+		want int
+	}{
+		{`{"path": "specs/new.json", "id": "PRD-2026-003", "title": "T", "owner": "O"}`, http.StatusConflict},
+		{`{"path": "other.json", "id": "PRD-2026-002", "title": "T", "owner": "O"}`, http.StatusConflict},
+		{`{"path": "../escape.json", "title": "T", "owner": "O"}`, http.StatusBadRequest},
+		{`{"path": "notes.txt", "title": "T", "owner": "O"}`, http.StatusBadRequest},
+		{`{"title": "T"}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		resp, body := do(t, ts, "POST", "/api/prds", tt.body)
+		expectStatus(t, resp, body, tt.want)
+	}
+}
+
+func TestETag(t *testing.T) {
+	ts, _, path := newTestServer(t)
+	base := "/api/prds/" + testID
+
+	resp, body := do(t, ts, "GET", base, "")
+	expectStatus(t, resp, body, http.StatusOK)
+	tag := resp.Header.Get("ETag")
+	if tag == "" {
+		t.Fatal("expected an ETag")
+	}
+
+	req, _ := http.NewRequest("GET", ts.URL+base, nil)
+	req.Header.Set("If-None-Match", tag)
+	resp, body = send(t, ts, req)
+	expectStatus(t, resp, body, http.StatusNotModified)
+
+	// A write based on the current version succeeds and changes the ETag.
+	req, _ = http.NewRequest("POST", ts.URL+base+"/entities/risks", strings.NewReader(`{"description": "Outage"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", tag)
+	resp, body = send(t, ts, req)
+	expectStatus(t, resp, body, http.StatusCreated)
+	newTag := resp.Header.Get("ETag")
+	if newTag == "" || newTag == tag {
+		t.Fatalf("expected a new ETag, got %q", newTag)
+	}
+
+	// A write based on the old version is rejected and changes nothing.
+	req, _ = http.NewRequest("DELETE", ts.URL+base+"/entities/risks/RISK-1", nil)
+	req.Header.Set("If-Match", tag)
+	resp, body = send(t, ts, req)
+	expectStatus(t, resp, body, http.StatusPreconditionFailed)
+	p, err := prd.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Risks) != 1 {
+		t.Errorf("expected the risk to be kept, got %+v", p.Risks)
+	}
+
+	resp, body = do(t, ts, "GET", base, "")
+	expectStatus(t, resp, body, http.StatusOK)
+	if got := resp.Header.Get("ETag"); got != newTag {
+		t.Errorf("expected GET to return the ETag of the last write %s, got %s", newTag, got)
+	}
+}
+
+func TestOperations(t *testing.T) {
+	ts, _, path := newTestServer(t)
+	base := "/api/prds/" + testID
+
+	resp, body := do(t, ts, "PUT", base+"/status", `{"status": "in_review"}`)
+	expectStatus(t, resp, body, http.StatusOK)
+	resp, body = do(t, ts, "PUT", base+"/status", `{"status": "shipped"}`)
+	expectStatus(t, resp, body, http.StatusBadRequest)
+	resp, body = do(t, ts, "PUT", base+"/status", `{"status": "deprecated"}`)
+	expectStatus(t, resp, body, http.StatusConflict)
+	resp, body = do(t, ts, "PATCH", base, `{"merge": {"metadata": {"status": "deprecated"}}}`)
+	expectStatus(t, resp, body, http.StatusConflict)
+
+	resp, body = do(t, ts, "POST", base+"/entities/solutions", `{"name": "Passkeys", "description": "WebAuthn login"}`)
+	expectStatus(t, resp, body, http.StatusCreated)
+	resp, body = do(t, ts, "PUT", base+"/solution", `{"id": "SOL-1", "rationale": "Phishing resistant"}`)
+	expectStatus(t, resp, body, http.StatusOK)
+	resp, body = do(t, ts, "PUT", base+"/solution", `{"id": "SOL-9"}`)
+	expectStatus(t, resp, body, http.StatusNotFound)
+
+	resp, body = do(t, ts, "PATCH", base, `{"merge": {"metadata": {"title": "Renamed"}}}`)
+	expectStatus(t, resp, body, http.StatusOK)
+	resp, body = do(t, ts, "PATCH", base, `{"operations": [{"op": "replace", "path": "/metadata/version", "value": "2.0.0"}]}`)
+	expectStatus(t, resp, body, http.StatusOK)
+	resp, body = do(t, ts, "PATCH", base, `{}`)
+	expectStatus(t, resp, body, http.StatusBadRequest)
+	resp, body = do(t, ts, "PATCH", base, `{"operations": [{"op": "remove", "path": "/nope"}]}`)
+	expectStatus(t, resp, body, http.StatusBadRequest)
+
+	p, err := prd.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Metadata.Status != prd.StatusInReview || p.Metadata.Title != "Renamed" || p.Metadata.Version != "2.0.0" {
+		t.Errorf("unexpected metadata: %+v", p.Metadata)
+	}
+	if p.Solution == nil || p.Solution.SelectedSolutionID != "SOL-1" {
+		t.Errorf("expected SOL-1 to be selected, got %+v", p.Solution)
+	}
+
+	resp, body = do(t, ts, "GET", base+"/score?explain=true", "")
+	expectStatus(t, resp, body, http.StatusOK)
+	resp, body = do(t, ts, "GET", base+"/next?limit=2", "")
+	expectStatus(t, resp, body, http.StatusOK)
+	var next NextActionsResponse
+	if err := json.Unmarshal(body, &next); err != nil {
+		t.Fatal(err)
+	}
+	if len(next.Actions) > 2 {
+		t.Errorf("expected at most 2 actions, got %d", len(next.Actions))
+	}
+	resp, body = do(t, ts, "GET", base+"/next?limit=none", "")
+	expectStatus(t, resp, body, http.StatusBadRequest)
+}
+
+func TestRecordReview(t *testing.T) {
+	ts, _, path := newTestServer(t)
+
+	report := evaluation.NewEvaluationReport("prd", "PRD.json")
+	report.Metadata.DocumentID = testID
+	report.AddCategory(evaluation.NewCategoryScore("problem_definition", 1, 8, "Clear problem"))
+	report.Finalize("test")
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, body := do(t, ts, "POST", "/api/prds/"+testID+"/reviews", string(data))
+	expectStatus(t, resp, body, http.StatusOK)
+	p, err := prd.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Reviews == nil || p.Reviews.QualityScores.ProblemDefinition != 8 {
+		t.Errorf("expected review to be recorded, got %+v", p.Reviews)
+	}
+
+	report.Metadata.DocumentID = "PRD-2026-999"
+	data, _ = json.Marshal(report)
+	resp, body = do(t, ts, "POST", "/api/prds/"+testID+"/reviews", string(data))
+	expectStatus(t, resp, body, http.StatusBadRequest)
+}
@@ -0,0 +1,420 @@
+// PRD review UI. Talks to the JSON API served alongside this file.
+"use strict";
+
+const state = {
+  id: null,     // selected PRD ID
+  etag: null,   // ETag of the selected PRD, sent as If-Match on changes
+  tab: "overview",
+  kinds: [],
+};
+
+const $ = (sel) => document.querySelector(sel);
+
+// ---------------------------------------------------------------------------
+// API
+
+async function api(method, path, body) {
+  const opts = { method, headers: {} };
+  if (body !== undefined) {
+    opts.headers["Content-Type"] = "application/json";
+    opts.body = JSON.stringify(body);
+  }
+  // Changes only apply to the version of the PRD on screen, so edits made
+  // elsewhere in the meantime are not overwritten.
+  const current = state.id !== null && (path === prdPath() || path.startsWith(prdPath("/")));
+  if (current && method !== "GET" && state.etag) {
+    opts.headers["If-Match"] = state.etag;
+  }
+  const res = await fetch("/api" + path, opts);
+  if (current && res.headers.get("ETag")) {
+    state.etag = res.headers.get("ETag");
+  }
+  if (res.status === 204) {
+    return null;
+  }
+  const data = await res.json();
+  if (res.status === 412) {
+    throw new Error(data.error + "; reload the PRD to see the latest version");
+  }
+  if (!res.ok) {
+    throw new Error(data.error || res.statusText);
+  }
+  return data;
+}
+
+function prdPath(suffix) {
+  return "/prds/" + encodeURIComponent(state.id) + (suffix || "");
+}
+
+// ---------------------------------------------------------------------------
+// Helpers
+
+function escapeHTML(s) {
+  return String(s == null ? "" : s)
+    .replace(/&/g, "&amp;")
+    .replace(/</g, "&lt;")
+    .replace(/>/g, "&gt;")
+    .replace(/"/g, "&quot;")
+    .replace(/'/g, "&#39;");
+}
+
+function scoreClass(score) {
+  if (score >= 8) return "good";
+  if (score >= 6.5) return "fair";
+  return "poor";
+}
+
+function toast(message, isError) {
+  const el = $("#toast");
+  el.textContent = message;
+  el.className = isError ? "error" : "";
+  el.hidden = false;
+  clearTimeout(toast.timer);
+  toast.timer = setTimeout(() => { el.hidden = true; }, 3000);
+}
+
+// ---------------------------------------------------------------------------
+// Markdown (the subset produced by the views)
+
+function inline(text) {
+  return escapeHTML(text)
+    .replace(/`([^`]+)`/g, "<code>$1</code>")
+    .replace(/\*\*([^*]+)\*\*/g, "<strong>$1</strong>")
+    .replace(/(^|[^*])\*([^*\s][^*]*)\*/g, "$1<em>$2</em>")
+    .replace(/(^|\W)_([^_\s][^_]*)_(?=\W|$)/g, "$1<em>$2</em>")
+    .replace(/\[([^\]]+)\]\(((?:https?:\/\/|#)[^)\s]+)\)/g, '<a href="$2">$1</a>');
+}
+
+function renderMarkdown(md) {
+  const lines = md.replace(/\r\n/g, "\n").split("\n");
+  const out = [];
+  let i = 0;
+
+  const isTableRow = (l) => /^\s*\|.*\|\s*$/.test(l);
+  const cells = (l) => l.trim().replace(/^\||\|$/g, "").split("|").map((c) => c.trim());
+
+  while (i < lines.length) {
+    const line = lines[i];
+
+    if (/^```/.test(line)) {
+      const code = [];
+      for (i++; i < lines.length && !/^```/.test(lines[i]); i++) {
+        code.push(lines[i]);
+      }
+      i++;
+      out.push("<pre><code>" + escapeHTML(code.join("\n")) + "</code></pre>");
+      continue;
+    }
+
+    let m = /^(#{1,6})\s+(.*)$/.exec(line);
+    if (m) {
+      out.push(`<h${m[1].length}>${inline(m[2])}</h${m[1].length}>`);
+      i++;
+      continue;
+    }
+
+    if (/^\s*(---|\*\*\*)\s*$/.test(line)) {
+      out.push("<hr>");
+      i++;
+      continue;
+    }
+
+    if (isTableRow(line) && i + 1 < lines.length && /^\s*\|[\s:|-]+\|\s*$/.test(lines[i + 1])) {
+      const head = cells(line);
+      const rows = [];
+      for (i += 2; i < lines.length && isTableRow(lines[i]); i++) {
+        rows.push(cells(lines[i]));
+      }
+      out.push("<table><thead><tr>" + head.map((c) => "<th>" + inline(c) + "</th>").join("") + "</tr></thead><tbody>" +
+        rows.map((r) => "<tr>" + r.map((c) => "<td>" + inline(c) + "</td>").join("") + "</tr>").join("") +
+        "</tbody></table>");
+      continue;
+    }
+
+    m = /^\s*([-*]|\d+\.)\s+/.exec(line);
+    if (m) {
+      const ordered = /\d/.test(m[1]);
+      const items = [];
+      while (i < lines.length && (m = /^\s*([-*]|\d+\.)\s+(.*)$/.exec(lines[i]))) {
+        items.push("<li>" + inline(m[2].replace(/^\[( |x)\]\s*/, (_, x) => (x === "x" ? "☑ " : "☐ "))) + "</li>");
+        i++;
+      }
+      const tag = ordered ? "ol" : "ul";
+      out.push(`<${tag}>${items.join("")}</${tag}>`);
+      continue;
+    }
+
+    if (/^>\s?/.test(line)) {
+      const quote = [];
+      for (; i < lines.length && /^>\s?/.test(lines[i]); i++) {
+        quote.push(lines[i].replace(/^>\s?/, ""));
+      }
+      out.push("<blockquote>" + inline(quote.join(" ")) + "</blockquote>");
+      continue;
+    }
+
+    if (line.trim() === "") {
+      i++;
+      continue;
+    }
+
+    const para = [];
+    for (; i < lines.length && lines[i].trim() !== "" && !/^(#|```|\s*\||\s*[-*]\s|\s*\d+\.\s|>)/.test(lines[i]); i++) {
+      para.push(lines[i]);
+    }
+    if (para.length === 0) {
+      para.push(line);
+      i++;
+    }
+    out.push("<p>" + inline(para.join(" ")) + "</p>");
+  }
+  return out.join("\n");
+}
+
+// ---------------------------------------------------------------------------
+// Portfolio
+
+async function loadList() {
+  const status = $("#status-filter").value;
+  const pf = await api("GET", "/prds" + (status ? "?status=" + encodeURIComponent(status) : ""));
+  const list = $("#prd-list");
+  list.innerHTML = "";
+  for (const e of pf.entries) {
+    const li = document.createElement("li");
+    li.dataset.id = e.id;
+    li.className = e.id === state.id ? "active" : "";
+    const score = e.score == null ? "" : `<span class="score-badge ${scoreClass(e.score)}">${e.score.toFixed(1)}</span>`;
+    li.innerHTML = `<div><div class="title">${escapeHTML(e.title)}</div>` +
+      `<div class="muted">${escapeHTML(e.id)} · ${escapeHTML(e.status)}${e.owner ? " · " + escapeHTML(e.owner) : ""}</div></div>${score}`;
+    li.addEventListener("click", () => selectPRD(e.id).catch((err) => toast(err.message, true)));
+    list.appendChild(li);
+  }
+  const avg = pf.summary.averageScore == null ? "" : `, average score ${pf.summary.averageScore.toFixed(1)}`;
+  $("#portfolio-summary").textContent = `${pf.summary.total} PRD(s)${avg}`;
+}
+
+// ---------------------------------------------------------------------------
+// Document
+
+async function selectPRD(id) {
+  state.id = id;
+  state.etag = null;
+  location.hash = encodeURIComponent(id);
+  document.querySelectorAll("#prd-list li").forEach((li) => li.classList.toggle("active", li.dataset.id === id));
+  $("#empty").hidden = true;
+  $("#document").hidden = false;
+  await refreshDocument();
+}
+
+async function refreshDocument() {
+  const doc = await api("GET", prdPath());
+  const m = doc.prd.metadata;
+  $("#doc-title").textContent = m.title;
+  $("#doc-id").textContent = m.id;
+  $("#doc-status").textContent = m.status;
+  $("#doc-path").textContent = doc.path;
+
+  const score = await api("GET", prdPath("/score"));
+  const badge = $("#doc-score");
+  badge.textContent = score.weightedScore.toFixed(1) + "/10";
+  badge.className = "score-badge large " + scoreClass(score.weightedScore);
+
+  const comments = await api("GET", prdPath("/comments"));
+  const open = comments.filter((c) => !c.resolved).length;
+  $("#comment-count").textContent = open ? `(${open})` : "";
+
+  await showTab(state.tab);
+}
+
+async function showTab(tab) {
+  state.tab = tab;
+  document.querySelectorAll("#tabs button").forEach((b) => b.classList.toggle("active", b.dataset.tab === tab));
+  const isView = tab === "pm" || tab === "exec" || tab === "sixpager";
+  $("#tab-overview").hidden = tab !== "overview";
+  $("#tab-view").hidden = !isView;
+  $("#tab-edit").hidden = tab !== "edit";
+  $("#tab-comments").hidden = tab !== "comments";
+
+  try {
+    if (tab === "overview") await renderOverview();
+    else if (isView) await renderView(tab);
+    else if (tab === "edit") await renderEdit();
+    else if (tab === "comments") await renderComments();
+  } catch (err) {
+    toast(err.message, true);
+  }
+}
+
+async function renderOverview() {
+  const [score, validation] = await Promise.all([api("GET", prdPath("/score")), api("GET", prdPath("/validation"))]);
+  const rows = score.categoryScores.map((c) =>
+    `<tr><td>${escapeHTML(c.category)}</td><td>${(c.weight * 100).toFixed(0)}%</td>` +
+    `<td class="${scoreClass(c.score)}">${c.score.toFixed(1)}</td>` +
+    `<td><div class="bar"><span style="width:${Math.max(0, Math.min(100, c.score * 10))}%"></span></div></td>` +
+    `<td>${escapeHTML(c.justification)}</td></tr>`).join("");
+
+  const issues = [
+    ...(validation.errors || []).map((e) => `<li class="error">✗ ${escapeHTML(e.field)}: ${escapeHTML(e.message)}</li>`),
+    ...(validation.warnings || []).map((w) => `<li class="warning">! ${escapeHTML(w.field)}: ${escapeHTML(w.message)}</li>`),
+  ];
+
+  $("#tab-overview").innerHTML =
+    `<h3>Score <span class="score-badge ${scoreClass(score.weightedScore)}">${score.weightedScore.toFixed(1)}/10 · ${escapeHTML(score.decision)}</span></h3>` +
+    (score.summary ? `<p>${escapeHTML(score.summary)}</p>` : "") +
+    `<table><thead><tr><th>Category</th><th>Weight</th><th>Score</th><th></th><th>Justification</th></tr></thead><tbody>${rows}</tbody></table>` +
+    ((score.blockers || []).length ? `<h3>Blockers</h3><ul>${score.blockers.map((b) => `<li>${escapeHTML(b)}</li>`).join("")}</ul>` : "") +
+    `<h3>Validation</h3>` +
+    (issues.length ? `<ul class="issues">${issues.join("")}</ul>` : `<p class="good">✓ Valid</p>`);
+}
+
+async function renderView(view) {
+  const res = await api("GET", prdPath("/views/" + view));
+  $("#tab-view").innerHTML = res.markdown.trim() ? renderMarkdown(res.markdown) : '<p class="muted">This view is empty.</p>';
+}
+
+// ---------------------------------------------------------------------------
+// Editing
+
+function fieldInput(f, value) {
+  const name = escapeHTML(f.name);
+  if (f.choices && f.choices.length) {
+    const options = ['<option value=""></option>']
+      .concat(f.choices.map((c) => `<option${c === value ? " selected" : ""}>${escapeHTML(c)}</option>`));
+    return `<select name="${name}">${options.join("")}</select>`;
+  }
+  const placeholder = f.list ? ' placeholder="Comma-separated"' : "";
+  return `<input name="${name}" value="${escapeHTML(value)}"${placeholder}>`;
+}
+
+function entityForm(kind, entity) {
+  const fields = kind.fields.map((f) =>
+    `<div class="field"><label>${escapeHTML(f.label)}${f.required ? ' <span class="required">*</span>' : ""}</label>` +
+    fieldInput(f, entity ? entity.values[f.name] : "") + "</div>").join("");
+  const actions = entity
+    ? '<button type="submit" class="primary">Save</button><button type="button" class="danger" data-action="delete">Delete</button>'
+    : '<button type="submit" class="primary">Add</button>';
+  const title = entity ? `${escapeHTML(entity.id)}` : `New ${escapeHTML(kind.title.toLowerCase())}`;
+  return `<form class="entity" data-kind="${escapeHTML(kind.name)}" data-id="${entity ? escapeHTML(entity.id) : ""}">` +
+    `<h4>${title}</h4>${fields}<div class="actions">${actions}</div></form>`;
+}
+
+async function renderEdit() {
+  if (state.kinds.length === 0) {
+    state.kinds = await api("GET", "/kinds");
+  }
+  const open = new Set([...document.querySelectorAll("details.kind[open]")].map((d) => d.dataset.kind));
+  const lists = await Promise.all(state.kinds.map((k) => api("GET", prdPath("/entities/" + k.name))));
+
+  $("#tab-edit").innerHTML = state.kinds.map((kind, i) => {
+    const entities = lists[i];
+    const canAdd = !kind.singleton || entities.length === 0;
+    return `<details class="kind" data-kind="${escapeHTML(kind.name)}"${open.has(kind.name) ? " open" : ""}>` +
+      `<summary>${escapeHTML(kind.title)} (${entities.length})</summary>` +
+      entities.map((e) => entityForm(kind, e)).join("") +
+      (canAdd ? entityForm(kind, null) : "") +
+      "</details>";
+  }).join("");
+}
+
+async function submitEntity(form, action) {
+  const kind = form.dataset.kind;
+  const id = form.dataset.id;
+  const base = prdPath("/entities/" + encodeURIComponent(kind));
+  try {
+    if (action === "delete") {
+      if (!confirm(`Delete ${id}?`)) return;
+      await api("DELETE", base + "/" + encodeURIComponent(id));
+      toast(`Deleted ${id}`);
+    } else {
+      const values = {};
+      new FormData(form).forEach((v, k) => { values[k] = v; });
+      const entity = await api(id ? "PATCH" : "POST", id ? base + "/" + encodeURIComponent(id) : base, values);
+      toast(id ? `Saved ${entity.id}` : `Added ${entity.id}`);
+    }
+    await refreshDocument();
+    loadList();
+  } catch (err) {
+    toast(err.message, true);
+  }
+}
+
+// ---------------------------------------------------------------------------
+// Comments
+
+async function renderComments() {
+  const comments = await api("GET", prdPath("/comments"));
+  const list = $("#comment-list");
+  if (comments.length === 0) {
+    list.innerHTML = '<li class="muted">No comments yet.</li>';
+    return;
+  }
+  list.innerHTML = comments.slice().reverse().map((c) =>
+    `<li class="${c.resolved ? "resolved" : ""}">` +
+    `<div class="meta"><span><strong>${escapeHTML(c.author)}</strong>${c.target ? " on " + escapeHTML(c.target) : ""}` +
+    ` · ${escapeHTML(new Date(c.createdAt).toLocaleString())}</span>` +
+    `<button data-comment="${escapeHTML(c.id)}" data-resolved="${c.resolved}">${c.resolved ? "Reopen" : "Resolve"}</button></div>` +
+    `<div class="body">${escapeHTML(c.body)}</div></li>`).join("");
+}
+
+async function addComment(e) {
+  e.preventDefault();
+  const form = e.target;
+  const data = Object.fromEntries(new FormData(form));
+  try {
+    await api("POST", prdPath("/comments"), data);
+    localStorage.setItem("prd-author", data.author);
+    form.body.value = "";
+    form.target.value = "";
+    await refreshDocument();
+  } catch (err) {
+    toast(err.message, true);
+  }
+}
+
+async function toggleComment(button) {
+  try {
+    await api("PATCH", prdPath("/comments/" + encodeURIComponent(button.dataset.comment)), {
+      resolved: button.dataset.resolved !== "true",
+    });
+    await refreshDocument();
+  } catch (err) {
+    toast(err.message, true);
+  }
+}
+
+// ---------------------------------------------------------------------------
+// Wiring
+
+document.addEventListener("DOMContentLoaded", async () => {
+  $("#status-filter").addEventListener("change", () => loadList().catch((err) => toast(err.message, true)));
+  $("#tabs").addEventListener("click", (e) => {
+    const tab = e.target.closest("button");
+    if (tab) showTab(tab.dataset.tab);
+  });
+
+  $("#tab-edit").addEventListener("submit", (e) => {
+    e.preventDefault();
+    submitEntity(e.target, "save");
+  });
+  $("#tab-edit").addEventListener("click", (e) => {
+    if (e.target.dataset.action === "delete") {
+      submitEntity(e.target.closest("form"), "delete");
+    }
+  });
+
+  const form = $("#comment-form");
+  form.author.value = localStorage.getItem("prd-author") || "";
+  form.addEventListener("submit", addComment);
+  $("#comment-list").addEventListener("click", (e) => {
+    if (e.target.dataset.comment) toggleComment(e.target);
+  });
+
+  try {
+    await loadList();
+    if (location.hash.length > 1) {
+      await selectPRD(decodeURIComponent(location.hash.slice(1)));
+    }
+  } catch (err) {
+    toast(err.message, true);
+  }
+});
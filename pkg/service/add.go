@@ -0,0 +1,264 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+)
+
+// AddProblemRequest sets the problem statement.
+type AddProblemRequest struct {
+	Path       string  `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
+	Statement  string  `json:"statement" jsonschema:"Problem statement"`
+	Impact     string  `json:"impact,omitempty" jsonschema:"User impact"`
+	Confidence float64 `json:"confidence,omitempty" jsonschema:"Confidence level 0-1 (default: 0.5)"`
+	DryRun     bool    `json:"dry_run,omitempty" jsonschema:"Preview the change and score delta without saving (default: false)"`
+}
+
+// AddProblem sets the problem statement. A zero confidence means 0.5.
+func (s *Service) AddProblem(req AddProblemRequest) (*Result, error) {
+	statement, err := required("statement", req.Statement)
+	if err != nil {
+		return nil, err
+	}
+	confidence := req.Confidence
+	if confidence == 0 {
+		confidence = 0.5
+	}
+	if confidence < 0 || confidence > 1 {
+		return nil, invalid("confidence must be between 0 and 1, got %g", confidence)
+	}
+
+	return s.mutate(req.Path, req.DryRun, func(p *prd.PRD) (string, string, error) {
+		prd.SetProblemStatement(p, statement, req.Impact, confidence)
+		id := "PROB-1"
+		if p.Problem != nil {
+			id = p.Problem.ID
+		}
+		return id, fmt.Sprintf("Set problem statement: %s", id), nil
+	})
+}
+
+// AddPersonaRequest adds a user persona.
+type AddPersonaRequest struct {
+	Path       string   `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
+	Name       string   `json:"name" jsonschema:"Persona name"`
+	Role       string   `json:"role,omitempty" jsonschema:"Persona role"`
+	PainPoints []string `json:"pain_points,omitempty" jsonschema:"Pain points, one per item"`
+	DryRun     bool     `json:"dry_run,omitempty" jsonschema:"Preview the change and score delta without saving (default: false)"`
+}
+
+// AddPersona adds a user persona. The first persona becomes the primary
+// one.
+func (s *Service) AddPersona(req AddPersonaRequest) (*Result, error) {
+	name, err := required("name", req.Name)
+	if err != nil {
+		return nil, err
+	}
+	painPoints := cleanList(req.PainPoints)
+
+	return s.mutate(req.Path, req.DryRun, func(p *prd.PRD) (string, string, error) {
+		id := prd.AddPersona(p, name, req.Role, painPoints)
+		return id, fmt.Sprintf("Added persona: %s (%s)", name, id), nil
+	})
+}
+
+// AddGoalRequest adds a product goal.
+type AddGoalRequest struct {
+	Path      string `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
+	Statement string `json:"statement" jsonschema:"Goal statement"`
+	DryRun    bool   `json:"dry_run,omitempty" jsonschema:"Preview the change and score delta without saving (default: false)"`
+}
+
+// AddGoal adds a product goal.
+func (s *Service) AddGoal(req AddGoalRequest) (*Result, error) {
+	statement, err := required("statement", req.Statement)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mutate(req.Path, req.DryRun, func(p *prd.PRD) (string, string, error) {
+		id := prd.AddProductGoal(p, statement, "")
+		return id, fmt.Sprintf("Added goal: %s", id), nil
+	})
+}
+
+// AddNonGoalRequest adds an out-of-scope item.
+type AddNonGoalRequest struct {
+	Path      string `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
+	Statement string `json:"statement" jsonschema:"Non-goal statement"`
+	DryRun    bool   `json:"dry_run,omitempty" jsonschema:"Preview the change and score delta without saving (default: false)"`
+}
+
+// AddNonGoal adds an out-of-scope item. Non-goals have no ID.
+func (s *Service) AddNonGoal(req AddNonGoalRequest) (*Result, error) {
+	statement, err := required("statement", req.Statement)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mutate(req.Path, req.DryRun, func(p *prd.PRD) (string, string, error) {
+		prd.AddOutOfScope(p, statement)
+		return "", "Added non-goal", nil
+	})
+}
+
+// AddSolutionRequest adds a solution option.
+type AddSolutionRequest struct {
+	Path        string   `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
+	Name        string   `json:"name" jsonschema:"Solution name"`
+	Description string   `json:"description,omitempty" jsonschema:"Solution description"`
+	Tradeoffs   []string `json:"tradeoffs,omitempty" jsonschema:"Tradeoffs, one per item"`
+	DryRun      bool     `json:"dry_run,omitempty" jsonschema:"Preview the change and score delta without saving (default: false)"`
+}
+
+// AddSolution adds a solution option.
+func (s *Service) AddSolution(req AddSolutionRequest) (*Result, error) {
+	name, err := required("name", req.Name)
+	if err != nil {
+		return nil, err
+	}
+	tradeoffs := cleanList(req.Tradeoffs)
+
+	return s.mutate(req.Path, req.DryRun, func(p *prd.PRD) (string, string, error) {
+		id := prd.AddSolution(p, name, req.Description, tradeoffs)
+		return id, fmt.Sprintf("Added solution option: %s (%s)", name, id), nil
+	})
+}
+
+// AddRequirementRequest adds a functional requirement.
+type AddRequirementRequest struct {
+	Path        string `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
+	Title       string `json:"title,omitempty" jsonschema:"Requirement title (default: the start of the description)"`
+	Description string `json:"description" jsonschema:"Requirement description"`
+	Priority    string `json:"priority,omitempty" jsonschema:"Priority (default: should)"`
+	DryRun      bool   `json:"dry_run,omitempty" jsonschema:"Preview the change and score delta without saving (default: false)"`
+}
+
+// AddRequirement adds a functional requirement. Without a title, the
+// first 50 characters of the description are used.
+func (s *Service) AddRequirement(req AddRequirementRequest) (*Result, error) {
+	description, err := required("description", req.Description)
+	if err != nil {
+		return nil, err
+	}
+	priority, err := prd.ParseMoSCoW(defaultString(req.Priority, "should"))
+	if err != nil {
+		return nil, invalid("%v", err)
+	}
+	title := defaultString(req.Title, shortTitle(description))
+
+	return s.mutate(req.Path, req.DryRun, func(p *prd.PRD) (string, string, error) {
+		id := prd.AddFunctionalRequirement(p, title, description, priority)
+		return id, fmt.Sprintf("Added requirement: %s (%s)", id, priority), nil
+	})
+}
+
+// AddNFRRequest adds a non-functional requirement.
+type AddNFRRequest struct {
+	Path        string `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
+	Category    string `json:"category,omitempty" jsonschema:"NFR category (default: performance)"`
+	Title       string `json:"title,omitempty" jsonschema:"NFR title (default: the start of the requirement)"`
+	Requirement string `json:"requirement" jsonschema:"NFR description"`
+	Target      string `json:"target,omitempty" jsonschema:"Target value"`
+	Priority    string `json:"priority,omitempty" jsonschema:"Priority (default: should)"`
+	DryRun      bool   `json:"dry_run,omitempty" jsonschema:"Preview the change and score delta without saving (default: false)"`
+}
+
+// AddNFR adds a non-functional requirement. Without a title, the first
+// 50 characters of the requirement are used.
+func (s *Service) AddNFR(req AddNFRRequest) (*Result, error) {
+	requirement, err := required("requirement", req.Requirement)
+	if err != nil {
+		return nil, err
+	}
+	category, err := prd.ParseNFRCategory(defaultString(req.Category, "performance"))
+	if err != nil {
+		return nil, invalid("%v", err)
+	}
+	priority, err := prd.ParseMoSCoW(defaultString(req.Priority, "should"))
+	if err != nil {
+		return nil, invalid("%v", err)
+	}
+	title := defaultString(req.Title, shortTitle(requirement))
+
+	return s.mutate(req.Path, req.DryRun, func(p *prd.PRD) (string, string, error) {
+		id := prd.AddNonFunctionalRequirement(p, category, title, requirement, req.Target, priority)
+		return id, fmt.Sprintf("Added NFR: %s (%s)", id, category), nil
+	})
+}
+
+// AddMetricRequest adds a success metric.
+type AddMetricRequest struct {
+	Path        string `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
+	Name        string `json:"name" jsonschema:"Metric name"`
+	Description string `json:"description,omitempty" jsonschema:"How the metric is calculated"`
+	Target      string `json:"target,omitempty" jsonschema:"Target value"`
+	DryRun      bool   `json:"dry_run,omitempty" jsonschema:"Preview the change and score delta without saving (default: false)"`
+}
+
+// AddMetric adds a success metric as a key result of the first objective.
+func (s *Service) AddMetric(req AddMetricRequest) (*Result, error) {
+	name, err := required("name", req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mutate(req.Path, req.DryRun, func(p *prd.PRD) (string, string, error) {
+		id := prd.AddSuccessMetric(p, name, req.Description, req.Target)
+		return id, fmt.Sprintf("Added metric: %s (%s)", name, id), nil
+	})
+}
+
+// AddRiskRequest adds a risk.
+type AddRiskRequest struct {
+	Path        string `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
+	Description string `json:"description" jsonschema:"Risk description"`
+	Probability string `json:"probability,omitempty" jsonschema:"Probability (default: medium)"`
+	Impact      string `json:"impact,omitempty" jsonschema:"Impact level (default: medium)"`
+	Mitigation  string `json:"mitigation,omitempty" jsonschema:"Mitigation strategy"`
+	DryRun      bool   `json:"dry_run,omitempty" jsonschema:"Preview the change and score delta without saving (default: false)"`
+}
+
+// AddRisk adds a risk.
+func (s *Service) AddRisk(req AddRiskRequest) (*Result, error) {
+	description, err := required("description", req.Description)
+	if err != nil {
+		return nil, err
+	}
+	probability, err := prd.ParseRiskProbability(defaultString(req.Probability, "medium"))
+	if err != nil {
+		return nil, invalid("%v", err)
+	}
+	impact, err := prd.ParseRiskImpact(defaultString(req.Impact, "medium"))
+	if err != nil {
+		return nil, invalid("%v", err)
+	}
+
+	return s.mutate(req.Path, req.DryRun, func(p *prd.PRD) (string, string, error) {
+		id := prd.AddRisk(p, description, probability, impact, req.Mitigation)
+		return id, fmt.Sprintf("Added risk: %s (%s impact)", id, impact), nil
+	})
+}
+
+// AddDecisionRequest adds a decision record.
+type AddDecisionRequest struct {
+	Path      string `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
+	Decision  string `json:"decision" jsonschema:"Decision made"`
+	Rationale string `json:"rationale,omitempty" jsonschema:"Rationale for decision"`
+	MadeBy    string `json:"made_by,omitempty" jsonschema:"Who made the decision"`
+	DryRun    bool   `json:"dry_run,omitempty" jsonschema:"Preview the change and score delta without saving (default: false)"`
+}
+
+// AddDecision adds a decision record.
+func (s *Service) AddDecision(req AddDecisionRequest) (*Result, error) {
+	decision, err := required("decision", req.Decision)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mutate(req.Path, req.DryRun, func(p *prd.PRD) (string, string, error) {
+		id := prd.AddDecision(p, decision, req.Rationale, req.MadeBy)
+		return id, fmt.Sprintf("Added decision: %s", id), nil
+	})
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+)
+
+func TestAddPersonaNormalizesPainPoints(t *testing.T) {
+	svc := New()
+	path := newTestPRD(t)
+
+	res, err := svc.AddPersona(AddPersonaRequest{
+		Path:       path,
+		Name:       "  Developer Dan ",
+		PainPoints: []string{" Slow builds ", "", "  ", "Complex configs"},
+	})
+	if err != nil {
+		t.Fatalf("AddPersona failed: %v", err)
+	}
+	if res.Message != "Added persona: Developer Dan ("+res.ID+")" {
+		t.Errorf("unexpected message: %s", res.Message)
+	}
+
+	persona := load(t, path).Personas[0]
+	if persona.Name != "Developer Dan" {
+		t.Errorf("expected a trimmed name, got %q", persona.Name)
+	}
+	if got := strings.Join(persona.PainPoints, "|"); got != "Slow builds|Complex configs" {
+		t.Errorf("unexpected pain points: %q", got)
+	}
+}
+
+func TestAddRequirementDefaults(t *testing.T) {
+	svc := New()
+	path := newTestPRD(t)
+
+	description := strings.Repeat("Users can sign in with a link sent by email. ", 3)
+	res, err := svc.AddRequirement(AddRequirementRequest{Path: path, Description: description})
+	if err != nil {
+		t.Fatalf("AddRequirement failed: %v", err)
+	}
+	if !strings.HasSuffix(res.Message, "(should)") {
+		t.Errorf("expected the default priority in %q", res.Message)
+	}
+
+	req := load(t, path).Requirements.Functional[0]
+	if req.Priority != prd.MoSCoWShould {
+		t.Errorf("expected priority should, got %s", req.Priority)
+	}
+	if want := strings.TrimSpace(description)[:50] + "..."; req.Title != want {
+		t.Errorf("expected title %q, got %q", want, req.Title)
+	}
+}
+
+func TestAddNFRDefaults(t *testing.T) {
+	svc := New()
+	path := newTestPRD(t)
+
+	if _, err := svc.AddNFR(AddNFRRequest{Path: path, Requirement: "p95 login under 2s", Target: "2s"}); err != nil {
+		t.Fatalf("AddNFR failed: %v", err)
+	}
+	nfr := load(t, path).Requirements.NonFunctional[0]
+	if nfr.Category != prd.NFRPerformance || nfr.Priority != prd.MoSCoWShould {
+		t.Errorf("expected performance and should, got %s and %s", nfr.Category, nfr.Priority)
+	}
+	if nfr.Title != "p95 login under 2s" {
+		t.Errorf("expected the requirement as title, got %q", nfr.Title)
+	}
+}
+
+func TestAddProblemConfidence(t *testing.T) {
+	svc := New()
+	path := newTestPRD(t)
+
+	if _, err := svc.AddProblem(AddProblemRequest{Path: path, Statement: "Password resets flood support"}); err != nil {
+		t.Fatalf("AddProblem failed: %v", err)
+	}
+	if got := load(t, path).Problem.Confidence; got != 0.5 {
+		t.Errorf("expected default confidence 0.5, got %f", got)
+	}
+
+	var reqErr *RequestError
+	if _, err := svc.AddProblem(AddProblemRequest{Path: path, Statement: "x", Confidence: 1.5}); !errors.As(err, &reqErr) {
+		t.Errorf("expected a RequestError for confidence 1.5, got %v", err)
+	}
+}
+
+func TestAddRequiresFields(t *testing.T) {
+	svc := New()
+	path := newTestPRD(t)
+
+	tests := []struct {
+		name string
+		run  func() (*Result, error)
+	}{
+		{"problem", func() (*Result, error) { return svc.AddProblem(AddProblemRequest{Path: path}) }},
+		{"persona", func() (*Result, error) { return svc.AddPersona(AddPersonaRequest{Path: path, Name: " "}) }},
+		{"goal", func() (*Result, error) { return svc.AddGoal(AddGoalRequest{Path: path}) }},
+		{"nongoal", func() (*Result, error) { return svc.AddNonGoal(AddNonGoalRequest{Path: path}) }},
+		{"solution", func() (*Result, error) { return svc.AddSolution(AddSolutionRequest{Path: path}) }},
+		{"requirement", func() (*Result, error) { return svc.AddRequirement(AddRequirementRequest{Path: path}) }},
+		{"nfr", func() (*Result, error) { return svc.AddNFR(AddNFRRequest{Path: path}) }},
+		{"metric", func() (*Result, error) { return svc.AddMetric(AddMetricRequest{Path: path}) }},
+		{"risk", func() (*Result, error) { return svc.AddRisk(AddRiskRequest{Path: path}) }},
+		{"decision", func() (*Result, error) { return svc.AddDecision(AddDecisionRequest{Path: path}) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reqErr *RequestError
+			if _, err := tt.run(); !errors.As(err, &reqErr) {
+				t.Errorf("expected a RequestError, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAddRejectsInvalidValues(t *testing.T) {
+	svc := New()
+	path := newTestPRD(t)
+
+	tests := []struct {
+		name string
+		run  func() (*Result, error)
+		want string
+	}{
+		{"priority", func() (*Result, error) {
+			return svc.AddRequirement(AddRequirementRequest{Path: path, Description: "Sign in", Priority: "mst"})
+		}, `invalid priority "mst" (valid: must, should, could, wont)`},
+		{"category", func() (*Result, error) {
+			return svc.AddNFR(AddNFRRequest{Path: path, Requirement: "Isolate tenants", Category: "multitenancy"})
+		}, `invalid NFR category "multitenancy"`},
+		{"impact", func() (*Result, error) {
+			return svc.AddRisk(AddRiskRequest{Path: path, Description: "Outage", Impact: "severe"})
+		}, `invalid impact "severe"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.run()
+			var reqErr *RequestError
+			if !errors.As(err, &reqErr) || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected a RequestError containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+
+	if p := load(t, path); len(p.Requirements.Functional)+len(p.Requirements.NonFunctional)+len(p.Risks) != 0 {
+		t.Error("rejected requests changed the PRD")
+	}
+}
+
+func TestAddMissingPRD(t *testing.T) {
+	_, err := New().AddGoal(AddGoalRequest{Path: filepath.Join(t.TempDir(), "missing.json"), Statement: "Goal"})
+	if err == nil || !strings.Contains(err.Error(), "failed to load PRD") {
+		t.Errorf("expected a load error, got %v", err)
+	}
+}
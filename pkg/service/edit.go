@@ -0,0 +1,312 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/structured-evaluation/evaluation"
+)
+
+// ErrPRDExists is returned by Init when the PRD file already exists.
+var ErrPRDExists = errors.New("PRD file already exists")
+
+// InitRequest creates a new PRD.
+type InitRequest struct {
+	Path   string `json:"path,omitempty" jsonschema:"File path (default: PRD.json)"`
+	ID     string `json:"id,omitempty" jsonschema:"PRD ID (optional, auto-generated if not provided)"`
+	Title  string `json:"title" jsonschema:"PRD title"`
+	Owner  string `json:"owner" jsonschema:"PRD owner name"`
+	DryRun bool   `json:"dry_run,omitempty" jsonschema:"Preview the change and score delta without saving (default: false)"`
+}
+
+// Init creates a new PRD file. It refuses to replace an existing file.
+func (s *Service) Init(req InitRequest) (*Result, error) {
+	title, err := required("title", req.Title)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := required("owner", req.Owner)
+	if err != nil {
+		return nil, err
+	}
+
+	path := defaultPath(req.Path)
+	defer s.Lock(path)()
+
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("%w: %s", ErrPRDExists, path)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to check %s: %w", path, err)
+	}
+
+	id := strings.TrimSpace(req.ID)
+	if id == "" {
+		id = prd.GenerateID()
+	}
+
+	p := prd.New(id, title, prd.Person{Name: owner})
+	preview, err := Save(path, p, req.DryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	message := fmt.Sprintf("Created new PRD: %s\nID: %s\nTitle: %s\nOwner: %s", path, id, title, owner)
+	return &Result{Path: path, ID: id, Message: message, Preview: preview, PRD: p}, nil
+}
+
+// SelectSolutionRequest selects one of the solution options.
+type SelectSolutionRequest struct {
+	Path      string `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
+	ID        string `json:"id" jsonschema:"Solution ID to select"`
+	Rationale string `json:"rationale,omitempty" jsonschema:"Selection rationale"`
+	DryRun    bool   `json:"dry_run,omitempty" jsonschema:"Preview the change and score delta without saving (default: false)"`
+}
+
+// SelectSolution selects a solution option. The error wraps
+// prd.ErrEntityNotFound if there is no option with the ID.
+func (s *Service) SelectSolution(req SelectSolutionRequest) (*Result, error) {
+	id, err := required("id", req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mutate(req.Path, req.DryRun, func(p *prd.PRD) (string, string, error) {
+		if !prd.SelectSolution(p, id, req.Rationale) {
+			return "", "", fmt.Errorf("solution %s: %w", id, prd.ErrEntityNotFound)
+		}
+		return id, fmt.Sprintf("Selected solution: %s", id), nil
+	})
+}
+
+// UpdateStatusRequest changes the PRD status.
+type UpdateStatusRequest struct {
+	Path   string `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
+	Status string `json:"status" jsonschema:"New status"`
+	DryRun bool   `json:"dry_run,omitempty" jsonschema:"Preview the change and score delta without saving (default: false)"`
+}
+
+// UpdateStatus changes the PRD status through the workflow. If the
+// transition is illegal or a gate fails, the error is a
+// *prd.TransitionError naming the failed gates and nothing is saved.
+func (s *Service) UpdateStatus(req UpdateStatusRequest) (*Result, error) {
+	status, err := prd.ParseStatus(req.Status)
+	if err != nil {
+		return nil, invalid("%v", err)
+	}
+
+	var gates []prd.GateResult
+	res, err := s.mutate(req.Path, req.DryRun, func(p *prd.PRD) (string, string, error) {
+		var err error
+		if gates, err = s.Workflow.Transition(p, status); err != nil {
+			return "", "", err
+		}
+		return "", fmt.Sprintf("Updated status to: %s", status), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	res.Gates = gates
+	return res, nil
+}
+
+// PatchRequest edits arbitrary fields with a JSON Patch or a merge patch.
+type PatchRequest struct {
+	Path       string               `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
+	Operations []prd.PatchOperation `json:"operations,omitempty" jsonschema:"RFC 6902 JSON Patch operations, applied all-or-nothing"`
+	Merge      map[string]any       `json:"merge,omitempty" jsonschema:"RFC 7396 JSON Merge Patch; null values remove fields"`
+	DryRun     bool                 `json:"dry_run,omitempty" jsonschema:"Preview the change and score delta without saving (default: false)"`
+}
+
+// Patch applies exactly one of the JSON Patch operations or the merge
+// patch. A patch that introduces validation errors is rejected with a
+// *prd.PatchValidationError, and one that changes the status is checked
+// against the workflow like UpdateStatus. Nothing is saved on error.
+func (s *Service) Patch(req PatchRequest) (*Result, error) {
+	if (len(req.Operations) == 0) == (req.Merge == nil) {
+		return nil, invalid("provide exactly one of operations or merge")
+	}
+
+	path := defaultPath(req.Path)
+	return s.mutate(path, req.DryRun, func(p *prd.PRD) (string, string, error) {
+		var patched *prd.PRD
+		var err error
+		if req.Merge != nil {
+			patched, err = prd.ApplyMergePatch(p, req.Merge)
+		} else {
+			patched, err = prd.ApplyJSONPatch(p, req.Operations)
+		}
+		if err != nil {
+			return "", "", rejected(err)
+		}
+		if _, err := s.Workflow.CheckChange(p, patched); err != nil {
+			return "", "", err
+		}
+		*p = *patched
+		return "", fmt.Sprintf("Patched %s", path), nil
+	})
+}
+
+// RecordReviewRequest records an EvaluationReport in the reviews section.
+// Frontends read or decode the report themselves, so it has no JSON tags.
+type RecordReviewRequest struct {
+	Path   string
+	Report *evaluation.EvaluationReport
+	DryRun bool
+}
+
+// RecordReview records a finalized EvaluationReport's scores, decision,
+// blockers and revision triggers, replacing any earlier review.
+func (s *Service) RecordReview(req RecordReviewRequest) (*Result, error) {
+	if req.Report == nil {
+		return nil, invalid("report is required")
+	}
+
+	return s.mutate(req.Path, req.DryRun, func(p *prd.PRD) (string, string, error) {
+		if err := prd.RecordReview(p, req.Report); err != nil {
+			return "", "", rejected(err)
+		}
+		return "", fmt.Sprintf("Recorded review: %s (%.1f/10, %d blockers)",
+			p.Reviews.Decision, p.Reviews.QualityScores.OverallScore, len(p.Reviews.Blockers)), nil
+	})
+}
+
+// SignOffRequest records an approver's sign-off on the PRD's current
+// content.
+type SignOffRequest struct {
+	Path     string `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
+	Approver string `json:"approver" jsonschema:"Name of the approver signing off"`
+	Comment  string `json:"comment,omitempty" jsonschema:"Comment on the decision (required to reject)"`
+	DryRun   bool   `json:"dry_run,omitempty" jsonschema:"Preview the change and score delta without saving (default: false)"`
+}
+
+// Approve records an approval of the PRD's current content. It stays
+// valid until the content changes (see prd.ContentHash).
+func (s *Service) Approve(req SignOffRequest) (*Result, error) {
+	return s.signOff(req, prd.SignOffApproved)
+}
+
+// Reject records a rejection of the PRD's current content. A comment
+// explaining the rejection is required.
+func (s *Service) Reject(req SignOffRequest) (*Result, error) {
+	if _, err := required("comment", req.Comment); err != nil {
+		return nil, err
+	}
+	return s.signOff(req, prd.SignOffRejected)
+}
+
+func (s *Service) signOff(req SignOffRequest, decision prd.SignOffDecision) (*Result, error) {
+	approver, err := required("approver", req.Approver)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mutate(req.Path, req.DryRun, func(p *prd.PRD) (string, string, error) {
+		signOff, err := prd.RecordSignOff(p, approver, decision, req.Comment)
+		if err != nil {
+			return "", "", rejected(err)
+		}
+		verb := "Approved"
+		if decision == prd.SignOffRejected {
+			verb = "Rejected"
+		}
+		return "", fmt.Sprintf("%s by %s (content %s)", verb, approver, signOff.ContentHash[:12]), nil
+	})
+}
+
+// CreateEntityRequest adds an entity of any kind from field values.
+type CreateEntityRequest struct {
+	Path   string            `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
+	Kind   string            `json:"kind" jsonschema:"Entity kind, e.g. requirements, risks or personas (see prd_entity_kinds)"`
+	Values map[string]string `json:"values" jsonschema:"Field values, keyed by field name"`
+	DryRun bool              `json:"dry_run,omitempty" jsonschema:"Preview the change and score delta without saving (default: false)"`
+}
+
+// CreateEntity adds an entity with the next free ID of its kind. The
+// result's ID is the new entity's.
+func (s *Service) CreateEntity(req CreateEntityRequest) (*Result, error) {
+	kind, err := LookupEntityKind(req.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mutate(req.Path, req.DryRun, func(p *prd.PRD) (string, string, error) {
+		id, err := kind.Create(p, req.Values)
+		if err != nil {
+			return "", "", rejected(err)
+		}
+		return id, fmt.Sprintf("Added %s", id), nil
+	})
+}
+
+// UpdateEntityRequest changes fields of an entity.
+type UpdateEntityRequest struct {
+	Path   string            `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
+	Kind   string            `json:"kind" jsonschema:"Entity kind, e.g. requirements, risks or personas (see prd_entity_kinds)"`
+	ID     string            `json:"id" jsonschema:"Entity ID, e.g. FR-3"`
+	Values map[string]string `json:"values" jsonschema:"Field values to change, keyed by field name; other fields are kept"`
+	DryRun bool              `json:"dry_run,omitempty" jsonschema:"Preview the change and score delta without saving (default: false)"`
+}
+
+// UpdateEntity sets the given fields of an entity, keeping the rest.
+func (s *Service) UpdateEntity(req UpdateEntityRequest) (*Result, error) {
+	kind, err := LookupEntityKind(req.Kind)
+	if err != nil {
+		return nil, err
+	}
+	id, err := required("id", req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mutate(req.Path, req.DryRun, func(p *prd.PRD) (string, string, error) {
+		if err := kind.Update(p, id, req.Values); err != nil {
+			return "", "", rejected(err)
+		}
+		return id, fmt.Sprintf("Updated %s", id), nil
+	})
+}
+
+// RemoveEntityRequest removes an entity.
+type RemoveEntityRequest struct {
+	Path   string `json:"path,omitempty" jsonschema:"Path to PRD file (default: PRD.json)"`
+	Kind   string `json:"kind" jsonschema:"Entity kind, e.g. requirements, risks or personas (see prd_entity_kinds)"`
+	ID     string `json:"id" jsonschema:"Entity ID, e.g. FR-3"`
+	DryRun bool   `json:"dry_run,omitempty" jsonschema:"Preview the change and score delta without saving (default: false)"`
+}
+
+// RemoveEntity removes an entity.
+func (s *Service) RemoveEntity(req RemoveEntityRequest) (*Result, error) {
+	kind, err := LookupEntityKind(req.Kind)
+	if err != nil {
+		return nil, err
+	}
+	id, err := required("id", req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mutate(req.Path, req.DryRun, func(p *prd.PRD) (string, string, error) {
+		if err := kind.Delete(p, id); err != nil {
+			return "", "", err
+		}
+		return id, fmt.Sprintf("Removed %s", id), nil
+	})
+}
+
+// LookupEntityKind returns the entity kind with the given name, or an
+// error listing the valid names.
+func LookupEntityKind(name string) (*prd.EntityKind, error) {
+	kind, ok := prd.LookupEntityKind(strings.TrimSpace(name))
+	if !ok {
+		var names []string
+		for _, k := range prd.EntityKinds() {
+			names = append(names, k.Name)
+		}
+		return nil, invalid("unknown entity kind %q (use %s)", name, strings.Join(names, ", "))
+	}
+	return kind, nil
+}
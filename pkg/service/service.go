@@ -0,0 +1,230 @@
+// Package service implements the PRD operations shared by the prdtool CLI,
+// the prdtool-mcp server and the REST API of pkg/server.
+//
+// Each operation takes a typed request, normalizes and validates it, and
+// runs a load-modify-save cycle on the PRD file under a per-file lock. The
+// frontends only translate flags or tool arguments into requests and
+// results into output, so an operation behaves the same in both, and new
+// operations appear in both consistently.
+//
+// Requests carry snake_case JSON tags with jsonschema descriptions, so the
+// MCP server can use them directly as tool inputs.
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/agent-team-prd/pkg/scoring"
+)
+
+// DefaultPath is the PRD file used when a request has no path.
+const DefaultPath = "PRD.json"
+
+// Service runs PRD operations. Operations on the same file are serialized,
+// so concurrent callers don't overwrite each other's changes.
+type Service struct {
+	// Workflow checks status changes made with UpdateStatus or Patch.
+	Workflow *prd.Workflow
+
+	locks *lockTable
+
+	// check, if set, must accept the loaded PRD before an operation
+	// changes it (see If).
+	check func(p *prd.PRD) error
+}
+
+// lockTable holds a lock per PRD file.
+type lockTable struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// New creates a Service that enforces scoring.DefaultWorkflow.
+func New() *Service {
+	return &Service{Workflow: scoring.DefaultWorkflow(), locks: &lockTable{locks: make(map[string]*sync.Mutex)}}
+}
+
+// If returns a Service that shares s's workflow and locks, but whose
+// operations only change a PRD that check accepts. check sees the PRD as
+// loaded under the lock, so a caller can make a change conditional on the
+// content it last read; its error is returned as is.
+func (s *Service) If(check func(p *prd.PRD) error) *Service {
+	c := *s
+	c.check = check
+	return &c
+}
+
+// Result is returned by operations that change a PRD.
+type Result struct {
+	Path    string   `json:"path" jsonschema:"Path to the PRD file"`
+	ID      string   `json:"id,omitempty" jsonschema:"ID of the created or affected entity"`
+	Message string   `json:"message" jsonschema:"Human-readable summary"`
+	Preview *Preview `json:"preview,omitempty" jsonschema:"Changes that would be made, when dry_run is set"`
+
+	// Gates are the workflow gates checked for a status change.
+	Gates []prd.GateResult `json:"gates,omitempty" jsonschema:"Workflow gates checked for a status change"`
+
+	// PRD is the changed document. For a dry run it has not been saved.
+	PRD *prd.PRD `json:"-"`
+}
+
+// Preview describes what an operation would change when run as a dry run.
+type Preview struct {
+	Changes []prd.Change  `json:"changes" jsonschema:"Field-level changes, addressed by path"`
+	Score   scoring.Delta `json:"score" jsonschema:"Score and decision before and after the change"`
+}
+
+// RequestError reports a request with a missing or invalid field, or a
+// change the PRD can't take, such as a patch whose path doesn't exist.
+// Nothing is saved when a request is rejected.
+type RequestError struct {
+	Message string
+
+	// Err is the underlying error, if any.
+	Err error
+}
+
+func (e *RequestError) Error() string {
+	return e.Message
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+func invalid(format string, args ...interface{}) error {
+	return &RequestError{Message: fmt.Sprintf(format, args...)}
+}
+
+// rejected reports err, caused by the request, as a *RequestError that
+// wraps it. A missing entity is returned as is.
+func rejected(err error) error {
+	if err == nil || errors.Is(err, prd.ErrEntityNotFound) {
+		return err
+	}
+	return &RequestError{Message: err.Error(), Err: err}
+}
+
+// Lock acquires the lock for the PRD at path and returns a function that
+// releases it. Callers that save with Save outside an operation should
+// hold it.
+//
+//	defer svc.Lock(path)()
+func (s *Service) Lock(path string) func() {
+	key := path
+	if abs, err := filepath.Abs(path); err == nil {
+		key = abs
+	}
+
+	s.locks.mu.Lock()
+	m, ok := s.locks.locks[key]
+	if !ok {
+		m = &sync.Mutex{}
+		s.locks.locks[key] = m
+	}
+	s.locks.mu.Unlock()
+
+	m.Lock()
+	return m.Unlock
+}
+
+// Save saves p to path. For a dry run it leaves the file alone and returns
+// the changes relative to the saved PRD instead.
+func Save(path string, p *prd.PRD, dryRun bool) (*Preview, error) {
+	if !dryRun {
+		if err := prd.Save(p, path); err != nil {
+			return nil, fmt.Errorf("failed to save PRD: %w", err)
+		}
+		return nil, nil
+	}
+
+	before, err := prd.Load(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		before = nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to load PRD: %w", err)
+	}
+
+	changes, err := prd.Diff(before, p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff PRD: %w", err)
+	}
+	return &Preview{Changes: changes, Score: scoring.Compare(before, p)}, nil
+}
+
+// mutate loads the PRD at path, changes it in place with fn and saves it.
+// fn returns the ID and message of the result.
+func (s *Service) mutate(path string, dryRun bool, fn func(p *prd.PRD) (id, message string, err error)) (*Result, error) {
+	path = defaultPath(path)
+	defer s.Lock(path)()
+
+	p, err := prd.Load(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load PRD: %w", err)
+	}
+	if s.check != nil {
+		if err := s.check(p); err != nil {
+			return nil, err
+		}
+	}
+
+	id, message, err := fn(p)
+	if err != nil {
+		return nil, err
+	}
+
+	preview, err := Save(path, p, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Path: path, ID: id, Message: message, Preview: preview, PRD: p}, nil
+}
+
+func defaultPath(path string) string {
+	if path == "" {
+		return DefaultPath
+	}
+	return path
+}
+
+func defaultString(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
+
+// required trims s and rejects it if it is empty.
+func required(name, s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", invalid("%s is required", name)
+	}
+	return s, nil
+}
+
+// cleanList trims the items of a list and drops empty ones.
+func cleanList(items []string) []string {
+	var result []string
+	for _, item := range items {
+		if trimmed := strings.TrimSpace(item); trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
+
+// shortTitle derives a title from a description, truncated to 50
+// characters.
+func shortTitle(description string) string {
+	if r := []rune(description); len(r) > 50 {
+		return string(r[:50]) + "..."
+	}
+	return description
+}
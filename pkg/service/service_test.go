@@ -0,0 +1,377 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+)
+
+// newTestPRD creates a PRD file in a temporary directory and returns its
+// path.
+func newTestPRD(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "PRD.json")
+	if _, err := New().Init(InitRequest{Path: path, ID: "PRD-2026-001", Title: "Service Test", Owner: "Jane PM"}); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	return path
+}
+
+func load(t *testing.T, path string) *prd.PRD {
+	t.Helper()
+	p, err := prd.Load(path)
+	if err != nil {
+		t.Fatalf("failed to load PRD: %v", err)
+	}
+	return p
+}
+
+func TestInit(t *testing.T) {
+	svc := New()
+	path := filepath.Join(t.TempDir(), "PRD.json")
+
+	res, err := svc.Init(InitRequest{Path: path, Title: " Passwordless Login ", Owner: "Jane PM"})
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	if res.ID == "" || res.Path != path {
+		t.Errorf("unexpected result: %+v", res)
+	}
+	if p := load(t, path); p.Metadata.Title != "Passwordless Login" || p.Metadata.ID != res.ID {
+		t.Errorf("unexpected metadata: %+v", p.Metadata)
+	}
+
+	if _, err := svc.Init(InitRequest{Path: path, Title: "Again", Owner: "Jane PM"}); !errors.Is(err, ErrPRDExists) {
+		t.Errorf("expected ErrPRDExists, got %v", err)
+	}
+
+	var reqErr *RequestError
+	if _, err := svc.Init(InitRequest{Path: filepath.Join(t.TempDir(), "x.json"), Title: "No owner"}); !errors.As(err, &reqErr) {
+		t.Errorf("expected a RequestError for a missing owner, got %v", err)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	svc := New()
+	path := newTestPRD(t)
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := svc.AddGoal(AddGoalRequest{Path: path, Statement: "Cut login time in half", DryRun: true})
+	if err != nil {
+		t.Fatalf("AddGoal failed: %v", err)
+	}
+	if res.Preview == nil || len(res.Preview.Changes) == 0 {
+		t.Fatalf("expected a preview with changes, got %+v", res.Preview)
+	}
+	if after, _ := os.ReadFile(path); string(after) != string(before) {
+		t.Error("dry run modified the PRD file")
+	}
+
+	// A dry run of Init previews the whole document without creating it.
+	newPath := filepath.Join(t.TempDir(), "new.json")
+	res, err = svc.Init(InitRequest{Path: newPath, Title: "New", Owner: "Jane PM", DryRun: true})
+	if err != nil || res.Preview == nil {
+		t.Fatalf("expected a preview, got %+v, %v", res, err)
+	}
+	if _, err := os.Stat(newPath); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("dry run created %s", newPath)
+	}
+}
+
+func TestUpdateStatus(t *testing.T) {
+	svc := New()
+	path := newTestPRD(t)
+
+	var terr *prd.TransitionError
+	if _, err := svc.UpdateStatus(UpdateStatusRequest{Path: path, Status: "approved"}); !errors.As(err, &terr) {
+		t.Errorf("expected a TransitionError for draft to approved, got %v", err)
+	}
+
+	res, err := svc.UpdateStatus(UpdateStatusRequest{Path: path, Status: "in_review"})
+	if err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+	if len(res.Gates) != 1 || res.Gates[0].Gate != "valid" || !res.Gates[0].Passed {
+		t.Errorf("expected the valid gate to pass, got %+v", res.Gates)
+	}
+	if got := load(t, path).Metadata.Status; got != prd.StatusInReview {
+		t.Errorf("expected in_review, got %s", got)
+	}
+
+	var reqErr *RequestError
+	if _, err := svc.UpdateStatus(UpdateStatusRequest{Path: path, Status: "shipped"}); !errors.As(err, &reqErr) {
+		t.Errorf("expected a RequestError for an unknown status, got %v", err)
+	}
+}
+
+func TestStatusGates(t *testing.T) {
+	svc := New()
+	svc.Workflow = &prd.Workflow{Gates: map[prd.Status][]prd.Gate{
+		prd.StatusApproved: {prd.SignOffGate()},
+	}}
+	path := newTestPRD(t)
+
+	merge := map[string]any{"metadata": map[string]any{
+		"status":    "in_review",
+		"approvers": []any{map[string]any{"name": "Bob", "approved": false}},
+	}}
+	if _, err := svc.Patch(PatchRequest{Path: path, Merge: merge}); err != nil {
+		t.Fatalf("Patch failed: %v", err)
+	}
+
+	_, err := svc.UpdateStatus(UpdateStatusRequest{Path: path, Status: "approved"})
+	var terr *prd.TransitionError
+	if !errors.As(err, &terr) || len(terr.Failed) != 1 || terr.Failed[0].Gate != "sign-off" {
+		t.Fatalf("expected the sign-off gate to fail, got %v", err)
+	}
+
+	// A patch can't skip the gates by setting the status directly.
+	merge = map[string]any{"metadata": map[string]any{"status": "approved"}}
+	if _, err := svc.Patch(PatchRequest{Path: path, Merge: merge}); !errors.As(err, &terr) {
+		t.Errorf("expected a TransitionError for a status patch, got %v", err)
+	}
+	if got := load(t, path).Metadata.Status; got != prd.StatusInReview {
+		t.Errorf("expected in_review, got %s", got)
+	}
+}
+
+func TestSignOff(t *testing.T) {
+	svc := New()
+	svc.Workflow = &prd.Workflow{Gates: map[prd.Status][]prd.Gate{
+		prd.StatusApproved: {prd.SignOffGate()},
+	}}
+	path := newTestPRD(t)
+	if _, err := svc.UpdateStatus(UpdateStatusRequest{Path: path, Status: "in_review"}); err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+
+	var reqErr *RequestError
+	if _, err := svc.Reject(SignOffRequest{Path: path, Approver: "Bob"}); !errors.As(err, &reqErr) {
+		t.Errorf("expected a RequestError for a rejection without comment, got %v", err)
+	}
+	if _, err := svc.Approve(SignOffRequest{Path: path}); !errors.As(err, &reqErr) {
+		t.Errorf("expected a RequestError for a missing approver, got %v", err)
+	}
+	if _, err := svc.Approve(SignOffRequest{Path: path, Approver: "Bob"}); !errors.As(err, &reqErr) {
+		t.Errorf("expected a RequestError for an approver who isn't listed, got %v", err)
+	}
+
+	merge := map[string]any{"metadata": map[string]any{"approvers": []any{map[string]any{"name": "Bob"}}}}
+	if _, err := svc.Patch(PatchRequest{Path: path, Merge: merge}); err != nil {
+		t.Fatalf("Patch failed: %v", err)
+	}
+
+	if _, err := svc.Approve(SignOffRequest{Path: path, Approver: "Bob"}); err != nil {
+		t.Fatalf("Approve failed: %v", err)
+	}
+
+	// A material change invalidates the sign-off.
+	if _, err := svc.AddGoal(AddGoalRequest{Path: path, Statement: "Cut login time in half"}); err != nil {
+		t.Fatalf("AddGoal failed: %v", err)
+	}
+	_, err := svc.UpdateStatus(UpdateStatusRequest{Path: path, Status: "approved"})
+	if err == nil || !strings.Contains(err.Error(), "waiting for Bob (stale)") {
+		t.Fatalf("expected a stale sign-off, got %v", err)
+	}
+
+	if _, err := svc.Approve(SignOffRequest{Path: path, Approver: "Bob"}); err != nil {
+		t.Fatalf("Approve failed: %v", err)
+	}
+	if _, err := svc.UpdateStatus(UpdateStatusRequest{Path: path, Status: "approved"}); err != nil {
+		t.Errorf("expected the approval to pass, got %v", err)
+	}
+}
+
+func TestSelectSolution(t *testing.T) {
+	svc := New()
+	path := newTestPRD(t)
+
+	res, err := svc.AddSolution(AddSolutionRequest{Path: path, Name: "Magic links"})
+	if err != nil {
+		t.Fatalf("AddSolution failed: %v", err)
+	}
+
+	preview, err := svc.SelectSolution(SelectSolutionRequest{Path: path, ID: res.ID, DryRun: true})
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if preview.Preview == nil || len(preview.Preview.Changes) == 0 {
+		t.Error("expected the dry run to preview the selection")
+	}
+	if load(t, path).Solution.SelectedSolutionID != "" {
+		t.Error("dry run selected the solution")
+	}
+
+	if _, err := svc.SelectSolution(SelectSolutionRequest{Path: path, ID: " " + res.ID + " ", Rationale: "Simplest"}); err != nil {
+		t.Fatalf("SelectSolution failed: %v", err)
+	}
+	if sol := load(t, path).Solution; sol.SelectedSolutionID != res.ID || sol.SolutionRationale != "Simplest" {
+		t.Errorf("expected %s to be selected with its rationale, got %q %q", res.ID, sol.SelectedSolutionID, sol.SolutionRationale)
+	}
+
+	if _, err := svc.SelectSolution(SelectSolutionRequest{Path: path, ID: "SOL-9"}); !errors.Is(err, prd.ErrEntityNotFound) {
+		t.Errorf("expected ErrEntityNotFound, got %v", err)
+	}
+	var reqErr *RequestError
+	if _, err := svc.SelectSolution(SelectSolutionRequest{Path: path, ID: " "}); !errors.As(err, &reqErr) {
+		t.Errorf("expected a RequestError for a missing ID, got %v", err)
+	}
+}
+
+func TestPatch(t *testing.T) {
+	svc := New()
+	path := newTestPRD(t)
+
+	var reqErr *RequestError
+	if _, err := svc.Patch(PatchRequest{Path: path}); !errors.As(err, &reqErr) {
+		t.Errorf("expected a RequestError without a patch, got %v", err)
+	}
+
+	res, err := svc.Patch(PatchRequest{Path: path, Merge: map[string]any{"metadata": map[string]any{"version": "1.1.0"}}})
+	if err != nil {
+		t.Fatalf("Patch failed: %v", err)
+	}
+	if res.PRD.Metadata.Version != "1.1.0" || load(t, path).Metadata.Version != "1.1.0" {
+		t.Error("expected the merge patch to be saved")
+	}
+
+	_, err = svc.Patch(PatchRequest{Path: path, Operations: []prd.PatchOperation{{Op: "remove", Path: "/metadata/title"}}})
+	var verr *prd.PatchValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected a PatchValidationError, got %v", err)
+	}
+	if load(t, path).Metadata.Title != "Service Test" {
+		t.Error("rejected patch was saved")
+	}
+
+	_, err = svc.Patch(PatchRequest{Path: path, Operations: []prd.PatchOperation{{Op: "remove", Path: "/metadata/missing"}}})
+	if !errors.As(err, &reqErr) {
+		t.Errorf("expected a RequestError for a missing path, got %v", err)
+	}
+}
+
+func TestIf(t *testing.T) {
+	svc := New()
+	path := newTestPRD(t)
+
+	stale := errors.New("stale")
+	guarded := svc.If(func(p *prd.PRD) error {
+		if p.Metadata.Version != "1.0.0" {
+			return stale
+		}
+		return nil
+	})
+
+	if _, err := guarded.Patch(PatchRequest{Path: path, Merge: map[string]any{"metadata": map[string]any{"version": "1.1.0"}}}); err != nil {
+		t.Fatalf("expected the first change to pass the check, got %v", err)
+	}
+	if _, err := guarded.Patch(PatchRequest{Path: path, Merge: map[string]any{"metadata": map[string]any{"version": "2.0.0"}}}); !errors.Is(err, stale) {
+		t.Errorf("expected the check's error, got %v", err)
+	}
+	if got := load(t, path).Metadata.Version; got != "1.1.0" {
+		t.Errorf("expected the rejected change not to be saved, got version %s", got)
+	}
+
+	if _, err := svc.Patch(PatchRequest{Path: path, Merge: map[string]any{"metadata": map[string]any{"version": "2.0.0"}}}); err != nil {
+		t.Errorf("expected the original service to be unchecked, got %v", err)
+	}
+}
+
+func TestEntities(t *testing.T) {
+	svc := New()
+	path := newTestPRD(t)
+
+	res, err := svc.AddRisk(AddRiskRequest{Path: path, Description: "Email delivery delays"})
+	if err != nil {
+		t.Fatalf("AddRisk failed: %v", err)
+	}
+
+	if _, err := svc.UpdateEntity(UpdateEntityRequest{Path: path, Kind: "risks", ID: res.ID, Values: map[string]string{"mitigation": "Fall back to SMS"}}); err != nil {
+		t.Fatalf("UpdateEntity failed: %v", err)
+	}
+	if got := load(t, path).Risks[0].Mitigation; got != "Fall back to SMS" {
+		t.Errorf("expected the mitigation to be updated, got %q", got)
+	}
+
+	if _, err := svc.RemoveEntity(RemoveEntityRequest{Path: path, Kind: "risks", ID: res.ID}); err != nil {
+		t.Fatalf("RemoveEntity failed: %v", err)
+	}
+	if n := len(load(t, path).Risks); n != 0 {
+		t.Errorf("expected no risks, got %d", n)
+	}
+
+	var reqErr *RequestError
+	if _, err := svc.RemoveEntity(RemoveEntityRequest{Path: path, Kind: "widgets", ID: "W-1"}); !errors.As(err, &reqErr) {
+		t.Errorf("expected a RequestError for an unknown kind, got %v", err)
+	}
+}
+
+func TestEntityErrors(t *testing.T) {
+	svc := New()
+	path := newTestPRD(t)
+
+	res, err := svc.AddRisk(AddRiskRequest{Path: path, Description: "Email delivery delays", Impact: "high"})
+	if err != nil {
+		t.Fatalf("AddRisk failed: %v", err)
+	}
+
+	var reqErr *RequestError
+	_, err = svc.UpdateEntity(UpdateEntityRequest{Path: path, Kind: "risks", ID: res.ID, Values: map[string]string{"impact": "severe"}})
+	if !errors.As(err, &reqErr) {
+		t.Errorf("expected a RequestError for an invalid impact, got %v", err)
+	}
+	if _, err := svc.UpdateEntity(UpdateEntityRequest{Path: path, Kind: "risks", ID: "RISK-9", Values: map[string]string{"mitigation": "x"}}); !errors.Is(err, prd.ErrEntityNotFound) {
+		t.Errorf("expected ErrEntityNotFound for an unknown risk, got %v", err)
+	}
+	if _, err := svc.RemoveEntity(RemoveEntityRequest{Path: path, Kind: "risks", ID: "RISK-9"}); !errors.Is(err, prd.ErrEntityNotFound) {
+		t.Errorf("expected ErrEntityNotFound for an unknown risk, got %v", err)
+	}
+	if _, err := svc.RemoveEntity(RemoveEntityRequest{Path: path, Kind: "risks"}); !errors.As(err, &reqErr) {
+		t.Errorf("expected a RequestError for a missing ID, got %v", err)
+	}
+
+	update, err := svc.UpdateEntity(UpdateEntityRequest{Path: path, Kind: "risks", ID: res.ID, Values: map[string]string{"mitigation": "Fall back to SMS"}, DryRun: true})
+	if err != nil {
+		t.Fatalf("dry run update failed: %v", err)
+	}
+	remove, err := svc.RemoveEntity(RemoveEntityRequest{Path: path, Kind: "risks", ID: res.ID, DryRun: true})
+	if err != nil {
+		t.Fatalf("dry run remove failed: %v", err)
+	}
+	if update.Preview == nil || remove.Preview == nil {
+		t.Error("expected dry runs to return a preview")
+	}
+	if risks := load(t, path).Risks; len(risks) != 1 || risks[0].Mitigation != "" || risks[0].Impact != prd.RiskImpactHigh {
+		t.Errorf("expected the invalid and dry-run changes not to be saved, got %+v", risks)
+	}
+}
+
+func TestConcurrentChanges(t *testing.T) {
+	svc := New()
+	path := newTestPRD(t)
+
+	const n = 20
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := svc.AddGoal(AddGoalRequest{Path: path, Statement: "Goal"}); err != nil {
+				t.Errorf("AddGoal failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := len(load(t, path).Objectives.OKRs); got != n {
+		t.Errorf("expected %d goals, got %d", n, got)
+	}
+}
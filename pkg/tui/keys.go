@@ -0,0 +1,99 @@
+package tui
+
+import "unicode/utf8"
+
+// KeyCode identifies a key press. Printable characters are KeyRune.
+type KeyCode int
+
+// Key codes.
+const (
+	KeyRune KeyCode = iota
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyEnter
+	KeyEsc
+	KeyTab
+	KeyBackspace
+	KeyDelete
+	KeyCtrlC
+	KeyCtrlS
+	KeyCtrlU
+)
+
+// Key is a single key press.
+type Key struct {
+	Code KeyCode
+	Rune rune
+}
+
+// ParseKeys decodes the bytes read from a terminal in raw mode. A lone
+// escape byte is the Esc key; escape sequences for the arrow and delete
+// keys are recognized and other sequences are dropped.
+func ParseKeys(b []byte) []Key {
+	var keys []Key
+	for len(b) > 0 {
+		switch c := b[0]; {
+		case c == 0x1b:
+			n, k := parseEscape(b)
+			if n == 1 || k.Code != KeyEsc {
+				keys = append(keys, k)
+			}
+			b = b[n:]
+			continue
+		case c == '\r' || c == '\n':
+			keys = append(keys, Key{Code: KeyEnter})
+		case c == '\t':
+			keys = append(keys, Key{Code: KeyTab})
+		case c == 0x7f || c == 0x08:
+			keys = append(keys, Key{Code: KeyBackspace})
+		case c == 0x03:
+			keys = append(keys, Key{Code: KeyCtrlC})
+		case c == 0x13:
+			keys = append(keys, Key{Code: KeyCtrlS})
+		case c == 0x15:
+			keys = append(keys, Key{Code: KeyCtrlU})
+		case c < 0x20:
+			// Other control characters are ignored.
+		default:
+			r, size := utf8.DecodeRune(b)
+			keys = append(keys, Key{Code: KeyRune, Rune: r})
+			b = b[size:]
+			continue
+		}
+		b = b[1:]
+	}
+	return keys
+}
+
+// parseEscape decodes an escape sequence at the start of b and returns
+// its length. Unrecognized sequences are returned as KeyEsc with a length
+// greater than one.
+func parseEscape(b []byte) (int, Key) {
+	if len(b) < 2 || (b[1] != '[' && b[1] != 'O') {
+		return 1, Key{Code: KeyEsc}
+	}
+	// Skip parameter bytes up to the final byte of the sequence.
+	i := 2
+	for i < len(b) && (b[i] < 0x40 || b[i] > 0x7e) {
+		i++
+	}
+	if i == len(b) {
+		return len(b), Key{Code: KeyEsc}
+	}
+	seq := string(b[2:i]) + string(b[i])
+	switch seq {
+	case "A":
+		return i + 1, Key{Code: KeyUp}
+	case "B":
+		return i + 1, Key{Code: KeyDown}
+	case "C":
+		return i + 1, Key{Code: KeyRight}
+	case "D":
+		return i + 1, Key{Code: KeyLeft}
+	case "3~":
+		return i + 1, Key{Code: KeyDelete}
+	}
+	return i + 1, Key{Code: KeyEsc}
+}
@@ -0,0 +1,98 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// resizePoll is how often the terminal size is checked for changes.
+const resizePoll = 250 * time.Millisecond
+
+// Run puts the terminal in raw mode on the alternate screen and runs the
+// editor until the user quits. The terminal is restored on return.
+func Run(m *Model, in, out *os.File) error {
+	inFd, outFd := int(in.Fd()), int(out.Fd())
+	if !term.IsTerminal(inFd) || !term.IsTerminal(outFd) {
+		return fmt.Errorf("tui requires an interactive terminal")
+	}
+
+	state, err := term.MakeRaw(inFd)
+	if err != nil {
+		return fmt.Errorf("failed to enter raw mode: %w", err)
+	}
+	defer func() { _ = term.Restore(inFd, state) }()
+
+	fmt.Fprint(out, "\x1b[?1049h\x1b[?25l")
+	defer fmt.Fprint(out, "\x1b[?25h\x1b[?1049l")
+
+	keys := make(chan []Key)
+	errs := make(chan error, 1)
+	go readKeys(in, keys, errs)
+
+	ticker := time.NewTicker(resizePoll)
+	defer ticker.Stop()
+
+	width, height := 0, 0
+	redraw := true
+	for !m.Quit() {
+		w, h, err := term.GetSize(outFd)
+		if err != nil {
+			return fmt.Errorf("failed to get terminal size: %w", err)
+		}
+		if redraw || w != width || h != height {
+			width, height = w, h
+			draw(out, m.View(width, height))
+		}
+
+		select {
+		case batch := <-keys:
+			for _, k := range batch {
+				if m.Quit() {
+					break
+				}
+				m.HandleKey(k)
+			}
+			redraw = true
+		case err := <-errs:
+			return err
+		case <-ticker.C:
+			// Check for a resize.
+			redraw = false
+		}
+	}
+	return nil
+}
+
+// readKeys sends the keys read from in until reading fails.
+func readKeys(in io.Reader, keys chan<- []Key, errs chan<- error) {
+	buf := make([]byte, 256)
+	for {
+		n, err := in.Read(buf)
+		if n > 0 {
+			keys <- ParseKeys(buf[:n])
+		}
+		if err != nil {
+			errs <- err
+			return
+		}
+	}
+}
+
+// draw replaces the screen contents with lines.
+func draw(out io.Writer, lines []string) {
+	var b strings.Builder
+	b.WriteString("\x1b[H")
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString("\r\n")
+		}
+		b.WriteString(line)
+		b.WriteString(styleReset)
+	}
+	fmt.Fprint(out, b.String())
+}
@@ -0,0 +1,450 @@
+// Package tui is a full-screen terminal editor for PRDs.
+//
+// The screen shows a tree of PRD sections and their entities on the left,
+// an editor for the selected entity on the right, and a score and
+// validation panel that is recomputed after every change. Entities are
+// created, edited and deleted through the generic operations in
+// pkg/prd (see prd.EntityKind), so the editor covers every entity kind the
+// package defines.
+//
+// Model holds the editor state and is driven by key presses; Run connects
+// it to a terminal.
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/agent-team-prd/pkg/scoring"
+)
+
+type focus int
+
+const (
+	focusTree focus = iota
+	focusForm
+	focusInput
+	focusConfirm
+)
+
+// row is a line of the section tree: a section header, or an entity when
+// id is set.
+type row struct {
+	kind  *prd.EntityKind
+	id    string
+	label string
+}
+
+// Model is the state of the editor.
+type Model struct {
+	PRD  *prd.PRD
+	Path string
+
+	// Save persists the PRD. It is called when the user saves, and when
+	// they choose to save before quitting.
+	Save func() error
+
+	rows   []row
+	cursor int
+	top    int
+
+	focus focus
+	field int
+	input []rune
+
+	// draft holds the values of a new entity until its required fields
+	// are filled in and it can be created.
+	draft     map[string]string
+	draftKind *prd.EntityKind
+
+	prompt    string
+	onConfirm func(answer rune)
+	returnTo  focus
+
+	dirty   bool
+	message string
+	isError bool
+	quit    bool
+
+	score      *scoring.ScoringResult
+	validation *prd.ValidationResult
+}
+
+// New returns an editor for p, which was loaded from path.
+func New(p *prd.PRD, path string, save func() error) *Model {
+	m := &Model{PRD: p, Path: path, Save: save}
+	m.refresh()
+	return m
+}
+
+// Quit reports whether the user has quit.
+func (m *Model) Quit() bool {
+	return m.quit
+}
+
+// Dirty reports whether there are unsaved changes.
+func (m *Model) Dirty() bool {
+	return m.dirty
+}
+
+// Score returns the current scoring result.
+func (m *Model) Score() *scoring.ScoringResult {
+	return m.score
+}
+
+// Validation returns the current validation result.
+func (m *Model) Validation() *prd.ValidationResult {
+	return m.validation
+}
+
+// refresh rebuilds the section tree, keeping the cursor on the same row
+// where possible, and rescores the PRD.
+func (m *Model) refresh() {
+	var current row
+	if m.cursor < len(m.rows) {
+		current = m.rows[m.cursor]
+	}
+
+	m.rows = m.rows[:0]
+	for _, k := range prd.EntityKinds() {
+		m.rows = append(m.rows, row{kind: k, label: k.Title})
+		for _, e := range k.List(m.PRD) {
+			m.rows = append(m.rows, row{kind: k, id: e.ID, label: e.Label})
+		}
+	}
+	if current.kind != nil {
+		m.selectRow(current.kind, current.id)
+	}
+	if m.cursor >= len(m.rows) {
+		m.cursor = len(m.rows) - 1
+	}
+
+	m.score = scoring.Score(m.PRD)
+	m.validation = prd.Validate(m.PRD)
+}
+
+// selectRow moves the cursor to an entity, or to its section header if
+// the entity no longer exists.
+func (m *Model) selectRow(kind *prd.EntityKind, id string) {
+	header := -1
+	for i, r := range m.rows {
+		if r.kind != kind {
+			continue
+		}
+		if r.id == id {
+			m.cursor = i
+			return
+		}
+		if header < 0 {
+			header = i
+		}
+	}
+	if header >= 0 {
+		m.cursor = header
+	}
+}
+
+// selected returns the row under the cursor.
+func (m *Model) selected() row {
+	return m.rows[m.cursor]
+}
+
+// values returns the field values shown in the editor: the draft, or the
+// selected entity's values. It returns nil on a section header.
+func (m *Model) values() map[string]string {
+	if m.draft != nil {
+		return m.draft
+	}
+	r := m.selected()
+	if r.id == "" {
+		return nil
+	}
+	e, err := r.kind.Get(m.PRD, r.id)
+	if err != nil {
+		return nil
+	}
+	return e.Values
+}
+
+// editing returns the kind shown in the editor.
+func (m *Model) editing() *prd.EntityKind {
+	if m.draftKind != nil {
+		return m.draftKind
+	}
+	return m.selected().kind
+}
+
+func (m *Model) setMessage(format string, args ...interface{}) {
+	m.message = fmt.Sprintf(format, args...)
+	m.isError = false
+}
+
+func (m *Model) setError(err error) {
+	m.message = err.Error()
+	m.isError = true
+}
+
+// HandleKey updates the editor for a key press.
+func (m *Model) HandleKey(k Key) {
+	if k.Code == KeyCtrlC {
+		// A second Ctrl-C at the save prompt quits without saving.
+		if m.focus == focusConfirm {
+			m.quit = true
+			return
+		}
+		m.requestQuit()
+		return
+	}
+	if m.focus != focusConfirm && m.focus != focusInput {
+		m.message = ""
+	}
+
+	switch m.focus {
+	case focusTree:
+		m.handleTree(k)
+	case focusForm:
+		m.handleForm(k)
+	case focusInput:
+		m.handleInput(k)
+	case focusConfirm:
+		m.handleConfirm(k)
+	}
+}
+
+func (m *Model) handleTree(k Key) {
+	switch {
+	case k.Code == KeyUp || k.Rune == 'k':
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case k.Code == KeyDown || k.Rune == 'j':
+		if m.cursor < len(m.rows)-1 {
+			m.cursor++
+		}
+	case k.Code == KeyEnter || k.Code == KeyRight || k.Code == KeyTab || k.Rune == 'l':
+		if m.selected().id != "" {
+			m.focus, m.field = focusForm, 0
+		} else if m.cursor+1 < len(m.rows) && m.rows[m.cursor+1].kind == m.selected().kind {
+			m.cursor++
+			m.focus, m.field = focusForm, 0
+		} else {
+			m.newEntity()
+		}
+	case k.Rune == 'n' || k.Rune == 'a':
+		m.newEntity()
+	case k.Code == KeyDelete || k.Rune == 'd':
+		m.confirmDelete()
+	case k.Code == KeyCtrlS || k.Rune == 's':
+		m.save()
+	case k.Code == KeyEsc || k.Rune == 'q':
+		m.requestQuit()
+	}
+}
+
+func (m *Model) handleForm(k Key) {
+	kind := m.editing()
+	switch {
+	case k.Code == KeyUp || k.Rune == 'k':
+		if m.field > 0 {
+			m.field--
+		}
+	case k.Code == KeyDown || k.Rune == 'j' || k.Code == KeyTab:
+		m.field = (m.field + 1) % len(kind.Fields)
+	case k.Code == KeyEnter || k.Rune == 'e':
+		m.input = []rune(m.values()[kind.Fields[m.field].Name])
+		m.focus = focusInput
+	case k.Code == KeyLeft || k.Code == KeyRight || k.Rune == ' ':
+		m.cycleChoice(k.Code == KeyLeft)
+	case k.Code == KeyDelete || k.Rune == 'd':
+		if m.draft == nil {
+			m.confirmDelete()
+		}
+	case k.Code == KeyCtrlS || k.Rune == 's':
+		m.save()
+	case k.Code == KeyEsc || k.Rune == 'q' || k.Rune == 'h':
+		m.leaveForm()
+	}
+}
+
+func (m *Model) handleInput(k Key) {
+	switch k.Code {
+	case KeyRune:
+		m.input = append(m.input, k.Rune)
+	case KeyBackspace:
+		if len(m.input) > 0 {
+			m.input = m.input[:len(m.input)-1]
+		}
+	case KeyCtrlU:
+		m.input = m.input[:0]
+	case KeyEnter:
+		m.focus = focusForm
+		m.commit(m.editing().Fields[m.field].Name, string(m.input))
+	case KeyEsc:
+		m.focus = focusForm
+	}
+}
+
+func (m *Model) handleConfirm(k Key) {
+	answer := k.Rune
+	if k.Code == KeyEsc {
+		answer = 0
+	}
+	m.focus = m.returnTo
+	m.prompt = ""
+	m.onConfirm(answer)
+}
+
+// cycleChoice sets a choice field to its next or previous choice.
+func (m *Model) cycleChoice(back bool) {
+	f := m.editing().Fields[m.field]
+	if len(f.Choices) == 0 {
+		if back {
+			m.leaveForm()
+		}
+		return
+	}
+	i := indexOf(f.Choices, m.values()[f.Name])
+	switch {
+	case i < 0:
+		i = 0
+	case back:
+		i = (i + len(f.Choices) - 1) % len(f.Choices)
+	default:
+		i = (i + 1) % len(f.Choices)
+	}
+	m.commit(f.Name, f.Choices[i])
+}
+
+// commit applies a field value to the draft or the selected entity.
+func (m *Model) commit(name, value string) {
+	value = strings.TrimSpace(value)
+	if m.draft != nil {
+		m.draft[name] = value
+		m.createDraft()
+		return
+	}
+
+	r := m.selected()
+	if err := r.kind.Update(m.PRD, r.id, map[string]string{name: value}); err != nil {
+		m.setError(err)
+		return
+	}
+	m.dirty = true
+	m.refresh()
+}
+
+// newEntity starts a draft for the selected section.
+func (m *Model) newEntity() {
+	kind := m.selected().kind
+	if kind.Singleton && len(kind.List(m.PRD)) > 0 {
+		m.setError(fmt.Errorf("%s already exists", strings.ToLower(kind.Title)))
+		return
+	}
+	m.selectRow(kind, "")
+	m.draft = make(map[string]string)
+	m.draftKind = kind
+	m.focus, m.field = focusForm, 0
+	m.setMessage("New %s: fill in the required fields", strings.ToLower(kind.Title))
+}
+
+// createDraft creates the draft entity once its required fields are set.
+func (m *Model) createDraft() {
+	kind := m.draftKind
+	if missing := missingFields(kind, m.draft); len(missing) > 0 {
+		return
+	}
+	id, err := kind.Create(m.PRD, m.draft)
+	if err != nil {
+		m.setError(err)
+		return
+	}
+	m.draft, m.draftKind = nil, nil
+	m.dirty = true
+	m.refresh()
+	m.selectRow(kind, id)
+	m.setMessage("Created %s", id)
+}
+
+// leaveForm returns to the tree, discarding an incomplete draft.
+func (m *Model) leaveForm() {
+	if m.draft != nil {
+		missing := missingFields(m.draftKind, m.draft)
+		m.setMessage("Discarded new %s (missing %s)", strings.ToLower(m.draftKind.Title), strings.Join(missing, ", "))
+		m.draft, m.draftKind = nil, nil
+	}
+	m.focus = focusTree
+}
+
+func (m *Model) confirmDelete() {
+	r := m.selected()
+	if r.id == "" {
+		return
+	}
+	m.ask(fmt.Sprintf("Delete %s %q? (y/n)", r.id, truncate(r.label, 40)), func(answer rune) {
+		if answer != 'y' && answer != 'Y' {
+			return
+		}
+		if err := r.kind.Delete(m.PRD, r.id); err != nil {
+			m.setError(err)
+			return
+		}
+		m.dirty = true
+		m.focus = focusTree
+		m.refresh()
+		m.selectRow(r.kind, "")
+		m.setMessage("Deleted %s", r.id)
+	})
+}
+
+func (m *Model) save() {
+	if err := m.Save(); err != nil {
+		m.setError(fmt.Errorf("save failed: %w", err))
+		return
+	}
+	m.dirty = false
+	m.setMessage("Saved %s", m.Path)
+}
+
+func (m *Model) requestQuit() {
+	if !m.dirty {
+		m.quit = true
+		return
+	}
+	m.ask("Save changes before quitting? (y/n, Esc to cancel)", func(answer rune) {
+		switch answer {
+		case 'y', 'Y':
+			m.save()
+			m.quit = !m.dirty
+		case 'n', 'N':
+			m.quit = true
+		}
+	})
+}
+
+func (m *Model) ask(prompt string, onConfirm func(answer rune)) {
+	m.prompt = prompt
+	m.onConfirm = onConfirm
+	m.returnTo = m.focus
+	m.focus = focusConfirm
+}
+
+// missingFields returns the labels of required fields without a value.
+func missingFields(kind *prd.EntityKind, values map[string]string) []string {
+	var missing []string
+	for _, f := range kind.Fields {
+		if f.Required && strings.TrimSpace(values[f.Name]) == "" {
+			missing = append(missing, f.Label)
+		}
+	}
+	return missing
+}
+
+func indexOf(values []string, v string) int {
+	for i, s := range values {
+		if s == v {
+			return i
+		}
+	}
+	return -1
+}
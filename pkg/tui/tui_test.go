@@ -0,0 +1,233 @@
+package tui
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+)
+
+func newTestModel(t *testing.T) (*Model, *int) {
+	t.Helper()
+	p := prd.New("PRD-2026-001", "Test PRD", prd.Person{Name: "Owner"})
+	saves := 0
+	m := New(p, "PRD.json", func() error {
+		saves++
+		return nil
+	})
+	return m, &saves
+}
+
+// press feeds raw terminal input to the model.
+func press(m *Model, input string) {
+	for _, k := range ParseKeys([]byte(input)) {
+		m.HandleKey(k)
+	}
+}
+
+func selectSection(t *testing.T, m *Model, name string) *prd.EntityKind {
+	t.Helper()
+	kind, ok := prd.LookupEntityKind(name)
+	if !ok {
+		t.Fatalf("unknown kind %s", name)
+	}
+	m.selectRow(kind, "")
+	return kind
+}
+
+func TestParseKeys(t *testing.T) {
+	keys := ParseKeys([]byte("a\x1b[A\x1b[B\x1b[C\x1b[D\r\x7f\x1b[3~\x13é\x1b"))
+	want := []Key{
+		{Code: KeyRune, Rune: 'a'},
+		{Code: KeyUp},
+		{Code: KeyDown},
+		{Code: KeyRight},
+		{Code: KeyLeft},
+		{Code: KeyEnter},
+		{Code: KeyBackspace},
+		{Code: KeyDelete},
+		{Code: KeyCtrlS},
+		{Code: KeyRune, Rune: 'é'},
+		{Code: KeyEsc},
+	}
+	if len(keys) != len(want) {
+		t.Fatalf("expected %d keys, got %d: %+v", len(want), len(keys), keys)
+	}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Errorf("key %d = %+v, want %+v", i, keys[i], want[i])
+		}
+	}
+
+	// Unknown sequences, such as F1, are dropped.
+	if keys := ParseKeys([]byte("\x1bOP")); len(keys) != 0 {
+		t.Errorf("expected unknown sequence to be dropped, got %+v", keys)
+	}
+}
+
+func TestCreateEntity(t *testing.T) {
+	m, _ := newTestModel(t)
+	selectSection(t, m, "personas")
+
+	press(m, "n")
+	if m.draft == nil {
+		t.Fatal("expected a draft after n")
+	}
+	press(m, "\rDan\r")
+	if len(m.PRD.Personas) != 1 || m.PRD.Personas[0].Name != "Dan" {
+		t.Fatalf("expected persona Dan to be created, got %+v", m.PRD.Personas)
+	}
+	if m.draft != nil {
+		t.Error("expected draft to be cleared after creation")
+	}
+	if !m.Dirty() {
+		t.Error("expected model to be dirty")
+	}
+	if got := m.selected().id; got != "PER-1" {
+		t.Errorf("expected cursor on PER-1, got %q", got)
+	}
+
+	// Further edits update the created persona.
+	press(m, "j\rAdmin\r")
+	if m.PRD.Personas[0].Role != "Admin" {
+		t.Errorf("expected role Admin, got %q", m.PRD.Personas[0].Role)
+	}
+}
+
+func TestDiscardDraft(t *testing.T) {
+	m, _ := newTestModel(t)
+	selectSection(t, m, "risks")
+
+	press(m, "n\x1b")
+	if len(m.PRD.Risks) != 0 {
+		t.Errorf("expected no risk to be created, got %d", len(m.PRD.Risks))
+	}
+	if m.focus != focusTree || m.draft != nil {
+		t.Error("expected to return to the tree without a draft")
+	}
+	if !strings.Contains(m.message, "Discarded") {
+		t.Errorf("expected discard message, got %q", m.message)
+	}
+}
+
+func TestEditChoice(t *testing.T) {
+	m, _ := newTestModel(t)
+	id := prd.AddRisk(m.PRD, "Outage", prd.RiskProbabilityLow, prd.RiskImpactLow, "")
+	m.refresh()
+	m.selectRow(selectSection(t, m, "risks"), id)
+
+	// Description, probability, impact: move to impact and cycle forward.
+	press(m, "\r\x1b[B\x1b[B\x1b[C")
+	if m.PRD.Risks[0].Impact != prd.RiskImpactMedium {
+		t.Errorf("expected impact medium, got %s", m.PRD.Risks[0].Impact)
+	}
+	press(m, "\x1b[D\x1b[D")
+	if m.PRD.Risks[0].Impact != prd.RiskImpactCritical {
+		t.Errorf("expected impact to wrap to critical, got %s", m.PRD.Risks[0].Impact)
+	}
+}
+
+func TestEditInvalidValue(t *testing.T) {
+	m, _ := newTestModel(t)
+	prd.SetProblemStatement(m.PRD, "Slow builds", "", 0.5)
+	m.refresh()
+	kind := selectSection(t, m, "problem")
+	m.selectRow(kind, m.PRD.Problem.ID)
+
+	// Clear the required statement.
+	press(m, "\r\r\x15\r")
+	if m.PRD.Problem.Statement != "Slow builds" {
+		t.Errorf("expected statement to be unchanged, got %q", m.PRD.Problem.Statement)
+	}
+	if !m.isError {
+		t.Error("expected an error message")
+	}
+}
+
+func TestDeleteEntity(t *testing.T) {
+	m, _ := newTestModel(t)
+	prd.AddOutOfScope(m.PRD, "Mobile")
+	m.refresh()
+	m.selectRow(selectSection(t, m, "nongoals"), "1")
+
+	press(m, "dn")
+	if len(m.PRD.OutOfScope) != 1 {
+		t.Fatal("expected delete to be cancelled")
+	}
+	press(m, "dy")
+	if len(m.PRD.OutOfScope) != 0 {
+		t.Errorf("expected non-goal to be deleted, got %v", m.PRD.OutOfScope)
+	}
+	if m.selected().id != "" {
+		t.Error("expected cursor on the section header after delete")
+	}
+}
+
+func TestSaveAndQuit(t *testing.T) {
+	m, saves := newTestModel(t)
+	selectSection(t, m, "nongoals")
+	press(m, "n\rMobile\r\x1b")
+
+	press(m, "q")
+	if m.Quit() {
+		t.Fatal("expected a save prompt before quitting with changes")
+	}
+	press(m, "\x1b")
+	if m.Quit() || m.focus != focusTree {
+		t.Fatal("expected Esc to cancel quitting")
+	}
+
+	press(m, "qy")
+	if !m.Quit() {
+		t.Error("expected to quit after saving")
+	}
+	if *saves != 1 || m.Dirty() {
+		t.Errorf("expected one save and a clean model, got %d saves", *saves)
+	}
+}
+
+func TestSaveError(t *testing.T) {
+	m, _ := newTestModel(t)
+	m.Save = func() error { return errors.New("disk full") }
+	m.dirty = true
+
+	press(m, "qy")
+	if m.Quit() {
+		t.Error("expected not to quit when saving fails")
+	}
+	if !m.isError || !strings.Contains(m.message, "disk full") {
+		t.Errorf("expected save error message, got %q", m.message)
+	}
+}
+
+var ansi = regexp.MustCompile("\x1b\\[[0-9;?]*[a-zA-Z]")
+
+func TestView(t *testing.T) {
+	m, _ := newTestModel(t)
+	prd.AddPersona(m.PRD, "Developer Dan", "Backend Developer", nil)
+	m.refresh()
+	m.selectRow(selectSection(t, m, "personas"), "PER-1")
+	press(m, "\r")
+
+	for _, size := range [][2]int{{100, 30}, {60, 16}, {40, 10}} {
+		lines := m.View(size[0], size[1])
+		if len(lines) != size[1] {
+			t.Fatalf("%dx%d: expected %d lines, got %d", size[0], size[1], size[1], len(lines))
+		}
+		for i, line := range lines {
+			if n := utf8.RuneCountInString(ansi.ReplaceAllString(line, "")); n != size[0] {
+				t.Errorf("%dx%d: line %d is %d columns: %q", size[0], size[1], i, n, line)
+			}
+		}
+	}
+
+	screen := ansi.ReplaceAllString(strings.Join(m.View(100, 30), "\n"), "")
+	for _, want := range []string{"Test PRD", "Personas (1)", "PER-1 Developer Dan", "Backend Developer", "Score"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("expected screen to contain %q", want)
+		}
+	}
+}
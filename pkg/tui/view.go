@@ -0,0 +1,322 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/agentplexus/agent-team-prd/pkg/scoring"
+)
+
+// ANSI styles.
+const (
+	styleReset   = "\x1b[0m"
+	styleBold    = "\x1b[1m"
+	styleDim     = "\x1b[2m"
+	styleReverse = "\x1b[7m"
+	styleRed     = "\x1b[31m"
+	styleGreen   = "\x1b[32m"
+	styleYellow  = "\x1b[33m"
+)
+
+// Layout limits.
+const (
+	minWidth    = 60
+	minHeight   = 16
+	panelHeight = 6
+	treeWidth   = 34
+)
+
+// View renders the editor as height lines of width columns.
+func (m *Model) View(width, height int) []string {
+	if width < minWidth || height < minHeight {
+		lines := make([]string, height)
+		for i := range lines {
+			lines[i] = strings.Repeat(" ", width)
+		}
+		if height > 0 {
+			lines[0] = fit(fmt.Sprintf("Terminal too small (need %dx%d)", minWidth, minHeight), width)
+		}
+		return lines
+	}
+
+	lines := make([]string, 0, height)
+	lines = append(lines, m.titleBar(width))
+
+	bodyHeight := height - panelHeight - 3
+	leftWidth := treeWidth
+	if leftWidth > width/2 {
+		leftWidth = width / 2
+	}
+	rightWidth := width - leftWidth - 1
+
+	tree := m.tree(leftWidth, bodyHeight)
+	editor := m.editor(rightWidth, bodyHeight)
+	for i := 0; i < bodyHeight; i++ {
+		lines = append(lines, tree[i]+styled(styleDim, "│")+editor[i])
+	}
+
+	lines = append(lines, m.panel(width)...)
+	lines = append(lines, m.statusLine(width), m.helpLine(width))
+	return lines
+}
+
+func (m *Model) titleBar(width int) string {
+	title := " " + m.PRD.Metadata.Title + "  " + m.Path
+	if m.dirty {
+		title += "  [modified]"
+	}
+	return styled(styleReverse, fit(title, width))
+}
+
+// tree renders the section tree, scrolled to keep the cursor visible.
+func (m *Model) tree(width, height int) []string {
+	if m.cursor < m.top {
+		m.top = m.cursor
+	}
+	if m.cursor >= m.top+height {
+		m.top = m.cursor - height + 1
+	}
+
+	lines := make([]string, height)
+	for i := range lines {
+		n := m.top + i
+		if n >= len(m.rows) {
+			lines[i] = strings.Repeat(" ", width)
+			continue
+		}
+		r := m.rows[n]
+
+		var text, style string
+		if r.id == "" {
+			text = fmt.Sprintf(" %s (%d)", r.kind.Title, len(r.kind.List(m.PRD)))
+			style = styleBold
+		} else {
+			text = fmt.Sprintf("   %s %s", r.id, r.label)
+		}
+		if n == m.cursor && m.draft == nil {
+			style = styleReverse
+			if m.focus != focusTree {
+				style = styleDim + styleReverse
+			}
+		}
+		lines[i] = styled(style, fit(text, width))
+	}
+	return lines
+}
+
+// editor renders the form for the selected entity or draft, or a summary
+// of the selected section.
+func (m *Model) editor(width, height int) []string {
+	var lines []string
+	add := func(style, text string) {
+		lines = append(lines, styled(style, fit(text, width)))
+	}
+
+	kind := m.editing()
+	values := m.values()
+	switch {
+	case values == nil:
+		entities := kind.List(m.PRD)
+		add(styleBold, " "+kind.Title)
+		add("", "")
+		if len(entities) == 0 {
+			add(styleDim, " No entries. Press n to add one.")
+		}
+		for _, e := range entities {
+			add("", fmt.Sprintf(" %s  %s", e.ID, e.Label))
+		}
+	default:
+		heading := " New " + strings.ToLower(kind.Title)
+		if m.draft == nil {
+			heading = " " + kind.Title + " › " + m.selected().id
+		}
+		add(styleBold, heading)
+		add("", "")
+
+		labelWidth := 0
+		for _, f := range kind.Fields {
+			if n := utf8.RuneCountInString(f.Label); n > labelWidth {
+				labelWidth = n
+			}
+		}
+		for i, f := range kind.Fields {
+			label := f.Label
+			if f.Required {
+				label += "*"
+			}
+			value := values[f.Name]
+			hint := ""
+			active := m.focus != focusTree && i == m.field
+			switch {
+			case active && m.focus == focusInput:
+				value = string(m.input) + "█"
+				if f.List {
+					hint = "  comma-separated"
+				}
+			case active && len(f.Choices) > 0:
+				hint = "  ←/→ " + strings.Join(f.Choices, "|")
+			}
+			text := fmt.Sprintf(" %-*s  %s", labelWidth+1, label, value)
+			if n := utf8.RuneCountInString(text); active && m.focus == focusInput && n > width {
+				// Keep the end of a long value, where the user is typing,
+				// in view.
+				prefix := fmt.Sprintf(" %-*s  …", labelWidth+1, label)
+				text = prefix + string([]rune(text)[n-width+utf8.RuneCountInString(prefix):])
+			}
+			style := ""
+			if active {
+				style = styleReverse
+			}
+			if n := utf8.RuneCountInString(text); hint != "" && n+utf8.RuneCountInString(hint) <= width {
+				lines = append(lines, styled(style, text)+styled(styleDim, fit(hint, width-n)))
+				continue
+			}
+			add(style, text)
+		}
+	}
+
+	for len(lines) < height {
+		add("", "")
+	}
+	return lines[:height]
+}
+
+// panel renders the live score and validation results.
+func (m *Model) panel(width int) []string {
+	lines := []string{styled(styleDim, strings.Repeat("─", width))}
+
+	score := m.score.WeightedScore
+	scoreStyle := styleRed
+	switch {
+	case score >= scoring.ThresholdApprove:
+		scoreStyle = styleGreen
+	case score >= scoring.ThresholdRevise:
+		scoreStyle = styleYellow
+	}
+	summary := fmt.Sprintf(" Score %.1f/10  %s", score, strings.ToUpper(m.score.Decision))
+	validity := "  Valid"
+	validityStyle := styleGreen
+	if n := len(m.validation.Errors); n > 0 {
+		validity = fmt.Sprintf("  %d error(s), %d warning(s)", n, len(m.validation.Warnings))
+		validityStyle = styleRed
+	} else if n := len(m.validation.Warnings); n > 0 {
+		validity = fmt.Sprintf("  Valid, %d warning(s)", n)
+		validityStyle = styleYellow
+	}
+	rest := width - utf8.RuneCountInString(summary)
+	if rest < 0 {
+		rest = 0
+	}
+	lines = append(lines, styled(styleBold+scoreStyle, fit(summary, utf8.RuneCountInString(summary)))+styled(validityStyle, fit(validity, rest)))
+
+	// Category scores, wrapped over two lines.
+	var chips []string
+	for _, cs := range m.score.CategoryScores {
+		chips = append(chips, fmt.Sprintf("%s %.1f", cs.Category, cs.Score))
+	}
+	lines = append(lines, wrap(chips, "  ", width-1, 2)...)
+
+	// Validation messages fill the remaining lines.
+	type issue struct{ style, text string }
+	var issues []issue
+	for _, e := range m.validation.Errors {
+		issues = append(issues, issue{styleRed, "✗ " + e.Field + ": " + e.Message})
+	}
+	for _, w := range m.validation.Warnings {
+		issues = append(issues, issue{styleYellow, "! " + w.Field + ": " + w.Message})
+	}
+	if room := panelHeight - len(lines); len(issues) > room {
+		issues = append(issues[:room-1], issue{styleDim, fmt.Sprintf("… %d more", len(issues)-room+1)})
+	}
+	for _, is := range issues {
+		lines = append(lines, styled(is.style, fit(" "+is.text, width)))
+	}
+	for len(lines) < panelHeight {
+		lines = append(lines, strings.Repeat(" ", width))
+	}
+	return lines
+}
+
+func (m *Model) statusLine(width int) string {
+	switch {
+	case m.focus == focusConfirm:
+		return styled(styleBold+styleYellow, fit(" "+m.prompt, width))
+	case m.isError:
+		return styled(styleRed, fit(" "+m.message, width))
+	default:
+		return fit(" "+m.message, width)
+	}
+}
+
+func (m *Model) helpLine(width int) string {
+	var help string
+	switch m.focus {
+	case focusTree:
+		help = "↑/↓ move  Enter edit  n new  d delete  s save  q quit"
+	case focusForm:
+		help = "↑/↓ field  Enter edit  ←/→ choice  d delete  s save  Esc back"
+	case focusInput:
+		help = "Enter apply  Esc cancel  Ctrl-U clear"
+	case focusConfirm:
+		help = "y yes  n no  Esc cancel"
+	}
+	return styled(styleDim, fit(" "+help, width))
+}
+
+// wrap joins items with sep into at most maxLines lines of width columns,
+// each indented by one space and padded to width+1.
+func wrap(items []string, sep string, width, maxLines int) []string {
+	var lines []string
+	line := ""
+	for _, item := range items {
+		candidate := item
+		if line != "" {
+			candidate = line + sep + item
+		}
+		if utf8.RuneCountInString(candidate) > width && line != "" {
+			lines = append(lines, line)
+			line = item
+			continue
+		}
+		line = candidate
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+	}
+	for i, l := range lines {
+		lines[i] = fit(" "+l, width+1)
+	}
+	return lines
+}
+
+func styled(style, s string) string {
+	if style == "" {
+		return s
+	}
+	return style + s + styleReset
+}
+
+// fit truncates or pads s to exactly width columns.
+func fit(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	n := utf8.RuneCountInString(s)
+	if n > width {
+		return truncate(s, width)
+	}
+	return s + strings.Repeat(" ", width-n)
+}
+
+// truncate shortens s to at most width runes, ending in an ellipsis.
+func truncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:width-1]) + "…"
+}
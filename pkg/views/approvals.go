@@ -0,0 +1,54 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+)
+
+// RenderApprovalsMarkdown generates a markdown section listing where each
+// approver stands on the PRD's current content (see prd.ApprovalStatus).
+func RenderApprovalsMarkdown(statuses []prd.ApproverStatus) string {
+	if len(statuses) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Approvals\n\n")
+
+	var pending []string
+	for _, s := range statuses {
+		name := s.Name
+		if s.Role != "" {
+			name += " (" + s.Role + ")"
+		}
+		if s.State != prd.ApprovalApproved {
+			pending = append(pending, s.Name)
+		}
+
+		fmt.Fprintf(&sb, "- **%s**: %s", name, s.State)
+		if s.SignOff != nil {
+			if s.State == prd.ApprovalStale {
+				fmt.Fprintf(&sb, ", %s", s.SignOff.Decision)
+			}
+			fmt.Fprintf(&sb, " %s", s.SignOff.SignedAt.Format("2006-01-02"))
+			if s.SignOff.Version != "" {
+				fmt.Fprintf(&sb, " on v%s", s.SignOff.Version)
+			}
+			if s.SignOff.Comment != "" {
+				fmt.Fprintf(&sb, " - %s", s.SignOff.Comment)
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(pending) > 0 {
+		fmt.Fprintf(&sb, "\n**Pending approvals:** %s\n", strings.Join(pending, ", "))
+	} else {
+		sb.WriteString("\nAll approvers have signed off on the current content.\n")
+	}
+
+	sb.WriteString("\n")
+	return sb.String()
+}
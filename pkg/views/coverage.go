@@ -0,0 +1,46 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agentplexus/agent-team-prd/pkg/coverage"
+)
+
+// RenderCoverageMarkdown generates a markdown section for a test coverage report.
+func RenderCoverageMarkdown(report *coverage.Report) string {
+	if report == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Test Coverage\n\n")
+	fmt.Fprintf(&sb, "- Requirements: %d/%d covered (%.0f%%)\n",
+		report.Summary.CoveredRequirements, report.Summary.TotalRequirements,
+		report.Summary.RequirementPercent())
+	if report.Summary.TotalCriteria > 0 {
+		fmt.Fprintf(&sb, "- Acceptance criteria: %d/%d covered (%.0f%%)\n",
+			report.Summary.CoveredCriteria, report.Summary.TotalCriteria,
+			report.Summary.CriteriaPercent())
+	}
+	if !report.GeneratedAt.IsZero() {
+		fmt.Fprintf(&sb, "- Last scanned: %s\n", report.GeneratedAt.Format("2006-01-02"))
+	}
+
+	if uncovered := report.UncoveredRequirements(); len(uncovered) > 0 {
+		sb.WriteString("\n**Requirements without tests:**\n\n")
+		for _, req := range uncovered {
+			fmt.Fprintf(&sb, "- %s (%s) %s\n", req.ID, req.Priority, req.Title)
+		}
+	}
+
+	if uncovered := report.UncoveredCriteria(); len(uncovered) > 0 {
+		sb.WriteString("\n**Acceptance criteria without tests:**\n\n")
+		for _, c := range uncovered {
+			fmt.Fprintf(&sb, "- %s (%s) %s\n", c.ID, c.ParentID, c.Description)
+		}
+	}
+
+	sb.WriteString("\n")
+	return sb.String()
+}
@@ -0,0 +1,372 @@
+package views
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agentplexus/agent-team-prd/pkg/mermaid"
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+)
+
+// Diagrams holds Mermaid diagram sources generated from a PRD.
+// Empty fields mean the PRD has no content for that diagram.
+type Diagrams struct {
+	Roadmap    string        `json:"roadmap,omitempty"`
+	Flows      []FlowDiagram `json:"flows,omitempty"`
+	OKRTree    string        `json:"okrTree,omitempty"`
+	RiskMatrix string        `json:"riskMatrix,omitempty"`
+}
+
+// FlowDiagram is a Mermaid flowchart for one interaction flow.
+type FlowDiagram struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Diagram string `json:"diagram"`
+}
+
+// IsEmpty reports whether no diagrams were generated.
+func (d *Diagrams) IsEmpty() bool {
+	return d.Roadmap == "" && len(d.Flows) == 0 && d.OKRTree == "" && d.RiskMatrix == ""
+}
+
+// GenerateDiagrams creates Mermaid diagrams for the roadmap, interaction
+// flows, OKR tree and risk matrix of a PRD.
+func GenerateDiagrams(p *prd.PRD) *Diagrams {
+	d := &Diagrams{
+		Roadmap:    RoadmapGantt(p),
+		OKRTree:    OKRTree(p),
+		RiskMatrix: RiskQuadrant(p),
+	}
+	if p.UXRequirements != nil {
+		for _, flow := range p.UXRequirements.InteractionFlows {
+			if diagram := InteractionFlowchart(flow); diagram != "" {
+				d.Flows = append(d.Flows, FlowDiagram{ID: flow.ID, Title: flow.Title, Diagram: diagram})
+			}
+		}
+	}
+	return d
+}
+
+// RoadmapGantt renders the roadmap phases as a Mermaid Gantt chart.
+// Phases without a start date are skipped; a missing end date is drawn as
+// a one-week bar.
+func RoadmapGantt(p *prd.PRD) string {
+	var sb strings.Builder
+	count := 0
+
+	for i, phase := range p.Roadmap.Phases {
+		var info struct {
+			ID        string `json:"id"`
+			Name      string `json:"name"`
+			StartDate string `json:"startDate"`
+			EndDate   string `json:"endDate"`
+		}
+		if err := roundTrip(phase, &info); err != nil {
+			continue
+		}
+		start, ok := parseDate(info.StartDate)
+		if !ok {
+			continue
+		}
+
+		if count == 0 {
+			sb.WriteString("gantt\n")
+			fmt.Fprintf(&sb, "  title %s Roadmap\n", ganttText(p.Metadata.Title))
+			sb.WriteString("  dateFormat YYYY-MM-DD\n")
+			sb.WriteString("  section Phases\n")
+		}
+		count++
+
+		name := info.Name
+		if name == "" {
+			name = info.ID
+		}
+		taskID := mermaid.ID(info.ID)
+		if taskID == "" {
+			taskID = fmt.Sprintf("phase%d", i+1)
+		}
+
+		duration := "7d"
+		if end, ok := parseDate(info.EndDate); ok && end.After(start) {
+			duration = end.Format("2006-01-02")
+		}
+		fmt.Fprintf(&sb, "  %s :%s, %s, %s\n", ganttText(name), taskID, start.Format("2006-01-02"), duration)
+	}
+
+	return sb.String()
+}
+
+// InteractionFlowchart renders an interaction flow's steps as a top-down
+// Mermaid flowchart. Returns an empty string for flows without steps.
+func InteractionFlowchart(flow prd.InteractionFlow) string {
+	steps := flowSteps(flow)
+	if len(steps) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("flowchart TD\n")
+	for i, step := range steps {
+		fmt.Fprintf(&sb, "  S%d[\"%s\"]\n", i+1, mermaid.Text(step))
+	}
+	for i := 1; i < len(steps); i++ {
+		fmt.Fprintf(&sb, "  S%d --> S%d\n", i, i+1)
+	}
+	return sb.String()
+}
+
+// OKRTree renders objectives, their key results, and the functional
+// requirements that reference them as a Mermaid flowchart.
+//
+// A requirement is linked to a key result or objective when its title or
+// description mentions that ID (for example "Supports KR-2"). The PRD has
+// no field for these links, so they are a heuristic, and the diagram says
+// so. Requirements that mention none are grouped as unlinked.
+func OKRTree(p *prd.PRD) string {
+	if len(p.Objectives.OKRs) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+	sb.WriteString("  %% Requirement links are inferred from ID mentions in requirement text\n")
+
+	linked := make(map[string]bool)
+	linkRequirements := func(parentID string) {
+		for _, fr := range p.Requirements.Functional {
+			if mentionsID(fr.Title+" "+fr.Description, parentID) {
+				fmt.Fprintf(&sb, "  %s --> %s[\"%s\"]\n", mermaid.ID(parentID), mermaid.ID(fr.ID),
+					mermaid.Text(fr.ID+": "+fr.Title))
+				linked[fr.ID] = true
+			}
+		}
+	}
+
+	for _, okr := range p.Objectives.OKRs {
+		obj := okr.Objective
+		fmt.Fprintf(&sb, "  %s([\"%s\"])\n", mermaid.ID(obj.ID), mermaid.Text(obj.ID+": "+obj.Title))
+
+		seen := make(map[string]bool)
+		krs := append(append([]prd.KeyResult{}, okr.KeyResults...), obj.KeyResults...)
+		for _, kr := range krs {
+			if seen[kr.ID] {
+				continue
+			}
+			seen[kr.ID] = true
+			label := kr.ID + ": " + kr.Title
+			if kr.Target != "" {
+				label += " (" + kr.Target + ")"
+			}
+			fmt.Fprintf(&sb, "  %s --> %s[\"%s\"]\n", mermaid.ID(obj.ID), mermaid.ID(kr.ID), mermaid.Text(label))
+			linkRequirements(kr.ID)
+		}
+		linkRequirements(obj.ID)
+	}
+
+	var unlinked []string
+	for _, fr := range p.Requirements.Functional {
+		if !linked[fr.ID] {
+			unlinked = append(unlinked, fr.ID)
+		}
+	}
+	if len(unlinked) > 0 {
+		sb.WriteString("  unlinked{{\"Requirements mentioning no objective or KR\"}}\n")
+		for _, id := range unlinked {
+			fmt.Fprintf(&sb, "  unlinked -.- %s\n", mermaid.ID(id))
+		}
+	}
+
+	return sb.String()
+}
+
+// RiskQuadrant renders the risks as a Mermaid quadrant chart of
+// probability (x) against impact (y).
+func RiskQuadrant(p *prd.PRD) string {
+	if len(p.Risks) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("quadrantChart\n")
+	sb.WriteString("  title Risk Matrix\n")
+	sb.WriteString("  x-axis Low Probability --> High Probability\n")
+	sb.WriteString("  y-axis Low Impact --> High Impact\n")
+	sb.WriteString("  quadrant-1 Mitigate first\n")
+	sb.WriteString("  quadrant-2 Contingency plan\n")
+	sb.WriteString("  quadrant-3 Accept\n")
+	sb.WriteString("  quadrant-4 Monitor\n")
+
+	// Offset risks that share a cell so their points don't overlap.
+	placed := make(map[string]int)
+	for _, r := range p.Risks {
+		x := riskLevel(string(r.Probability))
+		y := riskLevel(string(r.Impact))
+		cell := fmt.Sprintf("%.2f,%.2f", x, y)
+		offset := float64(placed[cell]) * 0.04
+		placed[cell]++
+
+		fmt.Fprintf(&sb, "  %s: [%.2f, %.2f]\n", mermaid.Words(r.ID), clamp(x+offset), clamp(y-offset))
+	}
+
+	return sb.String()
+}
+
+// RenderDiagramsMarkdown renders diagrams as Markdown with fenced mermaid
+// blocks, which GitHub renders natively.
+func RenderDiagramsMarkdown(d *Diagrams) string {
+	var sb strings.Builder
+
+	writeBlock := func(heading, diagram string) {
+		fmt.Fprintf(&sb, "### %s\n\n```mermaid\n%s```\n\n", heading, diagram)
+	}
+
+	if d.Roadmap != "" {
+		writeBlock("Roadmap", d.Roadmap)
+	}
+	for _, f := range d.Flows {
+		writeBlock("Flow: "+f.Title, f.Diagram)
+	}
+	if d.OKRTree != "" {
+		fmt.Fprintf(&sb, "### Objectives and Key Results\n\n")
+		sb.WriteString("_Requirements are linked to the objectives and key results whose IDs they mention " +
+			"(e.g. \"Supports KR-2\"). These links are inferred from the text, not declared in the PRD._\n\n")
+		fmt.Fprintf(&sb, "```mermaid\n%s```\n\n", d.OKRTree)
+	}
+	if d.RiskMatrix != "" {
+		writeBlock("Risk Matrix", d.RiskMatrix)
+	}
+
+	return sb.String()
+}
+
+// RenderDiagramsViewMarkdown renders the diagrams view of a PRD as every
+// frontend shows it, with a note when there is nothing to draw.
+func RenderDiagramsViewMarkdown(p *prd.PRD) string {
+	d := GenerateDiagrams(p)
+	if d.IsEmpty() {
+		return "No diagrams: the PRD has no dated roadmap phases, interaction flows, objectives or risks\n"
+	}
+	return fmt.Sprintf("# %s Diagrams\n\n", p.Metadata.Title) + RenderDiagramsMarkdown(d)
+}
+
+// RenderPMMarkdownWithDiagrams renders the PM view followed by a Diagrams
+// section. The diagrams section is omitted when there is nothing to draw.
+func RenderPMMarkdownWithDiagrams(view *PMView, d *Diagrams) string {
+	out := RenderPMMarkdown(view)
+	if d == nil || d.IsEmpty() {
+		return out
+	}
+	if !strings.HasSuffix(out, "\n") {
+		out += "\n"
+	}
+	return out + "\n## Diagrams\n\n" + RenderDiagramsMarkdown(d)
+}
+
+// flowSteps returns the step descriptions of an interaction flow. Steps are
+// read through JSON so both plain strings and step objects are supported.
+func flowSteps(flow prd.InteractionFlow) []string {
+	var info struct {
+		Steps []json.RawMessage `json:"steps"`
+	}
+	if err := roundTrip(flow, &info); err != nil {
+		return nil
+	}
+
+	var steps []string
+	for _, raw := range info.Steps {
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			if s != "" {
+				steps = append(steps, s)
+			}
+			continue
+		}
+		var obj map[string]interface{}
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			continue
+		}
+		for _, key := range []string{"description", "action", "title", "name"} {
+			if v, ok := obj[key].(string); ok && v != "" {
+				steps = append(steps, v)
+				break
+			}
+		}
+	}
+	return steps
+}
+
+func roundTrip(in, out interface{}) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+func parseDate(s string) (time.Time, bool) {
+	if s == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil && !t.IsZero() {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// mentionsID reports whether text mentions id as a whole word, so KR-1
+// isn't found in KR-12.
+func mentionsID(text, id string) bool {
+	if id == "" {
+		return false
+	}
+	for i := 0; ; {
+		n := strings.Index(text[i:], id)
+		if n < 0 {
+			return false
+		}
+		start, end := i+n, i+n+len(id)
+		if (start == 0 || !isWordByte(text[start-1])) && (end == len(text) || !isWordByte(text[end])) {
+			return true
+		}
+		i = start + 1
+	}
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || '0' <= b && b <= '9' || 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z'
+}
+
+func riskLevel(level string) float64 {
+	switch level {
+	case "low":
+		return 0.2
+	case "high":
+		return 0.75
+	case "critical":
+		return 0.9
+	default:
+		return 0.5
+	}
+}
+
+func clamp(v float64) float64 {
+	if v < 0.02 {
+		return 0.02
+	}
+	if v > 0.98 {
+		return 0.98
+	}
+	return v
+}
+
+// ganttText makes a task or title safe for a Gantt chart, where a colon
+// separates the task name from its metadata.
+func ganttText(s string) string {
+	s = strings.ReplaceAll(s, ":", " -")
+	s = strings.ReplaceAll(s, "\n", " ")
+	return s
+}
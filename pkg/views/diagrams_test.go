@@ -0,0 +1,149 @@
+package views
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+)
+
+func TestRoadmapGantt(t *testing.T) {
+	p := createTestPRD()
+	if RoadmapGantt(p) != "" {
+		t.Error("expected no Gantt chart without phases")
+	}
+
+	var phases []prd.Phase
+	mustUnmarshal(t, `[
+		{"id": "PHASE-1", "name": "MVP: Core", "startDate": "2026-01-05T00:00:00Z", "endDate": "2026-02-27T00:00:00Z"},
+		{"id": "PHASE-2", "name": "GA", "startDate": "2026-03-02T00:00:00Z"},
+		{"id": "PHASE-3", "name": "Unscheduled"}
+	]`, &phases)
+	p.Roadmap.Phases = phases
+
+	gantt := RoadmapGantt(p)
+
+	if !strings.HasPrefix(gantt, "gantt\n") {
+		t.Errorf("expected gantt header, got:\n%s", gantt)
+	}
+	if !strings.Contains(gantt, "MVP - Core :PHASE_1, 2026-01-05, 2026-02-27") {
+		t.Errorf("expected dated MVP phase, got:\n%s", gantt)
+	}
+	if !strings.Contains(gantt, "GA :PHASE_2, 2026-03-02, 7d") {
+		t.Errorf("expected default duration for phase without end date, got:\n%s", gantt)
+	}
+	if strings.Contains(gantt, "Unscheduled") {
+		t.Error("expected phase without start date to be skipped")
+	}
+}
+
+func TestInteractionFlowchart(t *testing.T) {
+	var flow prd.InteractionFlow
+	mustUnmarshal(t, `{"id": "FLOW-1", "title": "Login", "steps": ["Open app", "Enter \"email\"", "See dashboard"]}`, &flow)
+
+	chart := InteractionFlowchart(flow)
+
+	if !strings.HasPrefix(chart, "flowchart TD\n") {
+		t.Errorf("expected flowchart header, got:\n%s", chart)
+	}
+	if !strings.Contains(chart, `S2["Enter #quot;email#quot;"]`) {
+		t.Errorf("expected escaped quotes in step label, got:\n%s", chart)
+	}
+	if !strings.Contains(chart, "S2 --> S3") {
+		t.Errorf("expected steps to be connected, got:\n%s", chart)
+	}
+
+	if InteractionFlowchart(prd.InteractionFlow{ID: "FLOW-2"}) != "" {
+		t.Error("expected no flowchart for flow without steps")
+	}
+}
+
+func TestOKRTree(t *testing.T) {
+	p := createTestPRD()
+	if OKRTree(p) != "" {
+		t.Error("expected no OKR tree without objectives")
+	}
+
+	prd.AddSuccessMetric(p, "Login success", "Successful logins", "99%")
+	prd.AddFunctionalRequirement(p, "OAuth login", "Supports KR-1 by adding Google login", prd.MoSCoWMust)
+	prd.AddFunctionalRequirement(p, "Audit log", "Record login attempts", prd.MoSCoWShould)
+	prd.AddFunctionalRequirement(p, "Rate limits", "Throttle per KR-12 and KR_1", prd.MoSCoWCould)
+
+	tree := OKRTree(p)
+
+	if !strings.Contains(tree, `OBJ_1 --> KR_1["KR-1: Login success (99%)"]`) {
+		t.Errorf("expected objective to key result edge, got:\n%s", tree)
+	}
+	if !strings.Contains(tree, `KR_1 --> FR_1["FR-1: OAuth login"]`) {
+		t.Errorf("expected key result to requirement edge, got:\n%s", tree)
+	}
+	if !strings.Contains(tree, "unlinked -.- FR_2") || !strings.Contains(tree, "unlinked -.- FR_3") {
+		t.Errorf("expected unlinked requirements, got:\n%s", tree)
+	}
+	if !strings.Contains(tree, "inferred from ID mentions") {
+		t.Errorf("expected the inferred links to be noted, got:\n%s", tree)
+	}
+}
+
+func TestRiskQuadrant(t *testing.T) {
+	p := createTestPRD()
+	if RiskQuadrant(p) != "" {
+		t.Error("expected no risk matrix without risks")
+	}
+
+	prd.AddRisk(p, "Provider outage", prd.RiskProbabilityHigh, prd.RiskImpactCritical, "Fallback")
+	prd.AddRisk(p, "Slow adoption", prd.RiskProbabilityLow, prd.RiskImpactLow, "")
+	prd.AddRisk(p, "Second low risk", prd.RiskProbabilityLow, prd.RiskImpactLow, "")
+
+	chart := RiskQuadrant(p)
+
+	if !strings.HasPrefix(chart, "quadrantChart\n") {
+		t.Errorf("expected quadrantChart header, got:\n%s", chart)
+	}
+	if !strings.Contains(chart, "RISK 1: [0.75, 0.90]") {
+		t.Errorf("expected high/critical risk in upper right, got:\n%s", chart)
+	}
+	if !strings.Contains(chart, "RISK 3: [0.24, 0.16]") {
+		t.Errorf("expected overlapping risk to be offset, got:\n%s", chart)
+	}
+}
+
+func TestRenderPMMarkdownWithDiagrams(t *testing.T) {
+	p := createTestPRD()
+	view := GeneratePMView(p)
+
+	if md := RenderPMMarkdownWithDiagrams(view, GenerateDiagrams(p)); strings.Contains(md, "## Diagrams") {
+		t.Error("expected no diagrams section for PRD without diagram content")
+	}
+
+	prd.AddRisk(p, "Provider outage", prd.RiskProbabilityMedium, prd.RiskImpactHigh, "Fallback")
+	md := RenderPMMarkdownWithDiagrams(view, GenerateDiagrams(p))
+
+	if !strings.Contains(md, "## Diagrams") {
+		t.Error("expected diagrams section")
+	}
+	if !strings.Contains(md, "```mermaid\nquadrantChart") {
+		t.Error("expected fenced mermaid risk matrix")
+	}
+}
+
+func TestRenderDiagramsViewMarkdown(t *testing.T) {
+	p := createTestPRD()
+	if md := RenderDiagramsViewMarkdown(p); !strings.HasPrefix(md, "No diagrams:") {
+		t.Errorf("expected a note without diagram content, got:\n%s", md)
+	}
+
+	prd.AddRisk(p, "Provider outage", prd.RiskProbabilityMedium, prd.RiskImpactHigh, "Fallback")
+	md := RenderDiagramsViewMarkdown(p)
+	if !strings.HasPrefix(md, "# "+p.Metadata.Title+" Diagrams\n") || !strings.Contains(md, "quadrantChart") {
+		t.Errorf("expected a titled risk matrix, got:\n%s", md)
+	}
+}
+
+func mustUnmarshal(t *testing.T, data string, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal([]byte(data), v); err != nil {
+		t.Fatalf("failed to unmarshal test data: %v", err)
+	}
+}
@@ -5,7 +5,11 @@ package views
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/agentplexus/agent-team-prd/pkg/coverage"
 	"github.com/agentplexus/agent-team-prd/pkg/prd"
 )
 
@@ -17,7 +21,6 @@ type (
 	SolutionSummary  = prd.SolutionSummary
 	RequirementsList = prd.RequirementsList
 	RiskSummary      = prd.RiskSummary
-	ExecView         = prd.ExecView
 	ExecHeader       = prd.ExecHeader
 	ExecAction       = prd.ExecAction
 	ExecRisk         = prd.ExecRisk
@@ -33,10 +36,67 @@ func GeneratePMView(p *prd.PRD) *PMView {
 	return prd.GeneratePMView(p)
 }
 
+// ExecView is the structured-prd executive view with the state of the
+// PRD's recorded review and where each approver stands.
+type ExecView struct {
+	*prd.ExecView
+	Review    *ExecReview          `json:"review,omitempty"`
+	Approvals []prd.ApproverStatus `json:"approvals,omitempty"`
+}
+
+// ExecReview describes the review recorded in a PRD (see prd.RecordReview).
+type ExecReview struct {
+	Decision   string     `json:"decision"`
+	ReviewedAt *time.Time `json:"reviewedAt,omitempty"`
+	Version    string     `json:"version,omitempty"`
+	// Stale is set when the PRD has changed since the review, or the
+	// review was recorded without the content it judged.
+	Stale bool `json:"stale"`
+}
+
 // GenerateExecView creates an executive-friendly view of the PRD.
 // Delegates to structured-prd implementation.
-func GenerateExecView(p *prd.PRD, scores *prd.ScoringResult) *ExecView {
-	return prd.GenerateExecView(p, scores)
+func GenerateExecView(p *prd.PRD, scores *prd.ScoringResult) (*ExecView, error) {
+	view := &ExecView{ExecView: prd.GenerateExecView(p, scores)}
+
+	if p.Reviews != nil && p.Reviews.Decision != "" {
+		record, err := prd.GetReviewRecord(p)
+		if err != nil {
+			return nil, err
+		}
+		current, err := prd.ReviewIsCurrent(p)
+		if err != nil {
+			return nil, err
+		}
+		view.Review = &ExecReview{Decision: string(p.Reviews.Decision), Stale: !current}
+		if record != nil {
+			view.Review.ReviewedAt = &record.ReviewedAt
+			view.Review.Version = record.Version
+		}
+	}
+
+	approvals, err := prd.ApprovalStatus(p)
+	if err != nil {
+		return nil, err
+	}
+	if len(approvals) > 0 {
+		view.Approvals = approvals
+	}
+	return view, nil
+}
+
+// RenderPMViewMarkdown renders the PM view of a PRD as every frontend shows
+// it: the PM view, its diagrams and, if recorded, the test coverage.
+func RenderPMViewMarkdown(p *prd.PRD) (string, error) {
+	markdown := RenderPMMarkdownWithDiagrams(GeneratePMView(p), GenerateDiagrams(p))
+	report, err := coverage.FromPRD(p)
+	if err != nil {
+		return "", err
+	}
+	if report != nil {
+		markdown += "\n" + RenderCoverageMarkdown(report)
+	}
+	return markdown, nil
 }
 
 // RenderPMMarkdown generates markdown output for PM view.
@@ -46,9 +106,35 @@ func RenderPMMarkdown(view *PMView) string {
 }
 
 // RenderExecMarkdown generates markdown output for exec view.
-// Delegates to structured-prd implementation.
+// Delegates to structured-prd implementation and adds the review and
+// approvals.
 func RenderExecMarkdown(view *ExecView) string {
-	return prd.RenderExecMarkdown(view)
+	markdown := prd.RenderExecMarkdown(view.ExecView) + renderReviewMarkdown(view.Review)
+	if len(view.Approvals) > 0 {
+		markdown += "\n" + RenderApprovalsMarkdown(view.Approvals)
+	}
+	return markdown
+}
+
+func renderReviewMarkdown(r *ExecReview) string {
+	if r == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\n## Review\n\n")
+	fmt.Fprintf(&sb, "- Decision: %s\n", r.Decision)
+	if r.ReviewedAt != nil {
+		fmt.Fprintf(&sb, "- Reviewed: %s", r.ReviewedAt.Format("2006-01-02"))
+		if r.Version != "" {
+			fmt.Fprintf(&sb, " (v%s)", r.Version)
+		}
+		sb.WriteString("\n")
+	}
+	if r.Stale {
+		sb.WriteString("\n**Stale:** the PRD has changed since this review, or it can't be checked. Record a new review before relying on its scores.\n")
+	}
+	return sb.String()
 }
 
 // ToJSON converts a view to JSON.
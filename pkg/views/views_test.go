@@ -6,6 +6,7 @@ import (
 
 	"github.com/agentplexus/agent-team-prd/pkg/prd"
 	"github.com/agentplexus/agent-team-prd/pkg/scoring"
+	"github.com/agentplexus/structured-evaluation/evaluation"
 )
 
 func TestGeneratePMView(t *testing.T) {
@@ -131,7 +132,10 @@ func TestGenerateExecView(t *testing.T) {
 	p := createTestPRD()
 	scores := scoring.Score(p)
 
-	view := GenerateExecView(p, scores)
+	view, err := GenerateExecView(p, scores)
+	if err != nil {
+		t.Fatalf("GenerateExecView failed: %v", err)
+	}
 
 	if view.Header.PRDID != "PRD-2026-001" {
 		t.Errorf("expected PRD ID 'PRD-2026-001', got %s", view.Header.PRDID)
@@ -147,7 +151,10 @@ func TestGenerateExecView(t *testing.T) {
 func TestGenerateExecViewWithoutScores(t *testing.T) {
 	p := createTestPRD()
 
-	view := GenerateExecView(p, nil)
+	view, err := GenerateExecView(p, nil)
+	if err != nil {
+		t.Fatalf("GenerateExecView failed: %v", err)
+	}
 
 	if view.Header.OverallDecision != "Pending Review" {
 		t.Errorf("expected 'Pending Review' without scores, got %s", view.Header.OverallDecision)
@@ -161,7 +168,10 @@ func TestGenerateExecViewStrengths(t *testing.T) {
 	p := createWellDefinedPRD()
 	scores := scoring.Score(p)
 
-	view := GenerateExecView(p, scores)
+	view, err := GenerateExecView(p, scores)
+	if err != nil {
+		t.Fatalf("GenerateExecView failed: %v", err)
+	}
 
 	// Should have some strengths
 	if len(view.Strengths) == 0 {
@@ -178,13 +188,61 @@ func TestGenerateExecViewWithBlockers(t *testing.T) {
 	// Minimal PRD will have blockers
 	scores := scoring.Score(p)
 
-	view := GenerateExecView(p, scores)
+	view, err := GenerateExecView(p, scores)
+	if err != nil {
+		t.Fatalf("GenerateExecView failed: %v", err)
+	}
 
 	if len(scores.Blockers) > 0 && len(view.Blockers) == 0 {
 		t.Error("expected blockers to be passed to exec view")
 	}
 }
 
+func TestGenerateExecViewReview(t *testing.T) {
+	p := createTestPRD()
+
+	view, err := GenerateExecView(p, nil)
+	if err != nil {
+		t.Fatalf("GenerateExecView failed: %v", err)
+	}
+	if view.Review != nil {
+		t.Errorf("expected no review, got %+v", view.Review)
+	}
+
+	report := evaluation.NewEvaluationReport("prd", "PRD.json")
+	report.AddCategory(evaluation.NewCategoryScore("problem_definition", 1, 8, "Clear problem"))
+	report.Finalize("test")
+	if err := prd.RecordReview(p, report); err != nil {
+		t.Fatalf("RecordReview failed: %v", err)
+	}
+
+	view, err = GenerateExecView(p, scoring.ReviewOrScore(p))
+	if err != nil {
+		t.Fatalf("GenerateExecView failed: %v", err)
+	}
+	if view.Review == nil || view.Review.ReviewedAt == nil || view.Review.Stale {
+		t.Fatalf("expected a current review, got %+v", view.Review)
+	}
+	if md := RenderExecMarkdown(view); !strings.Contains(md, "## Review") || strings.Contains(md, "**Stale:**") {
+		t.Errorf("expected a current review in:\n%s", md)
+	}
+
+	prd.SetProblemStatement(p, "Users can't reset passwords", "High support volume", 0.8)
+	view, err = GenerateExecView(p, scoring.ReviewOrScore(p))
+	if err != nil {
+		t.Fatalf("GenerateExecView failed: %v", err)
+	}
+	if !view.Review.Stale {
+		t.Error("expected the review to be stale after a content change")
+	}
+	if md := RenderExecMarkdown(view); !strings.Contains(md, "**Stale:**") {
+		t.Errorf("expected a stale note in:\n%s", md)
+	}
+	if out, err := ToJSON(view); err != nil || !strings.Contains(out, `"stale": true`) {
+		t.Errorf("expected the review in JSON, got %v:\n%s", err, out)
+	}
+}
+
 func TestRenderPMMarkdown(t *testing.T) {
 	p := createTestPRD()
 	prd.AddProductGoal(p, "Test goal", "Test rationale")
@@ -212,7 +270,10 @@ func TestRenderExecMarkdown(t *testing.T) {
 	p := createTestPRD()
 	scores := scoring.Score(p)
 
-	view := GenerateExecView(p, scores)
+	view, err := GenerateExecView(p, scores)
+	if err != nil {
+		t.Fatalf("GenerateExecView failed: %v", err)
+	}
 	markdown := RenderExecMarkdown(view)
 
 	// Check for expected sections
@@ -250,6 +311,65 @@ func TestToJSON(t *testing.T) {
 	}
 }
 
+func TestRenderApprovalsMarkdown(t *testing.T) {
+	if got := RenderApprovalsMarkdown(nil); got != "" {
+		t.Errorf("expected no section without approvers, got %q", got)
+	}
+
+	p := createTestPRD()
+	p.Metadata.Approvers = []prd.Approver{{Name: "Alice", Role: "Eng Lead"}, {Name: "Bob"}}
+	if _, err := prd.RecordSignOff(p, "Alice", prd.SignOffApproved, "Ship it"); err != nil {
+		t.Fatalf("RecordSignOff failed: %v", err)
+	}
+
+	statuses, err := prd.ApprovalStatus(p)
+	if err != nil {
+		t.Fatalf("ApprovalStatus failed: %v", err)
+	}
+	md := RenderApprovalsMarkdown(statuses)
+	for _, want := range []string{"## Approvals", "**Alice (Eng Lead)**: approved", "Ship it", "**Bob**: pending", "**Pending approvals:** Bob"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected %q in:\n%s", want, md)
+		}
+	}
+}
+
+func TestGenerateExecViewApprovals(t *testing.T) {
+	p := createTestPRD()
+
+	view, err := GenerateExecView(p, nil)
+	if err != nil {
+		t.Fatalf("GenerateExecView failed: %v", err)
+	}
+	if view.Approvals != nil || strings.Contains(RenderExecMarkdown(view), "## Approvals") {
+		t.Errorf("expected no approvals without approvers, got %+v", view.Approvals)
+	}
+
+	p.Metadata.Approvers = []prd.Approver{{Name: "Alice"}, {Name: "Bob"}}
+	if _, err := prd.RecordSignOff(p, "Alice", prd.SignOffApproved, ""); err != nil {
+		t.Fatalf("RecordSignOff failed: %v", err)
+	}
+	view, err = GenerateExecView(p, nil)
+	if err != nil {
+		t.Fatalf("GenerateExecView failed: %v", err)
+	}
+	if len(view.Approvals) != 2 || view.Approvals[0].State != prd.ApprovalApproved || view.Approvals[1].State != prd.ApprovalPending {
+		t.Errorf("unexpected approvals: %+v", view.Approvals)
+	}
+	if md := RenderExecMarkdown(view); !strings.Contains(md, "## Approvals") || !strings.Contains(md, "**Pending approvals:** Bob") {
+		t.Errorf("expected the approvals in:\n%s", md)
+	}
+	if out, err := ToJSON(view); err != nil || !strings.Contains(out, `"approvals"`) {
+		t.Errorf("expected the approvals in JSON, got %v:\n%s", err, out)
+	}
+
+	// A sign-offs section that can't be read is an error, not a missing section.
+	prd.SetCustomSection(p, prd.SectionSignOffs, "Sign-offs", "not a list")
+	if _, err := GenerateExecView(p, nil); err == nil {
+		t.Error("expected an error for unreadable sign-offs")
+	}
+}
+
 // Helper functions
 
 func createTestPRD() *prd.PRD {
@@ -0,0 +1,157 @@
+package workflows
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/agent-team-prd/pkg/scoring"
+)
+
+// weakCategoryScore is the score below which a category is listed as weak
+// in the rendered PRD state. It matches the review workflow's cut-off.
+const weakCategoryScore = 7.0
+
+// Options customizes a rendered workflow.
+type Options struct {
+	// Path is the PRD file path to pass to prd_* tools.
+	Path string
+	// Focus is a scoring category ID (e.g. "metrics_quality") to concentrate on.
+	Focus string
+	// Audience describes the intended readers, e.g. "board of directors".
+	Audience string
+}
+
+// Render returns the workflow instructions followed by the current state of
+// the PRD. p may be nil when the PRD does not exist yet.
+func Render(w Workflow, p *prd.PRD, opts Options) (string, error) {
+	if opts.Focus != "" && !scoring.IsCategory(opts.Focus) {
+		return "", fmt.Errorf("unknown focus category: %s", opts.Focus)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strings.TrimRight(w.Instructions, "\n"))
+	sb.WriteString("\n\n")
+
+	var result *scoring.ScoringResult
+	if p != nil {
+		result = scoring.Score(p)
+	}
+
+	if opts.Audience != "" {
+		sb.WriteString("## Audience\n\n")
+		fmt.Fprintf(&sb, "Write for: %s. Adjust depth, terminology and emphasis for these readers.\n\n", opts.Audience)
+	}
+
+	if opts.Focus != "" {
+		writeFocus(&sb, opts.Focus, result)
+	}
+
+	writeState(&sb, p, result, opts.Path)
+
+	return sb.String(), nil
+}
+
+func writeFocus(sb *strings.Builder, focus string, result *scoring.ScoringResult) {
+	fmt.Fprintf(sb, "## Focus: %s\n\n", scoring.CategoryName(focus))
+	sb.WriteString("Concentrate on this category before the others.\n")
+
+	if result == nil {
+		sb.WriteString("\n")
+		return
+	}
+
+	for _, cat := range result.CategoryScores {
+		if cat.Category != focus {
+			continue
+		}
+		fmt.Fprintf(sb, "\nCurrent score: %.1f / 10.0 (weight %.0f%%)\n", cat.Score, cat.Weight*100)
+		if cat.Justification != "" {
+			fmt.Fprintf(sb, "Justification: %s\n", cat.Justification)
+		}
+	}
+
+	var issues []string
+	for _, t := range result.RevisionTriggers {
+		if t.Category == focus {
+			issues = append(issues, fmt.Sprintf("- [%s] %s", t.Severity, t.Description))
+		}
+	}
+	if len(issues) > 0 {
+		sb.WriteString("\nOpen issues:\n")
+		sb.WriteString(strings.Join(issues, "\n"))
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+}
+
+func writeState(sb *strings.Builder, p *prd.PRD, result *scoring.ScoringResult, path string) {
+	sb.WriteString("## Current PRD State\n\n")
+
+	if path != "" {
+		fmt.Fprintf(sb, "PRD file: `%s` (pass `path=%s` to every prd_* tool)\n\n", path, path)
+	}
+
+	if p == nil {
+		sb.WriteString("No PRD exists yet. Start with `prd_init`.\n")
+		return
+	}
+
+	fmt.Fprintf(sb, "- **ID**: %s\n", p.Metadata.ID)
+	fmt.Fprintf(sb, "- **Title**: %s\n", p.Metadata.Title)
+	fmt.Fprintf(sb, "- **Status**: %s\n", p.Metadata.Status)
+	fmt.Fprintf(sb, "- **Version**: %s\n", p.Metadata.Version)
+
+	problem := "missing"
+	if (p.Problem != nil && p.Problem.Statement != "") || p.ExecutiveSummary.ProblemStatement != "" {
+		problem = "defined"
+	}
+	fmt.Fprintf(sb, "- **Problem statement**: %s\n", problem)
+	fmt.Fprintf(sb, "- **Personas**: %d\n", len(p.Personas))
+	fmt.Fprintf(sb, "- **Objectives**: %d, out of scope: %d\n", len(p.Objectives.OKRs), len(p.OutOfScope))
+
+	solutions := 0
+	selected := "none"
+	if p.Solution != nil {
+		solutions = len(p.Solution.SolutionOptions)
+		if p.Solution.SelectedSolutionID != "" {
+			selected = p.Solution.SelectedSolutionID
+		}
+	}
+	fmt.Fprintf(sb, "- **Solution options**: %d (selected: %s)\n", solutions, selected)
+	fmt.Fprintf(sb, "- **Requirements**: %d functional, %d non-functional\n",
+		len(p.Requirements.Functional), len(p.Requirements.NonFunctional))
+	fmt.Fprintf(sb, "- **Risks**: %d\n", len(p.Risks))
+
+	decisions := 0
+	if p.Decisions != nil {
+		decisions = len(p.Decisions.Records)
+	}
+	fmt.Fprintf(sb, "- **Decisions**: %d\n", decisions)
+
+	if result == nil {
+		return
+	}
+
+	sb.WriteString("\n### Score\n\n")
+	fmt.Fprintf(sb, "Overall: %.1f / 10.0 (%s)\n", result.WeightedScore, result.Decision)
+
+	var weak []string
+	for _, cat := range result.CategoryScores {
+		if cat.Score < weakCategoryScore {
+			weak = append(weak, fmt.Sprintf("- %s: %.1f", scoring.CategoryName(cat.Category), cat.Score))
+		}
+	}
+	if len(weak) > 0 {
+		sb.WriteString("\nCategories below 7.0:\n")
+		sb.WriteString(strings.Join(weak, "\n"))
+		sb.WriteString("\n")
+	}
+
+	if len(result.Blockers) > 0 {
+		sb.WriteString("\nBlockers:\n")
+		for _, b := range result.Blockers {
+			fmt.Fprintf(sb, "- %s\n", b)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+// Package workflows provides the guided PRD workflows: creation, review and
+// executive summary.
+//
+// The same instructions are written as steering files by "prdtool deploy"
+// and served as prompts by prdtool-mcp, so every client sees one version.
+package workflows
+
+// Workflow names.
+const (
+	NameCreation    = "prd-creation"
+	NameReview      = "prd-review"
+	NameExecSummary = "exec-summary"
+)
+
+// Workflow is a guided PRD workflow.
+type Workflow struct {
+	Name         string
+	Title        string
+	Description  string
+	Instructions string
+}
+
+// SteeringFile returns the file name used when the workflow is deployed as
+// a steering file.
+func (w Workflow) SteeringFile() string {
+	return w.Name + ".md"
+}
+
+// All returns the workflows in the order they are typically used.
+func All() []Workflow {
+	return []Workflow{
+		{
+			Name:         NameCreation,
+			Title:        "PRD Creation",
+			Description:  "Guide the user through creating a comprehensive PRD",
+			Instructions: creationInstructions,
+		},
+		{
+			Name:         NameReview,
+			Title:        "PRD Review",
+			Description:  "Review a PRD against the quality rubric and recommend improvements",
+			Instructions: reviewInstructions,
+		},
+		{
+			Name:         NameExecSummary,
+			Title:        "Executive Summary",
+			Description:  "Prepare a decision-ready executive summary of a PRD",
+			Instructions: execSummaryInstructions,
+		},
+	}
+}
+
+// Get returns the workflow with the given name.
+func Get(name string) (Workflow, bool) {
+	for _, w := range All() {
+		if w.Name == name {
+			return w, true
+		}
+	}
+	return Workflow{}, false
+}
+
+const creationInstructions = `# PRD Creation Workflow
+
+Guide users through creating comprehensive PRDs:
+
+## Phase 1: Problem Discovery
+- Ask probing questions about the problem
+- Request evidence and data
+- Document user impact
+- Identify root causes
+
+## Phase 2: User Understanding
+- Create detailed personas
+- Document pain points and behaviors
+- Identify primary persona
+
+## Phase 3: Scope Definition
+- Define measurable goals
+- Document explicit non-goals
+- Set success criteria
+
+## Phase 4: Solution Design
+- Generate multiple options (2-3 minimum)
+- Document tradeoffs for each
+- Select with documented rationale
+
+## Phase 5: Requirements
+- Write testable requirements with acceptance criteria
+- Use MoSCoW prioritization (Must/Should/Could)
+- Link requirements to goals
+
+## Phase 6: Metrics
+- Define North Star metric
+- Add supporting and guardrail metrics
+- Set specific targets
+
+## Phase 7: Risk Assessment
+- Document risks with impact levels
+- Provide mitigation strategies
+- List open questions
+
+## Validation
+Always run prd_validate and prd_score after significant changes.
+`
+
+const reviewInstructions = `# PRD Review Workflow
+
+Guide users through reviewing and improving PRDs:
+
+## Step 1: Load and Examine
+` + "```\nprd_load\n```" + `
+
+## Step 2: Validate Structure
+` + "```\nprd_validate\n```" + `
+
+## Step 3: Score Quality
+` + "```\nprd_score\n```" + `
+
+## Step 4: Analyze Low Scores
+
+For each category below 7.0, check:
+
+### Problem Definition (20%)
+- Is evidence provided?
+- Is impact quantified?
+- Is confidence justified?
+
+### Solution Fit (15%)
+- Were multiple options considered?
+- Is rationale documented?
+- Are tradeoffs acknowledged?
+
+### User Understanding (10%)
+- Are personas specific?
+- Are pain points validated?
+
+### Scope Discipline (10%)
+- Are non-goals documented?
+- Are goals measurable?
+
+### Requirements Quality (10%)
+- Do requirements have acceptance criteria?
+- Is priority assigned?
+
+### Metrics Quality (10%)
+- Is there a North Star metric?
+- Are targets defined?
+
+## Step 5: Generate Recommendations
+Prioritize by: Blockers > High-weight categories > Quick wins
+`
+
+const execSummaryInstructions = `# Executive Summary Workflow
+
+Generate executive-level PRD summaries:
+
+## Generate Views
+` + "```\nprd_score\nprd_view --type exec\n```" + `
+
+## Summary Structure
+
+1. **Opening**: State initiative and recommendation
+2. **Problem Validation**: Summarize evidence
+3. **Solution Summary**: Approach and rationale
+4. **Decision Factors**: Status table
+5. **Key Risks**: Top 3 with mitigations
+6. **Recommendation**: Clear call to action
+
+## Decision Matrix Format
+
+| Criteria | Status |
+|----------|--------|
+| Problem validated | ✅/⚠️/❌ |
+| Solution viable | ✅/⚠️/❌ |
+| Scope bounded | ✅/⚠️/❌ |
+| Success measurable | ✅/⚠️/❌ |
+| Risks managed | ✅/⚠️/❌ |
+`
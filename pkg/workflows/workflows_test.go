@@ -0,0 +1,78 @@
+package workflows
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+)
+
+func TestAll(t *testing.T) {
+	all := All()
+	if len(all) != 3 {
+		t.Fatalf("expected 3 workflows, got %d", len(all))
+	}
+
+	for _, w := range all {
+		if w.Instructions == "" {
+			t.Errorf("workflow %s has no instructions", w.Name)
+		}
+		got, ok := Get(w.Name)
+		if !ok || got.Title != w.Title {
+			t.Errorf("Get(%s) did not return the workflow", w.Name)
+		}
+	}
+
+	if _, ok := Get("unknown"); ok {
+		t.Error("expected unknown workflow to be missing")
+	}
+	if got := all[0].SteeringFile(); got != "prd-creation.md" {
+		t.Errorf("expected prd-creation.md, got %s", got)
+	}
+}
+
+func TestRenderWithoutPRD(t *testing.T) {
+	w, _ := Get(NameCreation)
+
+	out, err := Render(w, nil, Options{Path: "auth.json"})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !strings.HasPrefix(out, "# PRD Creation Workflow") {
+		t.Error("expected output to start with the workflow instructions")
+	}
+	if !strings.Contains(out, "path=auth.json") {
+		t.Error("expected output to include the PRD path")
+	}
+	if !strings.Contains(out, "No PRD exists yet") {
+		t.Error("expected output to note the missing PRD")
+	}
+}
+
+func TestRenderWithPRD(t *testing.T) {
+	p := prd.New("PRD-2026-001", "Search", prd.Person{Name: "Owner"})
+	w, _ := Get(NameReview)
+
+	out, err := Render(w, p, Options{Focus: "metrics_quality", Audience: "engineering leads"})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	for _, want := range []string{
+		"## Focus: Metrics Quality",
+		"Write for: engineering leads.",
+		"- **ID**: PRD-2026-001",
+		"- **Title**: Search",
+		"- **Problem statement**: missing",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+}
+
+func TestRenderUnknownFocus(t *testing.T) {
+	w, _ := Get(NameReview)
+	if _, err := Render(w, nil, Options{Focus: "vibes"}); err == nil {
+		t.Error("expected error for unknown focus category")
+	}
+}
@@ -0,0 +1,87 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrOutsideRoot is returned by Confine when a path escapes the workspace root.
+var ErrOutsideRoot = errors.New("outside the workspace root")
+
+// Confine resolves path against root and checks that it stays inside root.
+// Relative paths are taken relative to root. Symlinks are followed, so a
+// link inside the root that points outside it is rejected, as is a dangling
+// link. The file itself does not need to exist.
+//
+// The returned path is absolute and cleaned, but symlinks in it are kept.
+func Confine(root, path string) (string, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return "", fmt.Errorf("invalid workspace root %s: %w", root, err)
+	}
+	realRoot, err := filepath.EvalSymlinks(absRoot)
+	if err != nil {
+		return "", fmt.Errorf("invalid workspace root %s: %w", root, err)
+	}
+
+	resolved := path
+	if !filepath.IsAbs(resolved) {
+		resolved = filepath.Join(absRoot, resolved)
+	}
+	resolved = filepath.Clean(resolved)
+
+	if !within(absRoot, resolved) && !within(realRoot, resolved) {
+		return "", fmt.Errorf("path %q is %w %s", path, ErrOutsideRoot, absRoot)
+	}
+
+	real, err := evalExisting(resolved)
+	if err != nil {
+		return "", fmt.Errorf("path %q: %w", path, err)
+	}
+	if !within(realRoot, real) {
+		return "", fmt.Errorf("path %q resolves through a symlink to %s, %w %s", path, real, ErrOutsideRoot, absRoot)
+	}
+
+	return resolved, nil
+}
+
+// evalExisting evaluates symlinks in the longest existing prefix of path and
+// appends the remaining, not yet existing, elements.
+func evalExisting(path string) (string, error) {
+	var rest []string
+	current := path
+	for {
+		real, err := filepath.EvalSymlinks(current)
+		if err == nil {
+			return filepath.Join(append([]string{real}, rest...)...), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+		// A dangling symlink would let a write create a file wherever it points.
+		if info, lerr := os.Lstat(current); lerr == nil && info.Mode()&os.ModeSymlink != 0 {
+			return "", fmt.Errorf("dangling symlink %s", current)
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return path, nil
+		}
+		rest = append([]string{filepath.Base(current)}, rest...)
+		current = parent
+	}
+}
+
+// within reports whether path is root or inside it. Both must be clean and
+// absolute.
+func within(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,68 @@
+package workspace
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfine(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+
+	if err := os.MkdirAll(filepath.Join(root, "docs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, filepath.Join(root, "escape")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(root, "docs"), filepath.Join(root, "inside")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(outside, "missing.json"), filepath.Join(root, "dangling.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{"relative", "PRD.json", filepath.Join(root, "PRD.json"), false},
+		{"nested new file", "docs/new/auth.json", filepath.Join(root, "docs", "new", "auth.json"), false},
+		{"absolute inside", filepath.Join(root, "docs", "a.json"), filepath.Join(root, "docs", "a.json"), false},
+		{"symlink inside root", "inside/a.json", filepath.Join(root, "inside", "a.json"), false},
+		{"traversal", "../secrets.json", "", true},
+		{"cleaned traversal", "docs/../../secrets.json", "", true},
+		{"absolute outside", filepath.Join(outside, "a.json"), "", true},
+		{"symlink escape", "escape/a.json", "", true},
+		{"dangling symlink", "dangling.json", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Confine(root, tt.path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestConfineErrOutsideRoot(t *testing.T) {
+	_, err := Confine(t.TempDir(), "../x.json")
+	if !errors.Is(err, ErrOutsideRoot) {
+		t.Errorf("expected ErrOutsideRoot, got %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package workspace
+
+import (
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+	"github.com/agentplexus/agent-team-prd/pkg/scoring"
+)
+
+// Portfolio is a summary of the PRDs in a workspace, suitable for dashboards.
+type Portfolio struct {
+	Root    string  `json:"root"`
+	Entries []Entry `json:"entries"`
+	// Failures are the files that look like PRDs but failed to load.
+	Failures []Failure `json:"failures,omitempty"`
+	Summary  Summary   `json:"summary"`
+}
+
+// Entry is one PRD in a portfolio.
+type Entry struct {
+	Path     string   `json:"path"`
+	ID       string   `json:"id"`
+	Title    string   `json:"title"`
+	Status   string   `json:"status"`
+	Owner    string   `json:"owner,omitempty"`
+	Version  string   `json:"version,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Score    *float64 `json:"score,omitempty"`
+	Decision string   `json:"decision,omitempty"`
+	Valid    *bool    `json:"valid,omitempty"`
+	Errors   int      `json:"errors,omitempty"`
+	Warnings int      `json:"warnings,omitempty"`
+}
+
+// Summary holds portfolio totals.
+type Summary struct {
+	Total        int            `json:"total"`
+	ByStatus     map[string]int `json:"byStatus"`
+	AverageScore *float64       `json:"averageScore,omitempty"`
+	Invalid      *int           `json:"invalid,omitempty"`
+	Failed       int            `json:"failed,omitempty"`
+}
+
+// PortfolioOptions controls which checks are run for each PRD.
+type PortfolioOptions struct {
+	Score    bool
+	Validate bool
+}
+
+// Summarize builds a portfolio for the given documents.
+func Summarize(root string, docs []*Document, opts PortfolioOptions) *Portfolio {
+	pf := &Portfolio{
+		Root:    root,
+		Entries: []Entry{},
+		Summary: Summary{ByStatus: make(map[string]int)},
+	}
+
+	var totalScore float64
+	invalid := 0
+
+	for _, d := range docs {
+		m := d.PRD.Metadata
+		e := Entry{
+			Path:    d.Path,
+			ID:      m.ID,
+			Title:   m.Title,
+			Status:  string(m.Status),
+			Owner:   d.Owner(),
+			Version: m.Version,
+			Tags:    m.Tags,
+		}
+
+		if opts.Score {
+			result := scoring.Score(d.PRD)
+			score := result.WeightedScore
+			e.Score = &score
+			e.Decision = result.Decision
+			totalScore += score
+		}
+
+		if opts.Validate {
+			result := prd.Validate(d.PRD)
+			valid := result.Valid
+			e.Valid = &valid
+			e.Errors = len(result.Errors)
+			e.Warnings = len(result.Warnings)
+			if !valid {
+				invalid++
+			}
+		}
+
+		pf.Entries = append(pf.Entries, e)
+		pf.Summary.ByStatus[e.Status]++
+	}
+
+	pf.Summary.Total = len(pf.Entries)
+	if opts.Score && pf.Summary.Total > 0 {
+		avg := totalScore / float64(pf.Summary.Total)
+		pf.Summary.AverageScore = &avg
+	}
+	if opts.Validate {
+		pf.Summary.Invalid = &invalid
+	}
+
+	return pf
+}
+
+// AddFailures records files that look like PRDs but failed to load (see
+// Workspace.Failures). They count as invalid if the portfolio was
+// validated.
+func (pf *Portfolio) AddFailures(failures []Failure) {
+	pf.Failures = append(pf.Failures, failures...)
+	pf.Summary.Failed += len(failures)
+	if pf.Summary.Invalid != nil {
+		invalid := *pf.Summary.Invalid + len(failures)
+		pf.Summary.Invalid = &invalid
+	}
+}
@@ -0,0 +1,162 @@
+// Package workspace discovers and summarizes the PRDs in a directory tree.
+package workspace
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+)
+
+// Document is a PRD discovered in a workspace.
+type Document struct {
+	// Path is the file path relative to the workspace root.
+	Path string
+	PRD  *prd.PRD
+}
+
+// Owner returns the name of the PRD's first author.
+func (d *Document) Owner() string {
+	if len(d.PRD.Metadata.Authors) > 0 {
+		return d.PRD.Metadata.Authors[0].Name
+	}
+	return ""
+}
+
+// HasTag reports whether the PRD carries the given tag (case-insensitive).
+func (d *Document) HasTag(tag string) bool {
+	for _, t := range d.PRD.Metadata.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// Failure is a file that looks like a PRD but could not be loaded.
+type Failure struct {
+	// Path is the file path relative to the workspace root.
+	Path  string `json:"path"`
+	Error string `json:"error"`
+}
+
+// Workspace is a directory tree containing PRD JSON files.
+type Workspace struct {
+	Root      string
+	Documents []*Document
+	// Failures are the files with a top-level "metadata" object that
+	// failed to load or have no ID or title.
+	Failures []Failure
+}
+
+// Discover walks root and loads every JSON file that is a PRD. A file is
+// treated as a PRD when it has a top-level "metadata" object; one that
+// then fails to load, or has no ID or title, is recorded in Failures.
+// Hidden directories, vendor and node_modules are skipped.
+func Discover(root string) (*Workspace, error) {
+	ws := &Workspace{Root: root}
+
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && skipDir(d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".json" || !isPRDFile(path) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			rel = path
+		}
+
+		p, err := prd.Load(path)
+		switch {
+		case err != nil:
+			ws.Failures = append(ws.Failures, Failure{Path: rel, Error: err.Error()})
+		case p.Metadata.ID == "" || p.Metadata.Title == "":
+			ws.Failures = append(ws.Failures, Failure{Path: rel, Error: "metadata has no id or title"})
+		default:
+			ws.Documents = append(ws.Documents, &Document{Path: rel, PRD: p})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(ws.Documents, func(i, j int) bool {
+		return ws.Documents[i].Path < ws.Documents[j].Path
+	})
+
+	return ws, nil
+}
+
+// Filter selects documents by status and tag. Empty values match everything.
+type Filter struct {
+	Status string
+	Tag    string
+}
+
+// Select returns the documents that match the filter.
+func (w *Workspace) Select(f Filter) []*Document {
+	var out []*Document
+	for _, d := range w.Documents {
+		if f.Status != "" && string(d.PRD.Metadata.Status) != f.Status {
+			continue
+		}
+		if f.Tag != "" && !d.HasTag(f.Tag) {
+			continue
+		}
+		out = append(out, d)
+	}
+	return out
+}
+
+// FindByID returns the document with the given PRD ID, or nil.
+func (w *Workspace) FindByID(id string) *Document {
+	for _, d := range w.Documents {
+		if d.PRD.Metadata.ID == id {
+			return d
+		}
+	}
+	return nil
+}
+
+// isPRDFile cheaply checks that a JSON file has a metadata object before
+// a full load, so unrelated JSON (package.json, configs) is ignored. A
+// file that isn't valid JSON counts if it mentions "metadata", so that a
+// damaged PRD is reported rather than skipped.
+func isPRDFile(path string) bool {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return false
+	}
+	var probe struct {
+		Metadata *json.RawMessage `json:"metadata"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return bytes.Contains(data, []byte(`"metadata"`))
+	}
+	return probe.Metadata != nil
+}
+
+func skipDir(name string) bool {
+	if strings.HasPrefix(name, ".") {
+		return true
+	}
+	switch name {
+	case "vendor", "node_modules":
+		return true
+	}
+	return false
+}
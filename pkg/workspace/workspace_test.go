@@ -0,0 +1,143 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agentplexus/agent-team-prd/pkg/prd"
+)
+
+func TestDiscover(t *testing.T) {
+	root := createTestWorkspace(t)
+
+	ws, err := Discover(root)
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+
+	if len(ws.Documents) != 3 {
+		t.Fatalf("expected 3 PRDs, got %d", len(ws.Documents))
+	}
+	if ws.Documents[0].Path != "auth/PRD.json" {
+		t.Errorf("expected documents sorted by path, got %s first", ws.Documents[0].Path)
+	}
+	if ws.FindByID("PRD-2026-002") == nil {
+		t.Error("expected to find PRD-2026-002 by ID")
+	}
+	if ws.FindByID("PRD-2026-999") != nil {
+		t.Error("expected no PRD for unknown ID")
+	}
+
+	if len(ws.Failures) != 2 || ws.Failures[0].Path != "notes/empty-metadata.json" || ws.Failures[1].Path != "payments/PRD.json" {
+		t.Errorf("expected the broken PRDs as failures, got %+v", ws.Failures)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	root := createTestWorkspace(t)
+	ws, err := Discover(root)
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   int
+	}{
+		{"no filter", Filter{}, 3},
+		{"by status", Filter{Status: "approved"}, 1},
+		{"by tag", Filter{Tag: "platform"}, 2},
+		{"by tag case-insensitive", Filter{Tag: "PLATFORM"}, 2},
+		{"by status and tag", Filter{Status: "draft", Tag: "platform"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := len(ws.Select(tt.filter)); got != tt.want {
+				t.Errorf("expected %d PRDs, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	root := createTestWorkspace(t)
+	ws, err := Discover(root)
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+
+	pf := Summarize(root, ws.Documents, PortfolioOptions{Validate: true})
+
+	if pf.Summary.Total != 3 {
+		t.Errorf("expected total 3, got %d", pf.Summary.Total)
+	}
+	if pf.Summary.ByStatus["draft"] != 2 {
+		t.Errorf("expected 2 draft PRDs, got %d", pf.Summary.ByStatus["draft"])
+	}
+	if pf.Summary.Invalid == nil || *pf.Summary.Invalid != 0 {
+		t.Errorf("expected 0 invalid PRDs, got %v", pf.Summary.Invalid)
+	}
+	if pf.Summary.AverageScore != nil {
+		t.Error("expected no average score without scoring")
+	}
+	if pf.Entries[0].Owner != "Owner A" {
+		t.Errorf("expected owner 'Owner A', got %s", pf.Entries[0].Owner)
+	}
+
+	pf.AddFailures(ws.Failures)
+	if *pf.Summary.Invalid != 2 || pf.Summary.Failed != 2 || len(pf.Failures) != 2 {
+		t.Errorf("expected the failures to count as invalid, got %+v", pf.Summary)
+	}
+}
+
+func createTestWorkspace(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+
+	save := func(rel string, p *prd.PRD) {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := prd.Save(p, path); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p1 := prd.New("PRD-2026-001", "Authentication", prd.Person{Name: "Owner A"})
+	p1.Metadata.Tags = []string{"platform"}
+	save("auth/PRD.json", p1)
+
+	p2 := prd.New("PRD-2026-002", "Search Feature", prd.Person{Name: "Owner B"})
+	p2.Metadata.Status = prd.StatusApproved
+	p2.Metadata.Tags = []string{"Platform", "search"}
+	save("search/PRD.json", p2)
+
+	p3 := prd.New("PRD-2026-003", "Billing Feature", prd.Person{Name: "Owner C"})
+	save("billing/billing-prd.json", p3)
+
+	// Not PRDs: unrelated JSON and skipped directories
+	files := map[string]string{
+		"web/package.json":          `{"name": "web"}`,
+		"web/broken.json":           `{"name": `,
+		"node_modules/dep/PRD.json": `{"metadata": {"id": "X", "title": "Dependency"}}`,
+		".git/PRD.json":             `{"metadata": {"id": "Y", "title": "Hidden"}}`,
+		// Broken PRDs, reported as failures
+		"notes/empty-metadata.json": `{"metadata": {}}`,
+		"payments/PRD.json":         `{"metadata": {"id": "PRD-2026-004", `,
+	}
+	for rel, content := range files {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return root
+}